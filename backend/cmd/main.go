@@ -24,6 +24,7 @@ import (
 
 	"intelligent-presenter-backend/internal/api"
 	"intelligent-presenter-backend/pkg/config"
+	"intelligent-presenter-backend/pkg/logging"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -52,6 +53,15 @@ func main() {
 	// Load application configuration from environment variables
 	cfg := config.Load()
 
+	// Route service logs through the leveled, credential-redacting logger
+	logging.Setup(cfg.LogLevel)
+
+	// Fail fast on a misconfigured production deployment instead of failing
+	// at request time
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
 	// Note: In Docker mode, MCP servers run in separate containers
 	// The MCP service will be initialized when needed by handlers
 
@@ -69,17 +79,18 @@ func main() {
 	corsConfig := cors.DefaultConfig()
     corsConfig.AllowOrigins = cfg.CORSOrigins
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "X-AI-Temperature", "X-AI-Credentials"}
 	corsConfig.AllowCredentials = true
 	router.Use(cors.New(corsConfig))
 
 	// Register health check endpoint for monitoring and load balancer health checks
-	// Returns server status, timestamp, and version information
+	// Returns server status, timestamp, version, and whether audio synthesis is enabled
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"timestamp": time.Now().UTC(),
-			"version":   "1.0.0",
+			"status":       "healthy",
+			"timestamp":    time.Now().UTC(),
+			"version":      "1.0.0",
+			"audioEnabled": !cfg.DisableAudio,
 		})
 	})
 
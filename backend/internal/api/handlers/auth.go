@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -81,6 +82,11 @@ type AuthHandler struct {
 }
 
 func NewAuthHandler(cfg *config.Config) *AuthHandler {
+	tokenURL := cfg.BacklogTokenURL
+	if tokenURL == "" {
+		tokenURL = fmt.Sprintf("https://%s/api/v2/oauth2/token", cfg.BacklogDomain)
+	}
+
 	return &AuthHandler{
 		config: cfg,
 		stateStore: NewStateStore(),
@@ -89,7 +95,7 @@ func NewAuthHandler(cfg *config.Config) *AuthHandler {
 			ClientSecret: cfg.BacklogClientSecret,
 			Endpoint: oauth2.Endpoint{
 				AuthURL:  fmt.Sprintf("https://%s/OAuth2AccessRequest.action", cfg.BacklogDomain),
-				TokenURL: fmt.Sprintf("https://%s/api/v2/oauth2/token", cfg.BacklogDomain),
+				TokenURL: tokenURL,
 			},
 			RedirectURL: cfg.OAuthRedirectURL,
 			Scopes:      []string{},
@@ -164,7 +170,7 @@ func (h *AuthHandler) HandleCallback(c *gin.Context) {
 	}
 	
 	// Generate JWT token
-	jwtToken, err := auth.GenerateToken(userInfo.ID, token.AccessToken, h.config.JWTSecret)
+	jwtToken, err := auth.GenerateToken(userInfo.ID, token.AccessToken, token.RefreshToken, h.config.JWTSecret, h.config.JWTTokenTTL)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to generate JWT token",
@@ -181,10 +187,70 @@ func (h *AuthHandler) HandleCallback(c *gin.Context) {
     c.Redirect(http.StatusFound, frontendCallbackURL)
 }
 
+// RefreshToken renews the Backlog access token using the refresh token stored
+// in the application JWT and returns a newly minted JWT. The current JWT is
+// accepted after it has expired, as long as its signature is valid and it
+// expired no longer ago than the configured refresh grace period.
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	// Implementation for token refresh
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Token refresh not implemented yet",
+	tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if tokenString == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authorization token required",
+		})
+		return
+	}
+
+	claims, err := auth.ParseRefreshableToken(tokenString, h.config.JWTSecret, h.config.JWTRefreshGrace)
+	if errors.Is(err, auth.ErrRefreshWindowExpired) {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Token is too old to be refreshed, please log in again",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+		})
+		return
+	}
+	if claims.BacklogRefreshToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Token cannot be refreshed, please log in again",
+		})
+		return
+	}
+
+	// An empty access token forces the token source to use the refresh_token grant
+	token, err := h.oauthConfig.TokenSource(context.Background(), &oauth2.Token{
+		RefreshToken: claims.BacklogRefreshToken,
+	}).Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.Response != nil &&
+			retrieveErr.Response.StatusCode >= 400 && retrieveErr.Response.StatusCode < 500 {
+			// Backlog rejects revoked or expired refresh tokens with a 4xx status
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Refresh token is invalid or expired, please log in again",
+			})
+			return
+		}
+		fmt.Printf("Failed to refresh Backlog token: %v\n", err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "Failed to refresh Backlog token",
+		})
+		return
+	}
+
+	jwtToken, err := auth.GenerateToken(claims.UserID, token.AccessToken, token.RefreshToken, h.config.JWTSecret, h.config.JWTTokenTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate JWT token",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token": jwtToken,
 	})
 }
 
@@ -267,10 +333,15 @@ func generateRandomState() string {
 func (h *AuthHandler) generateJWTState() string {
 	fmt.Printf("JWT Secret length: %d\n", len(h.config.JWTSecret))
 	
+	stateTTL := h.config.OAuthStateTTL
+	if stateTTL <= 0 {
+		stateTTL = 10 * time.Minute
+	}
+
 	// Create claims for the state token
 	claims := jwt.MapClaims{
 		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(10 * time.Minute).Unix(), // 10 minutes expiration
+		"exp": time.Now().Add(stateTTL).Unix(),
 		"iss": "intelligent-presenter",
 		"purpose": "oauth-state",
 	}
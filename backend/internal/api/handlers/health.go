@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds each downstream health check
+const readinessTimeout = 3 * time.Second
+
+// Dependency states reported by the readiness probe
+const (
+	dependencyUp       = "up"
+	dependencyDown     = "down"
+	dependencyDisabled = "disabled"
+	dependencyLocal    = "local"
+)
+
+// DependencyStatus reports the health of one downstream dependency
+type DependencyStatus struct {
+	Status    string `json:"status"`              // up, down, disabled, or local
+	LatencyMs int64  `json:"latencyMs,omitempty"` // Round trip of the health check
+	Error     string `json:"error,omitempty"`     // Why the dependency is down
+}
+
+type HealthHandler struct {
+	config *config.Config
+	client *http.Client
+}
+
+func NewHealthHandler(cfg *config.Config) *HealthHandler {
+	return &HealthHandler{
+		config: cfg,
+		client: &http.Client{Timeout: readinessTimeout},
+	}
+}
+
+// Ready is a readiness probe that checks the Backlog MCP server, the speech
+// server, and the AI provider configuration. It responds 200 when every
+// dependency is usable and 503 with the per-dependency status otherwise, so
+// load balancers can route away from instances that cannot serve requests.
+// The /health endpoint remains a liveness probe that does not check dependencies.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	dependencies := map[string]DependencyStatus{
+		"aiProvider": h.checkAIProvider(),
+	}
+
+	checks := map[string]string{}
+	if h.config.UsesLocalBacklogMCP() {
+		// The subprocess is started on first use and has no health endpoint
+		dependencies["backlogMCP"] = DependencyStatus{Status: dependencyLocal}
+	} else {
+		checks["backlogMCP"] = h.config.MCPBacklogURL
+	}
+	if h.config.DisableAudio {
+		dependencies["speech"] = DependencyStatus{Status: dependencyDisabled}
+	} else {
+		checks["speech"] = h.config.MCPSpeechURL
+	}
+
+	// Check the servers concurrently so the probe takes at most one timeout
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for name, baseURL := range checks {
+		wg.Add(1)
+		go func(name, baseURL string) {
+			defer wg.Done()
+			status := h.checkServer(baseURL)
+			mu.Lock()
+			dependencies[name] = status
+			mu.Unlock()
+		}(name, baseURL)
+	}
+	wg.Wait()
+
+	ready := true
+	for _, dependency := range dependencies {
+		if dependency.Status == dependencyDown {
+			ready = false
+		}
+	}
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "not_ready", http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{
+		"status":       status,
+		"timestamp":    time.Now().UTC(),
+		"dependencies": dependencies,
+	})
+}
+
+// checkServer calls the /health endpoint of an MCP server
+func (h *HealthHandler) checkServer(baseURL string) DependencyStatus {
+	if baseURL == "" {
+		return DependencyStatus{Status: dependencyDown, Error: "URL not configured"}
+	}
+
+	start := time.Now()
+	resp, err := h.client.Get(strings.TrimRight(baseURL, "/") + "/health")
+	latency := time.Since(start).Milliseconds()
+	if err != nil {
+		return DependencyStatus{Status: dependencyDown, LatencyMs: latency, Error: err.Error()}
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return DependencyStatus{Status: dependencyDown, LatencyMs: latency, Error: fmt.Sprintf("health check returned status %d", resp.StatusCode)}
+	}
+	return DependencyStatus{Status: dependencyUp, LatencyMs: latency}
+}
+
+// checkAIProvider verifies that the configured AI provider has credentials.
+// The provider API is not called, since every call may be billed.
+func (h *HealthHandler) checkAIProvider() DependencyStatus {
+	var configured bool
+	switch h.config.AIProvider {
+	case "openai", "":
+		configured = h.config.OpenAIAPIKey != ""
+	case "bedrock":
+		configured = h.config.AWSAccessKeyID != "" && h.config.AWSSecretAccessKey != ""
+	case "gemini":
+		configured = h.config.GeminiAPIKey != ""
+	case "anthropic":
+		configured = h.config.AnthropicAPIKey != ""
+	default:
+		return DependencyStatus{Status: dependencyDown, Error: fmt.Sprintf("unknown AI provider %q", h.config.AIProvider)}
+	}
+	if !configured {
+		return DependencyStatus{Status: dependencyDown, Error: "credentials not configured"}
+	}
+	return DependencyStatus{Status: dependencyUp}
+}
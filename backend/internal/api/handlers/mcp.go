@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"intelligent-presenter-backend/internal/services"
 	"intelligent-presenter-backend/pkg/config"
@@ -70,6 +72,12 @@ func (h *MCPHandler) GetProjectIssues(c *gin.Context) {
 	projectID := c.Param("projectId")
 	backlogToken := c.GetString("backlogToken")
 
+	// offset or count switches to one page of issues with the total count
+	if c.Query("offset") != "" || c.Query("count") != "" {
+		h.getProjectIssuePage(c, projectID, backlogToken)
+		return
+	}
+
 	issues, err := h.mcpService.GetProjectIssues(projectID, backlogToken)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -81,6 +89,44 @@ func (h *MCPHandler) GetProjectIssues(c *gin.Context) {
 	c.JSON(http.StatusOK, issues)
 }
 
+// defaultIssuePageCount is the page size used when only offset is given
+const defaultIssuePageCount = 20
+
+// getProjectIssuePage responds with the page of issues selected by the offset
+// and count query parameters
+func (h *MCPHandler) getProjectIssuePage(c *gin.Context, projectID, backlogToken string) {
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "offset must be an integer",
+		})
+		return
+	}
+	count, err := strconv.Atoi(c.DefaultQuery("count", strconv.Itoa(defaultIssuePageCount)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "count must be an integer",
+		})
+		return
+	}
+
+	page, err := h.mcpService.GetIssuePage(projectID, backlogToken, offset, count)
+	if errors.Is(err, services.ErrInvalidIssuePage) || errors.Is(err, services.ErrEmptyProjectID) || errors.Is(err, services.ErrEmptyBacklogToken) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project issues",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
 func (h *MCPHandler) GetProjectTeam(c *gin.Context) {
 	projectID := c.Param("projectId")
 	backlogToken := c.GetString("backlogToken")
@@ -113,10 +159,11 @@ func (h *MCPHandler) GetProjectRisks(c *gin.Context) {
 
 func (h *MCPHandler) SynthesizeSpeech(c *gin.Context) {
 	var req struct {
-		Text      string `json:"text" binding:"required"`
-		Language  string `json:"language" binding:"required"`
-		Voice     string `json:"voice"`
-		Streaming bool   `json:"streaming"`
+		Text      string  `json:"text" binding:"required"`
+		Language  string  `json:"language" binding:"required"`
+		Voice     string  `json:"voice"`
+		Speed     float64 `json:"speed"`
+		Streaming bool    `json:"streaming"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -126,7 +173,13 @@ func (h *MCPHandler) SynthesizeSpeech(c *gin.Context) {
 		return
 	}
 
-	audioURL, err := h.mcpService.SynthesizeSpeech(req.Text, req.Language, req.Voice)
+	audioURL, err := h.mcpService.SynthesizeSpeech(req.Text, req.Language, req.Voice, req.Speed)
+	if errors.Is(err, services.ErrAudioDisabled) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Audio synthesis is disabled on this server",
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to synthesize speech",
@@ -162,6 +215,14 @@ func (h *MCPHandler) GetAudioFile(c *gin.Context) {
 		return
 	}
 	
+	// Forward byte ranges so audio players can seek without downloading the whole file
+	if rangeHeader := c.GetHeader("Range"); rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+		if ifRange := c.GetHeader("If-Range"); ifRange != "" {
+			req.Header.Set("If-Range", ifRange)
+		}
+	}
+	
 	// Forward the request
 	resp, err := client.Do(req)
 	if err != nil {
@@ -175,8 +236,17 @@ func (h *MCPHandler) GetAudioFile(c *gin.Context) {
 	
 	fmt.Printf("GetAudioFile: Speech server response status: %d\n", resp.StatusCode)
 	
+	// An unsatisfiable range carries the file size in Content-Range
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		c.Header("Content-Range", resp.Header.Get("Content-Range"))
+		c.JSON(resp.StatusCode, gin.H{
+			"error": "Requested range not satisfiable",
+		})
+		return
+	}
+	
 	// Forward status code
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
 		fmt.Printf("GetAudioFile: Speech server returned non-200: %d\n", resp.StatusCode)
 		c.JSON(resp.StatusCode, gin.H{
 			"error": "Audio file not found",
@@ -188,9 +258,13 @@ func (h *MCPHandler) GetAudioFile(c *gin.Context) {
 	c.Header("Content-Type", "audio/wav")
 	c.Header("Cache-Control", "public, max-age=3600")
 	c.Header("Content-Length", resp.Header.Get("Content-Length"))
+	c.Header("Accept-Ranges", "bytes")
+	if resp.StatusCode == http.StatusPartialContent {
+		c.Header("Content-Range", resp.Header.Get("Content-Range"))
+	}
 	
-	fmt.Printf("GetAudioFile: Streaming audio file, content-length: %s\n", resp.Header.Get("Content-Length"))
+	fmt.Printf("GetAudioFile: Streaming audio file, status: %d, content-length: %s\n", resp.StatusCode, resp.Header.Get("Content-Length"))
 	
 	// Stream the audio file content
-	c.DataFromReader(http.StatusOK, resp.ContentLength, "audio/wav", resp.Body, nil)
+	c.DataFromReader(resp.StatusCode, resp.ContentLength, "audio/wav", resp.Body, nil)
 }
\ No newline at end of file
@@ -1,9 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"intelligent-presenter-backend/internal/models"
 	"intelligent-presenter-backend/internal/services"
@@ -19,28 +27,358 @@ type SlideHandler struct {
 	slideService   *services.SlideService
 	activeSlides   map[string]*SlideSession
 	slidesMutex    sync.RWMutex
+	sessionStore   services.SessionStore
 	wsUpgrader     websocket.Upgrader
 }
 
 type SlideSession struct {
-	ID          string
-	ProjectID   models.ProjectID
+	ID        string
+	ProjectID models.ProjectID
+	// Further projects compared with ProjectID on portfolio comparison slides
+	ProjectIDs  []models.ProjectID
 	Themes      []models.SlideTheme
 	Language    string
-	Status      string
+	Speed       float64
+	SlideSpeeds map[int]float64
+	// Admin override of the AI sampling temperature; nil uses the default
+	Temperature *float64
+	// Generate every slide anew instead of reusing cached slides
+	ForceRegenerate bool
+	// End each narration with a transition cue for auto-advancing playback
+	TransitionCues bool
+	Status         string
+	CreatedAt      time.Time
+	CompletedAt    time.Time
+	// Guards Status, CompletedAt, version, and deleted, and serializes snapshots
+	mutex sync.Mutex
+	// Version of the latest persisted snapshot
+	version int64
+	// Set when the session is deleted so that generation still in flight does
+	// not persist it again
+	deleted     bool
 	Connections map[*websocket.Conn]bool
 	ConnMutex   sync.RWMutex
-	// Store generated slides data
-	Slides      []*models.SlideContent    `json:"slides"`
-	Narrations  []*models.SlideNarration  `json:"narrations"`
-	AudioFiles  []*models.SlideAudio      `json:"audioFiles"`
+	// Recent broadcast messages replayed to late-joining clients, guarded by ConnMutex
+	replay messageBuffer
+	// Store generated slides data; Slides and Narrations are guarded by dataMutex
+	// because slides are generated concurrently
+	dataMutex     sync.Mutex
+	Slides        []*models.SlideContent   `json:"slides"`
+	Narrations    []*models.SlideNarration `json:"narrations"`
+	AudioFiles    []*models.SlideAudio     `json:"audioFiles"`
+	Warnings      []*models.SlideWarning   `json:"warnings"`
+	warningsMutex sync.Mutex
+	// Per-slide stage durations, guarded by timingsMutex
+	Timings      []*models.ThemeTiming `json:"timings"`
+	timingsMutex sync.Mutex
+
+	// Track slide indexes currently being regenerated
+	regenerating map[int]bool
+	regenMutex   sync.Mutex
+
+	// Limit concurrent audio synthesis within this session
+	AudioLimiter *services.ConcurrencyLimiter
+	audioMutex   sync.Mutex
+}
+
+// maxReplayMessages bounds how many broadcast messages are kept per session for replay
+const maxReplayMessages = 200
+
+// wsWriteTimeout bounds how long a single WebSocket write or ping may block
+const wsWriteTimeout = 10 * time.Second
+
+// messageBuffer is a bounded ring buffer of WebSocket messages. When full, the
+// oldest message is overwritten.
+type messageBuffer struct {
+	messages []models.WebSocketMessage
+	start    int
+}
+
+// add appends a message, evicting the oldest one when the buffer is full
+func (b *messageBuffer) add(message models.WebSocketMessage) {
+	if len(b.messages) < maxReplayMessages {
+		b.messages = append(b.messages, message)
+		return
+	}
+	b.messages[b.start] = message
+	b.start = (b.start + 1) % maxReplayMessages
+}
+
+// snapshot returns the buffered messages from oldest to newest
+func (b *messageBuffer) snapshot() []models.WebSocketMessage {
+	ordered := make([]models.WebSocketMessage, 0, len(b.messages))
+	ordered = append(ordered, b.messages[b.start:]...)
+	return append(ordered, b.messages[:b.start]...)
+}
+
+// GenerateAudio synthesizes narration audio while respecting the session's
+// audio concurrency cap, in addition to the global cap applied by the service.
+func (s *SlideSession) GenerateAudio(slideService *services.SlideService, narration *models.SlideNarration) (*models.SlideAudio, error) {
+	s.AudioLimiter.Acquire()
+	defer s.AudioLimiter.Release()
+	start := time.Now()
+	audio, err := slideService.GenerateSlideAudio(narration)
+	if err == nil {
+		s.RecordTiming(narration.SlideIndex, models.TimingStageAudio, time.Since(start))
+	}
+	return audio, err
+}
+
+// SpeedFor returns the narration speed for the slide at the given index,
+// preferring a per-slide override over the deck-level speed.
+func (s *SlideSession) SpeedFor(index int) float64 {
+	if speed, exists := s.SlideSpeeds[index]; exists && speed > 0 {
+		return speed
+	}
+	return s.Speed
+}
+
+// prepareNarration applies the session's narration settings to freshly
+// generated narration for the slide at the given index
+func (s *SlideSession) prepareNarration(index int, narration *models.SlideNarration) {
+	narration.Speed = s.SpeedFor(index)
+	if s.TransitionCues {
+		services.AppendTransitionCue(narration)
+	}
+}
+
+// tryStartRegeneration marks the slide index as being regenerated, returning
+// false if a regeneration for the same index is already in progress.
+func (s *SlideSession) tryStartRegeneration(index int) bool {
+	s.regenMutex.Lock()
+	defer s.regenMutex.Unlock()
+	if s.regenerating == nil {
+		s.regenerating = make(map[int]bool)
+	}
+	if s.regenerating[index] {
+		return false
+	}
+	s.regenerating[index] = true
+	return true
+}
+
+// finishRegeneration clears the in-progress marker for the slide index.
+func (s *SlideSession) finishRegeneration(index int) {
+	s.regenMutex.Lock()
+	defer s.regenMutex.Unlock()
+	delete(s.regenerating, index)
+}
+
+// ReplaceSlide stores generated content for the slide at the given index,
+// replacing any existing slide with the same index or inserting it so that
+// slides stay ordered by index regardless of completion order.
+func (s *SlideSession) ReplaceSlide(index int, content *models.SlideContent) {
+	s.dataMutex.Lock()
+	defer s.dataMutex.Unlock()
+	content.Index = index
+	position := sort.Search(len(s.Slides), func(i int) bool {
+		return s.Slides[i].Index >= index
+	})
+	if position < len(s.Slides) && s.Slides[position].Index == index {
+		s.Slides[position] = content
+		return
+	}
+	s.Slides = append(s.Slides, nil)
+	copy(s.Slides[position+1:], s.Slides[position:])
+	s.Slides[position] = content
+}
+
+// ReplaceNarration stores narration for the slide it belongs to, keeping
+// narrations ordered by slide index.
+func (s *SlideSession) ReplaceNarration(narration *models.SlideNarration) {
+	s.dataMutex.Lock()
+	defer s.dataMutex.Unlock()
+	position := sort.Search(len(s.Narrations), func(i int) bool {
+		return s.Narrations[i].SlideIndex >= narration.SlideIndex
+	})
+	if position < len(s.Narrations) && s.Narrations[position].SlideIndex == narration.SlideIndex {
+		s.Narrations[position] = narration
+		return
+	}
+	s.Narrations = append(s.Narrations, nil)
+	copy(s.Narrations[position+1:], s.Narrations[position:])
+	s.Narrations[position] = narration
+}
+
+// ReplaceAudio stores audio for the slide it belongs to, keeping audio files
+// ordered by slide index.
+func (s *SlideSession) ReplaceAudio(audio *models.SlideAudio) {
+	s.audioMutex.Lock()
+	defer s.audioMutex.Unlock()
+	position := sort.Search(len(s.AudioFiles), func(i int) bool {
+		return s.AudioFiles[i].SlideIndex >= audio.SlideIndex
+	})
+	if position < len(s.AudioFiles) && s.AudioFiles[position].SlideIndex == audio.SlideIndex {
+		s.AudioFiles[position] = audio
+		return
+	}
+	s.AudioFiles = append(s.AudioFiles, nil)
+	copy(s.AudioFiles[position+1:], s.AudioFiles[position:])
+	s.AudioFiles[position] = audio
+}
+
+// audioFiles returns a snapshot of the session's audio files in slide order.
+func (s *SlideSession) audioFiles() []*models.SlideAudio {
+	s.audioMutex.Lock()
+	defer s.audioMutex.Unlock()
+	return append(make([]*models.SlideAudio, 0, len(s.AudioFiles)), s.AudioFiles...)
+}
+
+// AddWarning records a non-fatal generation problem for the slide at the given index.
+func (s *SlideSession) AddWarning(index int, message string) {
+	s.warningsMutex.Lock()
+	defer s.warningsMutex.Unlock()
+	s.Warnings = append(s.Warnings, &models.SlideWarning{SlideIndex: index, Message: message})
+}
+
+// RecordTiming stores how long a generation stage of the slide at the given
+// index took. Recording the content stage starts a fresh entry, so timings from
+// an earlier generation of a regenerated slide are discarded.
+func (s *SlideSession) RecordTiming(index int, stage string, elapsed time.Duration) {
+	s.timingsMutex.Lock()
+	defer s.timingsMutex.Unlock()
+
+	position := -1
+	for i, timing := range s.Timings {
+		if timing.Index == index {
+			position = i
+			break
+		}
+	}
+	if position < 0 || stage == models.TimingStageContent {
+		timing := &models.ThemeTiming{Index: index}
+		if index >= 0 && index < len(s.Themes) {
+			timing.Theme = s.Themes[index]
+		}
+		if position < 0 {
+			position = len(s.Timings)
+			s.Timings = append(s.Timings, timing)
+		} else {
+			s.Timings[position] = timing
+		}
+	}
+
+	timing := s.Timings[position]
+	switch stage {
+	case models.TimingStageContent:
+		timing.ContentMs = elapsed.Milliseconds()
+	case models.TimingStageNarration:
+		timing.NarrationMs = elapsed.Milliseconds()
+	case models.TimingStageAudio:
+		timing.AudioMs = elapsed.Milliseconds()
+	}
+	timing.TotalMs = timing.ContentMs + timing.NarrationMs + timing.AudioMs
+}
+
+// clearWarnings removes the warnings recorded for the slide at the given index,
+// used when the slide is regenerated.
+func (s *SlideSession) clearWarnings(index int) {
+	s.warningsMutex.Lock()
+	defer s.warningsMutex.Unlock()
+	kept := s.Warnings[:0]
+	for _, warning := range s.Warnings {
+		if warning.SlideIndex != index {
+			kept = append(kept, warning)
+		}
+	}
+	s.Warnings = kept
+}
+
+// BuildReport summarizes the generated slides, narration, and audio of the
+// session. Token counts combine the slide content and narration estimates, and
+// their cost is estimated at costPerMillion USD per million tokens. Warnings
+// for slides that failed to generate are reported at the session level.
+func (s *SlideSession) BuildReport(costPerMillion float64) *models.GenerationReport {
+	s.dataMutex.Lock()
+	slides := append(make([]*models.SlideContent, 0, len(s.Slides)), s.Slides...)
+	narrations := append(make([]*models.SlideNarration, 0, len(s.Narrations)), s.Narrations...)
+	s.dataMutex.Unlock()
+	s.audioMutex.Lock()
+	audioFiles := append(make([]*models.SlideAudio, 0, len(s.AudioFiles)), s.AudioFiles...)
+	s.audioMutex.Unlock()
+	s.warningsMutex.Lock()
+	warnings := append(make([]*models.SlideWarning, 0, len(s.Warnings)), s.Warnings...)
+	s.warningsMutex.Unlock()
+	s.timingsMutex.Lock()
+	timings := make([]*models.ThemeTiming, 0, len(s.Timings))
+	for _, timing := range s.Timings {
+		copied := *timing
+		timings = append(timings, &copied)
+	}
+	s.timingsMutex.Unlock()
+	sort.Slice(timings, func(i, j int) bool {
+		return timings[i].Index < timings[j].Index
+	})
+
+	status, _ := s.currentStatus()
+	report := &models.GenerationReport{
+		SlideID:   s.ID,
+		ProjectID: s.ProjectID,
+		Status:    status,
+		Language:  s.Language,
+		Slides:    make([]*models.SlideReport, 0, len(slides)),
+		Warnings:  make([]string, 0),
+		Timings:   timings,
+	}
+
+	slideReports := make(map[int]*models.SlideReport)
+	for _, slide := range slides {
+		slideReport := &models.SlideReport{
+			Index:      slide.Index,
+			Title:      slide.Title,
+			Theme:      slide.Theme,
+			WordCount:  services.CountWords(slide.PlainText),
+			TokensUsed: slide.TokensUsed,
+			Warnings:   make([]string, 0),
+		}
+		slideReports[slide.Index] = slideReport
+		report.Slides = append(report.Slides, slideReport)
+	}
+	sort.Slice(report.Slides, func(i, j int) bool {
+		return report.Slides[i].Index < report.Slides[j].Index
+	})
+
+	for _, narration := range narrations {
+		if slideReport, exists := slideReports[narration.SlideIndex]; exists {
+			slideReport.TokensUsed += narration.TokensUsed
+		}
+	}
+	for _, audio := range audioFiles {
+		if slideReport, exists := slideReports[audio.SlideIndex]; exists {
+			slideReport.AudioDuration = audio.Duration
+		}
+	}
+	for _, warning := range warnings {
+		if slideReport, exists := slideReports[warning.SlideIndex]; exists {
+			slideReport.Warnings = append(slideReport.Warnings, warning.Message)
+		} else {
+			report.Warnings = append(report.Warnings, warning.Message)
+		}
+	}
+
+	for _, slideReport := range report.Slides {
+		report.TotalWords += slideReport.WordCount
+		report.TotalAudioDuration += slideReport.AudioDuration
+		report.TotalTokens += slideReport.TokensUsed
+	}
+	report.TotalSlides = len(report.Slides)
+	report.CostPerMillion = costPerMillion
+	report.EstimatedCost = services.EstimateCost(report.TotalTokens, costPerMillion)
+	return report
 }
 
 func NewSlideHandler(cfg *config.Config) *SlideHandler {
-	return &SlideHandler{
+	sessionStore, err := services.NewSessionStore(cfg)
+	if err != nil {
+		slog.Warn("Failed to create session store, falling back to memory", "error", err)
+		sessionStore = services.NewMemorySessionStore()
+	}
+
+	h := &SlideHandler{
 		config:       cfg,
 		slideService: services.NewSlideService(cfg),
 		activeSlides: make(map[string]*SlideSession),
+		sessionStore: sessionStore,
 		wsUpgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				// In production, implement proper origin checking
@@ -48,63 +386,738 @@ func NewSlideHandler(cfg *config.Config) *SlideHandler {
 			},
 		},
 	}
+	h.loadSessions()
+
+	// Start background cleanup of finished sessions
+	if cfg.SessionTTL > 0 {
+		go h.cleanupSessions()
+	}
+
+	return h
+}
+
+// loadSessions restores previously persisted sessions into memory so that
+// decks generated before a restart remain available.
+func (h *SlideHandler) loadSessions() {
+	records, err := h.sessionStore.List()
+	if err != nil {
+		slog.Error("Failed to load persisted slide sessions", "error", err)
+		return
+	}
+
+	h.slidesMutex.Lock()
+	defer h.slidesMutex.Unlock()
+	for _, record := range records {
+		session := sessionFromRecord(record)
+		session.AudioLimiter = services.NewConcurrencyLimiter(h.config.AudioSessionMaxConcurrency)
+		// Generation cannot resume after a restart, so mark unfinished sessions as failed
+		if session.Status == "generating" {
+			session.Status = "error"
+			session.CompletedAt = time.Now()
+		}
+		h.activeSlides[session.ID] = session
+	}
+	slog.Info("Loaded persisted slide sessions", "count", len(records))
+}
+
+// persistSession writes the current session state through to the session store.
+// Each snapshot is numbered under the session lock, so that the store can drop
+// a snapshot that reaches it after a newer one. Deleted sessions are skipped,
+// and the lock is held while saving so that a save cannot outlive a deletion.
+func (h *SlideHandler) persistSession(session *SlideSession) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if session.deleted {
+		return
+	}
+	session.version++
+
+	if err := h.sessionStore.Save(session.snapshot()); err != nil {
+		slog.Error("Failed to persist slide session", "slideID", session.ID, "error", err)
+	}
+}
+
+// toRecord creates a persistable snapshot of the session.
+func (s *SlideSession) toRecord() *models.SlideSessionRecord {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.snapshot()
+}
+
+// snapshot copies the session state into a record carrying the version of the
+// latest persisted snapshot. The caller must hold s.mutex.
+func (s *SlideSession) snapshot() *models.SlideSessionRecord {
+	// Slides, narrations, and audio files may be added concurrently, so snapshot them under their locks
+	s.dataMutex.Lock()
+	slides := append(make([]*models.SlideContent, 0, len(s.Slides)), s.Slides...)
+	narrations := append(make([]*models.SlideNarration, 0, len(s.Narrations)), s.Narrations...)
+	s.dataMutex.Unlock()
+	s.audioMutex.Lock()
+	audioFiles := append(make([]*models.SlideAudio, 0, len(s.AudioFiles)), s.AudioFiles...)
+	s.audioMutex.Unlock()
+	s.warningsMutex.Lock()
+	warnings := append(make([]*models.SlideWarning, 0, len(s.Warnings)), s.Warnings...)
+	s.warningsMutex.Unlock()
+	s.timingsMutex.Lock()
+	// RecordTiming updates timings in place, so copy each entry
+	timings := make([]*models.ThemeTiming, 0, len(s.Timings))
+	for _, timing := range s.Timings {
+		copied := *timing
+		timings = append(timings, &copied)
+	}
+	s.timingsMutex.Unlock()
+
+	return &models.SlideSessionRecord{
+		ID:             s.ID,
+		ProjectID:      s.ProjectID,
+		ProjectIDs:     s.ProjectIDs,
+		Themes:         s.Themes,
+		Language:       s.Language,
+		Speed:          s.Speed,
+		SlideSpeeds:    s.SlideSpeeds,
+		Temperature:    s.Temperature,
+		TransitionCues: s.TransitionCues,
+		Status:         s.Status,
+		Slides:         slides,
+		Narrations:     narrations,
+		AudioFiles:     audioFiles,
+		Warnings:       warnings,
+		Timings:        timings,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      time.Now(),
+		CompletedAt:    s.CompletedAt,
+		Version:        s.version,
+	}
+}
+
+// finish records that generation of the session ended with the given status
+func (s *SlideSession) finish(status string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Status = status
+	s.CompletedAt = time.Now()
+}
+
+// currentStatus returns the generation status and the time generation ended
+func (s *SlideSession) currentStatus() (string, time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.Status, s.CompletedAt
+}
+
+// generateContent generates the content of the slide at index of the session.
+// A slide of a split theme covers only its part of the theme, and a portfolio
+// comparison slide compares the session's project with ProjectIDs.
+func (s *SlideSession) generateContent(slideService *services.SlideService, index int, backlogToken string, opts services.GenerationOptions) (*models.SlideContent, error) {
+	theme := s.Themes[index]
+	opts.Part, opts.Parts = services.ThemePart(s.Themes, index)
+	if theme == models.ThemePortfolioComparison {
+		projectIDs := []string{s.ProjectID.String()}
+		for _, id := range s.ProjectIDs {
+			projectIDs = append(projectIDs, id.String())
+		}
+		return slideService.GeneratePortfolioSlideContentWithOptions(projectIDs, s.Language, backlogToken, opts)
+	}
+	return slideService.GenerateSlideContentWithOptions(s.ProjectID.String(), theme, s.Language, backlogToken, opts)
+}
+
+// state summarizes the session's current status and progress counts.
+func (s *SlideSession) state() models.SessionState {
+	status, _ := s.currentStatus()
+	state := models.SessionState{
+		Status:      status,
+		TotalSlides: len(s.Themes),
+	}
+	s.dataMutex.Lock()
+	state.Slides = len(s.Slides)
+	state.Narrations = len(s.Narrations)
+	s.dataMutex.Unlock()
+	s.audioMutex.Lock()
+	state.AudioFiles = len(s.AudioFiles)
+	s.audioMutex.Unlock()
+	s.warningsMutex.Lock()
+	state.Warnings = len(s.Warnings)
+	s.warningsMutex.Unlock()
+	return state
+}
+
+// sessionFromRecord rebuilds a live session from a persisted record.
+func sessionFromRecord(record *models.SlideSessionRecord) *SlideSession {
+	session := &SlideSession{
+		ID:             record.ID,
+		ProjectID:      record.ProjectID,
+		ProjectIDs:     record.ProjectIDs,
+		Themes:         record.Themes,
+		Language:       record.Language,
+		Speed:          record.Speed,
+		SlideSpeeds:    record.SlideSpeeds,
+		Temperature:    record.Temperature,
+		TransitionCues: record.TransitionCues,
+		Status:         record.Status,
+		CreatedAt:      record.CreatedAt,
+		CompletedAt:    record.CompletedAt,
+		Connections:    make(map[*websocket.Conn]bool),
+		Slides:         record.Slides,
+		Narrations:     record.Narrations,
+		AudioFiles:     record.AudioFiles,
+		Warnings:       record.Warnings,
+		Timings:        record.Timings,
+		version:        record.Version,
+	}
+	if session.Slides == nil {
+		session.Slides = make([]*models.SlideContent, 0)
+	}
+	// Sessions persisted before content hashes were introduced get them on load
+	for _, slide := range session.Slides {
+		if slide.ContentHash == "" {
+			slide.ContentHash = services.ContentHash(slide.Title, slide.Markdown)
+		}
+	}
+	if session.Narrations == nil {
+		session.Narrations = make([]*models.SlideNarration, 0)
+	}
+	// Slides and narrations are kept ordered by index
+	sort.SliceStable(session.Slides, func(i, j int) bool {
+		return session.Slides[i].Index < session.Slides[j].Index
+	})
+	sort.SliceStable(session.Narrations, func(i, j int) bool {
+		return session.Narrations[i].SlideIndex < session.Narrations[j].SlideIndex
+	})
+	if session.AudioFiles == nil {
+		session.AudioFiles = make([]*models.SlideAudio, 0)
+	}
+	return session
+}
+
+// cleanupSessions periodically removes finished sessions older than the configured TTL
+func (h *SlideHandler) cleanupSessions() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if removed := h.CleanupExpiredSessions(time.Now()); removed > 0 {
+			slog.Info("Removed expired slide sessions", "count", removed)
+		}
+	}
+}
+
+// CleanupExpiredSessions removes completed or failed sessions whose completion
+// time is older than the configured session TTL and returns how many were removed.
+func (h *SlideHandler) CleanupExpiredSessions(now time.Time) int {
+	h.slidesMutex.RLock()
+	expired := make([]string, 0)
+	for id, session := range h.activeSlides {
+		status, completedAt := session.currentStatus()
+		if status != "completed" && status != "error" {
+			continue
+		}
+		if completedAt.IsZero() || now.Sub(completedAt) <= h.config.SessionTTL {
+			continue
+		}
+		expired = append(expired, id)
+	}
+	h.slidesMutex.RUnlock()
+
+	removed := 0
+	for _, id := range expired {
+		if h.removeSession(id) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// removeSession drops a session from memory and the session store, closing any
+// WebSocket connections that are still attached to it.
+func (h *SlideHandler) removeSession(slideID string) bool {
+	h.slidesMutex.Lock()
+	session, exists := h.activeSlides[slideID]
+	if exists {
+		delete(h.activeSlides, slideID)
+	}
+	h.slidesMutex.Unlock()
+
+	if !exists {
+		return false
+	}
+
+	session.ConnMutex.Lock()
+	for conn := range session.Connections {
+		conn.Close()
+		delete(session.Connections, conn)
+	}
+	session.ConnMutex.Unlock()
+
+	// Generation may still be running; mark the session deleted before removing
+	// the stored record so that it is not persisted again afterwards
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.deleted = true
+	if err := h.sessionStore.Delete(slideID); err != nil {
+		slog.Error("Failed to delete persisted slide session", "slideID", slideID, "error", err)
+	}
+	return true
+}
+
+func (h *SlideHandler) GenerateSlides(c *gin.Context) {
+	var req models.SlideGenerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fmt.Printf("JSON binding error: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+	
+	fmt.Printf("Received request: ProjectID=%s, Language=%s, Themes=%v\n", req.ProjectID, req.Language, req.Themes)
+
+	// Reject blank project IDs and tokens before any Backlog API call is made
+	projectID, backlogToken, err := services.ValidateProjectInput(req.ProjectID.String(), c.GetString("backlogToken"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.ProjectID = models.ProjectID(projectID)
+
+	// Normalize the projects compared on portfolio slides, which always include
+	// the deck's own project first
+	portfolio := []models.ProjectID{req.ProjectID}
+	seenProjects := map[models.ProjectID]bool{req.ProjectID: true}
+	for _, id := range req.ProjectIDs {
+		id = models.ProjectID(strings.TrimSpace(id.String()))
+		if id == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "projectIds must not contain blank project IDs",
+			})
+			return
+		}
+		if !seenProjects[id] {
+			seenProjects[id] = true
+			portfolio = append(portfolio, id)
+		}
+	}
+	if len(portfolio) > services.MaxPortfolioProjects {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("A portfolio comparison covers at most %d projects", services.MaxPortfolioProjects),
+		})
+		return
+	}
+	req.ProjectIDs = portfolio[1:]
+
+	// Validate themes
+	if len(req.Themes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "At least one theme must be specified",
+		})
+		return
+	}
+	if !h.config.AllowUnknownThemes {
+		var unknown []string
+		for _, theme := range req.Themes {
+			if !theme.IsKnown() {
+				unknown = append(unknown, string(theme))
+			}
+		}
+		if len(unknown) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":         fmt.Sprintf("Unknown themes: %s", strings.Join(unknown, ", ")),
+				"unknownThemes": unknown,
+			})
+			return
+		}
+	}
+
+	// Validate the language; "auto" is resolved below
+	if req.Language != services.LanguageAuto && !services.IsSupportedLanguage(req.Language) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Unsupported language %q; supported languages are %s or %q",
+				req.Language, strings.Join(services.SupportedLanguages(), ", "), services.LanguageAuto),
+		})
+		return
+	}
+
+	// Validate narration speeds
+	if !isValidSpeed(req.Speed) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Speed must be between 0.25 and 4.0",
+		})
+		return
+	}
+	for index, speed := range req.SlideSpeeds {
+		if !isValidSpeed(speed) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Speed for slide %d must be between 0.25 and 4.0", index),
+			})
+			return
+		}
+	}
+
+	// Validate the deck template's slide target
+	if req.Template != nil && (req.Template.TargetSlides < 0 || req.Template.TargetSlides > services.MaxTargetSlides) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("targetSlides must be between 0 and %d", services.MaxTargetSlides),
+		})
+		return
+	}
+
+	temperature, ok := h.temperatureOverride(c)
+	if !ok {
+		return
+	}
+	slideService, ok := h.requestSlideService(c)
+	if !ok {
+		return
+	}
+
+	// Arrange the themes into the configured deck flow on request
+	if req.AutoOrder {
+		req.SlideSpeeds = services.OrderSlideSpeeds(req.SlideSpeeds, req.Themes, h.config.ThemeOrder)
+		req.Themes = services.OrderThemes(req.Themes, h.config.ThemeOrder)
+	}
+
+	// Split themes across several slides when the template targets more slides
+	if req.Template != nil && req.Template.TargetSlides > len(req.Themes) {
+		req.SlideSpeeds = services.SplitSlideSpeeds(req.SlideSpeeds, req.Themes, req.Template.TargetSlides)
+		req.Themes = services.SplitThemes(req.Themes, req.Template.TargetSlides)
+	}
+
+	// Resolve "auto" to the user's Backlog language before generation starts
+	language := h.slideService.ResolveLanguage(req.Language, backlogToken)
+
+	// Generate unique slide ID
+	slideID := uuid.New().String()
+
+	// Create slide session
+	session := &SlideSession{
+		ID:              slideID,
+		ProjectID:       req.ProjectID,
+		ProjectIDs:      req.ProjectIDs,
+		Themes:          req.Themes,
+		Language:        language,
+		Speed:           req.Speed,
+		SlideSpeeds:     req.SlideSpeeds,
+		Temperature:     temperature,
+		ForceRegenerate: req.ForceRegenerate,
+		TransitionCues:  req.TransitionCues,
+		Status:          "generating",
+		CreatedAt:       time.Now(),
+		Connections:     make(map[*websocket.Conn]bool),
+		Slides:          make([]*models.SlideContent, 0),
+		Narrations:      make([]*models.SlideNarration, 0),
+		AudioFiles:      make([]*models.SlideAudio, 0),
+		AudioLimiter:    services.NewConcurrencyLimiter(h.config.AudioSessionMaxConcurrency),
+	}
+
+	h.slidesMutex.Lock()
+	h.activeSlides[slideID] = session
+	h.slidesMutex.Unlock()
+	h.persistSession(session)
+
+	// Start slide generation in background
+	go h.generateSlidesAsync(session, slideService, c.GetInt("userID"), backlogToken)
+
+	// Return response
+	c.JSON(http.StatusOK, models.SlideGenerationResponse{
+		SlideID:      slideID,
+		Status:       "generating",
+		WebSocketURL: fmt.Sprintf("ws://localhost:%s/ws/slides/%s", h.config.Port, slideID),
+		Language:     language,
+	})
+}
+
+func (h *SlideHandler) GetSlideStatus(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	// Snapshot the session since slides may still be generated concurrently
+	record := session.toRecord()
+	c.JSON(http.StatusOK, gin.H{
+		"slideId":    record.ID,
+		"projectId":  record.ProjectID,
+		"status":     record.Status,
+		"themes":     record.Themes,
+		"slides":     record.Slides,
+		"narrations": record.Narrations,
+		"audioFiles": record.AudioFiles,
+	})
+}
+
+// GetSlideReport returns a summary of what was generated for a slide session,
+// including per-slide word counts, audio durations, warnings, and estimated tokens.
+func (h *SlideHandler) GetSlideReport(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, session.BuildReport(h.config.AITokenCostPerMillion))
+}
+
+// GetSlideAudio returns the session's audio files in slide order so clients can
+// prefetch or bulk download them
+func (h *SlideHandler) GetSlideAudio(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	audioFiles := session.audioFiles()
+	totalDuration := 0
+	for _, audio := range audioFiles {
+		totalDuration += audio.Duration
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"slideId":       slideID,
+		"audioFiles":    audioFiles,
+		"totalDuration": totalDuration,
+	})
+}
+
+// GetFullSlideAudio returns the narration of the whole deck as one WAV file for
+// download. The optional gapMs query parameter inserts silence between slides;
+// slides whose audio is missing are skipped and listed in X-Skipped-Slides.
+func (h *SlideHandler) GetFullSlideAudio(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	gapMs, err := strconv.Atoi(c.DefaultQuery("gapMs", "0"))
+	gap := time.Duration(gapMs) * time.Millisecond
+	if err != nil || gap < 0 || gap > services.MaxAudioGap {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("gapMs must be between 0 and %d", services.MaxAudioGap.Milliseconds()),
+		})
+		return
+	}
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	track, err := h.slideService.BuildFullAudioTrack(session.toRecord(), gap)
+	if err != nil {
+		slog.Warn("Failed to build full audio", "slideID", slideID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No audio is available for this deck",
+		})
+		return
+	}
+
+	if len(track.SkippedSlides) > 0 {
+		skipped := make([]string, len(track.SkippedSlides))
+		for i, index := range track.SkippedSlides {
+			skipped[i] = strconv.Itoa(index)
+		}
+		c.Header("X-Skipped-Slides", strings.Join(skipped, ","))
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.wav"`, slideID))
+	c.Data(http.StatusOK, "audio/wav", track.WAV)
+}
+
+// ExportSlideZip streams the deck as a ZIP bundle of slide markdown and HTML,
+// audio files, and a manifest for offline use
+func (h *SlideHandler) ExportSlideZip(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, slideID))
+	c.Status(http.StatusOK)
+	// The status is already sent once streaming starts, so failures can only be logged
+	if err := h.slideService.WriteDeckZip(c.Writer, session.toRecord()); err != nil {
+		slog.Error("Failed to export session as ZIP", "slideID", slideID, "error", err)
+	}
+}
+
+// GetSlideJSONLD returns the deck as schema.org PresentationDigitalDocument
+// JSON-LD for integrations that consume structured data
+func (h *SlideHandler) GetSlideJSONLD(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	body, err := json.Marshal(services.BuildPresentationJSONLD(session.toRecord()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to encode JSON-LD",
+		})
+		return
+	}
+	c.Data(http.StatusOK, "application/ld+json; charset=utf-8", body)
+}
+
+// GetSlideTeleprompter returns the deck's slides paired with their narration
+// and per-slide timing, for a presenter's auto-scrolling teleprompter
+func (h *SlideHandler) GetSlideTeleprompter(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, services.BuildTeleprompter(session.toRecord()))
 }
 
-func (h *SlideHandler) GenerateSlides(c *gin.Context) {
-	var req models.SlideGenerationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		fmt.Printf("JSON binding error: %v\n", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-			"details": err.Error(),
+// GetSlideMarkdown returns the raw markdown of the deck as a downloadable file
+// so that it can be edited outside the presenter
+func (h *SlideHandler) GetSlideMarkdown(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
 		})
 		return
 	}
-	
-	fmt.Printf("Received request: ProjectID=%s, Language=%s, Themes=%v\n", req.ProjectID, req.Language, req.Themes)
 
-	// Validate themes
-	if len(req.Themes) == 0 {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.md"`, slideID))
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(services.BuildDeckMarkdown(session.toRecord())))
+}
+
+// subtitleContentTypes maps each subtitle format to its response content type
+var subtitleContentTypes = map[string]string{
+	services.SubtitleFormatVTT: "text/vtt; charset=utf-8",
+	services.SubtitleFormatSRT: "application/x-subrip; charset=utf-8",
+}
+
+// GetSlideSubtitles returns the narration as a downloadable WebVTT or SRT
+// caption file timed against the session's audio
+func (h *SlideHandler) GetSlideSubtitles(c *gin.Context) {
+	slideID := c.Param("slideId")
+	format := strings.ToLower(c.DefaultQuery("format", services.SubtitleFormatVTT))
+
+	contentType, supported := subtitleContentTypes[format]
+	if !supported {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "At least one theme must be specified",
+			"error": "Unsupported subtitle format; use vtt or srt",
 		})
 		return
 	}
 
-	// Generate unique slide ID
-	slideID := uuid.New().String()
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
 
-	// Create slide session
-	session := &SlideSession{
-		ID:          slideID,
-		ProjectID:   req.ProjectID,
-		Themes:      req.Themes,
-		Language:    req.Language,
-		Status:      "generating",
-		Connections: make(map[*websocket.Conn]bool),
-		Slides:      make([]*models.SlideContent, 0),
-		Narrations:  make([]*models.SlideNarration, 0),
-		AudioFiles:  make([]*models.SlideAudio, 0),
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
 	}
 
-	h.slidesMutex.Lock()
-	h.activeSlides[slideID] = session
-	h.slidesMutex.Unlock()
+	subtitles, err := services.BuildSubtitles(session.toRecord(), format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to build subtitles",
+		})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, slideID, format))
+	c.Data(http.StatusOK, contentType, []byte(subtitles))
+}
 
-	// Start slide generation in background
-	go h.generateSlidesAsync(session, c.GetInt("userID"), c.GetString("backlogToken"))
+// GetCapabilities reports which deck features this deployment supports so that
+// clients can hide audio controls when audio synthesis is disabled.
+func (h *SlideHandler) GetCapabilities(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"slides":    true,
+		"narration": true,
+		"audio":     !h.config.DisableAudio,
+	})
+}
 
-	// Return response
-	c.JSON(http.StatusOK, models.SlideGenerationResponse{
-		SlideID:      slideID,
-		Status:       "generating",
-		WebSocketURL: fmt.Sprintf("ws://localhost:%s/ws/slides/%s", h.config.Port, slideID),
+func (h *SlideHandler) DeleteSlideSession(c *gin.Context) {
+	slideID := c.Param("slideId")
+
+	if !h.removeSession(slideID) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slide not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Slide session deleted",
+		"slideId": slideID,
 	})
 }
 
-func (h *SlideHandler) GetSlideStatus(c *gin.Context) {
+func (h *SlideHandler) RegenerateSlide(c *gin.Context) {
 	slideID := c.Param("slideId")
 
+	var req models.SlideRegenerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
 	h.slidesMutex.RLock()
 	session, exists := h.activeSlides[slideID]
 	h.slidesMutex.RUnlock()
@@ -116,14 +1129,51 @@ func (h *SlideHandler) GetSlideStatus(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"slideId":    session.ID,
-		"projectId":  session.ProjectID,
-		"status":     session.Status,
-		"themes":     session.Themes,
-		"slides":     session.Slides,
-		"narrations": session.Narrations,
-		"audioFiles": session.AudioFiles,
+	if status, _ := session.currentStatus(); status == "generating" {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Presentation is still being generated",
+		})
+		return
+	}
+
+	// Resolve the slide index from the request
+	index := -1
+	if req.SlideIndex != nil {
+		index = *req.SlideIndex
+	} else if req.Theme != "" {
+		for i, theme := range session.Themes {
+			if theme == req.Theme {
+				index = i
+				break
+			}
+		}
+	}
+	if index < 0 || index >= len(session.Themes) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A valid slide index or theme must be specified",
+		})
+		return
+	}
+
+	slideService, ok := h.requestSlideService(c)
+	if !ok {
+		return
+	}
+
+	if !session.tryStartRegeneration(index) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Slide is already being regenerated",
+		})
+		return
+	}
+
+	// Regenerate the slide in background
+	go h.regenerateSlideAsync(session, slideService, index, c.GetString("backlogToken"))
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"slideId":    slideID,
+		"slideIndex": index,
+		"status":     "regenerating",
 	})
 }
 
@@ -150,8 +1200,24 @@ func (h *SlideHandler) HandleWebSocket(c *gin.Context) {
 	}
 	defer conn.Close()
 
-	// Add connection to session
+	// Send a state snapshot, replay earlier messages and add the connection under
+	// the same lock so a late-joining or reconnecting client can resync, misses
+	// nothing and sees no duplicates. The snapshot is not kept for replay.
 	session.ConnMutex.Lock()
+	conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := conn.WriteJSON(models.WebSocketMessage{
+		Type: models.MessageTypeSessionState,
+		Data: session.state(),
+	}); err != nil {
+		session.ConnMutex.Unlock()
+		return
+	}
+	for _, message := range session.replay.snapshot() {
+		if err := conn.WriteJSON(message); err != nil {
+			session.ConnMutex.Unlock()
+			return
+		}
+	}
 	session.Connections[conn] = true
 	session.ConnMutex.Unlock()
 
@@ -162,6 +1228,18 @@ func (h *SlideHandler) HandleWebSocket(c *gin.Context) {
 		session.ConnMutex.Unlock()
 	}()
 
+	// Every pong extends the read deadline; a client that stops answering pings
+	// fails the read below and is removed instead of lingering half-open
+	pongTimeout := h.wsPongTimeout()
+	conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+
+	stopPing := make(chan struct{})
+	defer close(stopPing)
+	go h.pingConnection(conn, stopPing)
+
 	// Keep connection alive and handle messages
 	for {
 		_, _, err := conn.ReadMessage()
@@ -171,56 +1249,106 @@ func (h *SlideHandler) HandleWebSocket(c *gin.Context) {
 	}
 }
 
-func (h *SlideHandler) generateSlidesAsync(session *SlideSession, userID int, backlogToken string) {
+// pingConnection pings a WebSocket client at the configured interval until stop
+// is closed. A failed ping closes the connection, which ends the read loop in
+// HandleWebSocket and removes the connection from its session.
+func (h *SlideHandler) pingConnection(conn *websocket.Conn, stop <-chan struct{}) {
+	ticker := time.NewTicker(h.wsPingInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// WriteControl may be called concurrently with WriteJSON in broadcasts
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// wsPingInterval returns how often WebSocket clients are pinged
+func (h *SlideHandler) wsPingInterval() time.Duration {
+	if h.config.WSPingInterval > 0 {
+		return h.config.WSPingInterval
+	}
+	return 30 * time.Second
+}
+
+// wsPongTimeout returns how long a WebSocket client may stay silent before it
+// is considered dead. It is never shorter than the ping interval.
+func (h *SlideHandler) wsPongTimeout() time.Duration {
+	timeout := h.config.WSPongTimeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	if interval := h.wsPingInterval(); timeout <= interval {
+		timeout = 2 * interval
+	}
+	return timeout
+}
+
+// ActiveConnections returns the number of WebSocket clients connected to a session
+func (h *SlideHandler) ActiveConnections(slideID string) int {
+	h.slidesMutex.RLock()
+	session, exists := h.activeSlides[slideID]
+	h.slidesMutex.RUnlock()
+	if !exists {
+		return 0
+	}
+
+	session.ConnMutex.RLock()
+	defer session.ConnMutex.RUnlock()
+	return len(session.Connections)
+}
+
+func (h *SlideHandler) generateSlidesAsync(session *SlideSession, slideService *services.SlideService, userID int, backlogToken string) {
 	defer func() {
-		session.Status = "completed"
+		session.finish("completed")
+		h.persistSession(session)
 	}()
+	defer slideService.BeginRun(backlogToken)()
 
-	for i, theme := range session.Themes {
-		// Broadcast slide generation started
-		h.broadcastSlideGenerationStarted(session, &models.SlideGenerationStarted{
-			SlideIndex: i,
-			Theme:      theme,
-		})
-
-		// Generate slide content
-		slideContent, err := h.slideService.GenerateSlideContent(
-			session.ProjectID.String(),
-			theme,
-			session.Language,
-			backlogToken,
-		)
-		if err != nil {
-			h.broadcastError(session, fmt.Sprintf("Failed to generate slide %d: %v", i+1, err))
-			continue
-		}
+	// Slides are generated by a bounded pool to respect AI provider rate limits,
+	// and audio is synthesized in the background while other slides are generated
+	slideLimiter := services.NewConcurrencyLimiter(h.slideConcurrency())
+	var slideWG, audioWG sync.WaitGroup
 
-		slideContent.Index = i
-		// Store slide data in session
-		session.Slides = append(session.Slides, slideContent)
-		h.broadcastSlideContent(session, slideContent)
+	// Fetch every theme's data up front so Backlog I/O overlaps rather than
+	// waiting behind each slide's AI call
+	var prefetched *services.ThemeDataSet
+	if h.config.PrefetchThemeData {
+		prefetched = slideService.PrefetchThemeData(session.ProjectID.String(), session.Themes, backlogToken)
+	}
 
-		// Generate narration
-		narration, err := h.slideService.GenerateSlideNarration(slideContent, session.Language)
-		if err != nil {
-			h.broadcastError(session, fmt.Sprintf("Failed to generate narration for slide %d: %v", i+1, err))
-		} else {
-			// Store narration data in session
-			session.Narrations = append(session.Narrations, narration)
-			h.broadcastSlideNarration(session, narration)
-			
-			// Generate audio for the narration
-			audio, err := h.slideService.GenerateSlideAudio(narration)
-			if err != nil {
-				h.broadcastError(session, fmt.Sprintf("Failed to generate audio for slide %d: %v", i+1, err))
-			} else {
-				// Store audio data in session
-				session.AudioFiles = append(session.AudioFiles, audio)
-				h.broadcastSlideAudio(session, audio)
-			}
-		}
+	// Report overall progress each time a slide's pipeline finishes; the lock
+	// keeps progress messages in increasing order
+	var progressMutex sync.Mutex
+	completed, total := 0, len(session.Themes)
+	slideFinished := func() {
+		progressMutex.Lock()
+		defer progressMutex.Unlock()
+		completed++
+		h.broadcastProgress(session, completed, total)
+	}
+
+	for i, theme := range session.Themes {
+		slideWG.Add(1)
+		go func(i int, theme models.SlideTheme) {
+			defer slideWG.Done()
+			slideLimiter.Acquire()
+			defer slideLimiter.Release()
+			h.generateSlide(session, slideService, i, theme, backlogToken, prefetched, &audioWG, slideFinished)
+		}(i, theme)
 	}
 
+	// Wait for all slides and outstanding audio before reporting completion
+	slideWG.Wait()
+	audioWG.Wait()
+
 	// Send completion message
 	h.broadcastPresentationComplete(session, &models.PresentationComplete{
 		TotalSlides: len(session.Themes),
@@ -228,6 +1356,225 @@ func (h *SlideHandler) generateSlidesAsync(session *SlideSession, userID int, ba
 	})
 }
 
+// generateSlide generates the content and narration for one slide of the deck
+// and starts its audio synthesis, tracked by audioWG. Project data is taken from
+// prefetched when available. finished is called once when the slide is done,
+// after its audio or as soon as a step fails.
+func (h *SlideHandler) generateSlide(session *SlideSession, slideService *services.SlideService, i int, theme models.SlideTheme, backlogToken string, prefetched *services.ThemeDataSet, audioWG *sync.WaitGroup, finished func()) {
+	audioStarted := false
+	defer func() {
+		if !audioStarted {
+			finished()
+		}
+	}()
+
+	// Broadcast slide generation started
+	h.broadcastSlideGenerationStarted(session, &models.SlideGenerationStarted{
+		SlideIndex: i,
+		Theme:      theme,
+	})
+
+	// Generate slide content
+	start := time.Now()
+	slideContent, err := session.generateContent(slideService, i, backlogToken,
+		services.GenerationOptions{
+			Temperature: session.Temperature,
+			OnDelta:     h.streamSlideContent(session, i),
+			Prefetched:  prefetched,
+			BypassCache: session.ForceRegenerate,
+		},
+	)
+	if err != nil {
+		h.broadcastSlideFailure(session, i, fmt.Sprintf("Failed to generate slide %d: %v", i+1, err), err)
+		return
+	}
+	session.RecordTiming(i, models.TimingStageContent, time.Since(start))
+
+	// Store slide data in session
+	session.ReplaceSlide(i, slideContent)
+	h.broadcastSlideContent(session, slideContent)
+
+	// Generate narration
+	start = time.Now()
+	narration, err := slideService.GenerateSlideNarrationWithOptions(slideContent, session.Language,
+		services.GenerationOptions{Temperature: session.Temperature})
+	if err != nil {
+		h.broadcastSlideWarning(session, i, fmt.Sprintf("Failed to generate narration for slide %d: %v", i+1, err), err)
+		return
+	}
+	session.RecordTiming(i, models.TimingStageNarration, time.Since(start))
+	session.prepareNarration(i, narration)
+	// Store narration data in session
+	session.ReplaceNarration(narration)
+	h.broadcastSlideNarration(session, narration)
+
+	// Text-only deployments finish the slide with its narration
+	if h.config.DisableAudio {
+		return
+	}
+
+	// Generate audio for the narration
+	audioStarted = true
+	audioWG.Add(1)
+	go func() {
+		defer audioWG.Done()
+		defer finished()
+		audio, err := session.GenerateAudio(slideService, narration)
+		if err != nil {
+			h.broadcastSlideWarning(session, i, fmt.Sprintf("Failed to generate audio for slide %d: %v", i+1, err), err)
+			return
+		}
+		// Store audio data in session
+		session.ReplaceAudio(audio)
+		h.broadcastSlideAudio(session, audio)
+	}()
+}
+
+// TemperatureHeader lets space administrators override the AI sampling
+// temperature of a generation without changing the configuration
+const TemperatureHeader = "X-AI-Temperature"
+
+// temperatureOverride parses the TemperatureHeader of a generation request.
+// The header is only honoured for Backlog space administrators and must be
+// within the range supported by every AI provider.
+//
+// Returns the override, or nil when the header is absent. When the header is
+// rejected, an error response has been written and ok is false.
+func (h *SlideHandler) temperatureOverride(c *gin.Context) (temperature *float64, ok bool) {
+	header := strings.TrimSpace(c.GetHeader(TemperatureHeader))
+	if header == "" {
+		return nil, true
+	}
+
+	value, err := strconv.ParseFloat(header, 64)
+	if err != nil || !services.IsValidTemperature(value) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("%s must be a number between %.1f and %.1f", TemperatureHeader, services.MinTemperature, services.MaxTemperature),
+		})
+		return nil, false
+	}
+	if !h.slideService.IsSpaceAdministrator(c.GetString("backlogToken")) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": fmt.Sprintf("%s is only available to space administrators", TemperatureHeader),
+		})
+		return nil, false
+	}
+
+	slog.Info("AI temperature overridden", "temperature", value, "userID", c.GetInt("userID"))
+	return &value, true
+}
+
+// AICredentialsHeader carries a tenant's own AI provider credentials as a JSON
+// models.AICredentials object, used instead of the server's shared credentials
+// when ALLOW_TENANT_AI_CREDENTIALS is enabled
+const AICredentialsHeader = "X-AI-Credentials"
+
+// awsRegionPattern matches AWS region names such as ap-northeast-1
+var awsRegionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d$`)
+
+// requestSlideService returns the slide service for a generation request: one
+// scoped to the tenant's AICredentialsHeader credentials when the header is
+// present, otherwise the shared service. The credentials are used only for
+// this request and are never logged or persisted with the session.
+//
+// When the header is rejected, an error response has been written and ok is false.
+func (h *SlideHandler) requestSlideService(c *gin.Context) (slideService *services.SlideService, ok bool) {
+	header := strings.TrimSpace(c.GetHeader(AICredentialsHeader))
+	if header == "" {
+		return h.slideService, true
+	}
+	if !h.config.AllowTenantAICredentials {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": fmt.Sprintf("%s is not enabled on this server", AICredentialsHeader),
+		})
+		return nil, false
+	}
+
+	var creds models.AICredentials
+	if err := json.Unmarshal([]byte(header), &creds); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("%s must be a JSON object", AICredentialsHeader),
+		})
+		return nil, false
+	}
+	// The region becomes part of the Bedrock endpoint host, so accept region names only
+	if creds.AWSRegion != "" && !awsRegionPattern.MatchString(creds.AWSRegion) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "awsRegion must be an AWS region name such as ap-northeast-1",
+		})
+		return nil, false
+	}
+	slideService, err := h.slideService.WithAICredentials(&creds)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	return slideService, true
+}
+
+// slideConcurrency returns how many slides of a deck may be generated at once
+func (h *SlideHandler) slideConcurrency() int {
+	if h.config.SlideMaxConcurrency > 0 {
+		return h.config.SlideMaxConcurrency
+	}
+	return 3
+}
+
+func (h *SlideHandler) regenerateSlideAsync(session *SlideSession, slideService *services.SlideService, index int, backlogToken string) {
+	defer session.finishRegeneration(index)
+	session.clearWarnings(index)
+
+	theme := session.Themes[index]
+	h.broadcastSlideGenerationStarted(session, &models.SlideGenerationStarted{
+		SlideIndex: index,
+		Theme:      theme,
+	})
+
+	start := time.Now()
+	slideContent, err := session.generateContent(slideService, index, backlogToken,
+		services.GenerationOptions{
+			Temperature: session.Temperature,
+			OnDelta:     h.streamSlideContent(session, index),
+			// An explicit regeneration always asks the AI provider again
+			BypassCache: true,
+		},
+	)
+	if err != nil {
+		h.broadcastSlideFailure(session, index, fmt.Sprintf("Failed to regenerate slide %d: %v", index+1, err), err)
+		return
+	}
+	session.RecordTiming(index, models.TimingStageContent, time.Since(start))
+
+	session.ReplaceSlide(index, slideContent)
+	h.broadcastSlideContent(session, slideContent)
+
+	start = time.Now()
+	narration, err := slideService.GenerateSlideNarrationWithOptions(slideContent, session.Language,
+		services.GenerationOptions{Temperature: session.Temperature})
+	if err != nil {
+		h.broadcastSlideWarning(session, index, fmt.Sprintf("Failed to regenerate narration for slide %d: %v", index+1, err), err)
+		return
+	}
+	session.RecordTiming(index, models.TimingStageNarration, time.Since(start))
+	session.prepareNarration(index, narration)
+	session.ReplaceNarration(narration)
+	h.broadcastSlideNarration(session, narration)
+
+	if h.config.DisableAudio {
+		return
+	}
+
+	audio, err := session.GenerateAudio(slideService, narration)
+	if err != nil {
+		h.broadcastSlideWarning(session, index, fmt.Sprintf("Failed to regenerate audio for slide %d: %v", index+1, err), err)
+		return
+	}
+	session.ReplaceAudio(audio)
+	h.broadcastSlideAudio(session, audio)
+}
+
 func (h *SlideHandler) broadcastSlideGenerationStarted(session *SlideSession, started *models.SlideGenerationStarted) {
 	message := models.WebSocketMessage{
 		Type: models.MessageTypeSlideGenerationStarted,
@@ -244,6 +1591,20 @@ func (h *SlideHandler) broadcastSlideContent(session *SlideSession, content *mod
 	h.broadcastToSession(session, message)
 }
 
+// streamSlideContent returns a callback that forwards streamed markdown for the
+// slide to connected clients
+func (h *SlideHandler) streamSlideContent(session *SlideSession, index int) services.TextDeltaFunc {
+	return func(delta string) {
+		h.broadcastTransient(session, models.WebSocketMessage{
+			Type: models.MessageTypeSlidePartial,
+			Data: models.SlidePartial{
+				SlideIndex: index,
+				Delta:      delta,
+			},
+		})
+	}
+}
+
 func (h *SlideHandler) broadcastSlideNarration(session *SlideSession, narration *models.SlideNarration) {
 	message := models.WebSocketMessage{
 		Type: models.MessageTypeSlideNarration,
@@ -268,22 +1629,87 @@ func (h *SlideHandler) broadcastPresentationComplete(session *SlideSession, comp
 	h.broadcastToSession(session, message)
 }
 
-func (h *SlideHandler) broadcastError(session *SlideSession, errMsg string) {
+func (h *SlideHandler) broadcastProgress(session *SlideSession, completed, total int) {
+	percent := 100
+	if total > 0 {
+		percent = completed * 100 / total
+	}
 	message := models.WebSocketMessage{
-		Type: models.MessageTypeError,
-		Data: models.ErrorMessage{
-			Message: errMsg,
-			Code:    "GENERATION_ERROR",
+		Type: models.MessageTypeProgress,
+		Data: models.GenerationProgress{
+			Completed: completed,
+			Total:     total,
+			Percent:   percent,
 		},
 	}
 	h.broadcastToSession(session, message)
 }
 
+func (h *SlideHandler) broadcastError(session *SlideSession, errorMessage models.ErrorMessage) {
+	message := models.WebSocketMessage{
+		Type: models.MessageTypeError,
+		Data: errorMessage,
+	}
+	h.broadcastToSession(session, message)
+}
+
+// broadcastSlideWarning records a generation problem for the slide so that it
+// appears in the generation report, then broadcasts it as an error message with
+// a remediation hint for the underlying error when one is known.
+func (h *SlideHandler) broadcastSlideWarning(session *SlideSession, index int, errMsg string, err error) {
+	session.AddWarning(index, errMsg)
+	h.broadcastError(session, models.ErrorMessage{
+		Message: errMsg,
+		Code:    models.ErrorCodeGeneration,
+		Hint:    services.RemediationHint(err),
+	})
+}
+
+// broadcastSlideFailure is broadcastSlideWarning for slide content failures.
+// Project data that exceeds the prompt size limit is reported with a 413-style
+// DATA_TOO_LARGE code so that clients can prompt the user to narrow the scope.
+func (h *SlideHandler) broadcastSlideFailure(session *SlideSession, index int, errMsg string, err error) {
+	if !errors.Is(err, services.ErrDataTooLarge) {
+		h.broadcastSlideWarning(session, index, errMsg, err)
+		return
+	}
+	session.AddWarning(index, errMsg)
+	h.broadcastError(session, models.ErrorMessage{
+		Message: errMsg,
+		Code:    models.ErrorCodeDataTooLarge,
+		Status:  http.StatusRequestEntityTooLarge,
+		Hint:    services.RemediationHint(err),
+	})
+}
+
 func (h *SlideHandler) broadcastToSession(session *SlideSession, message models.WebSocketMessage) {
-	session.ConnMutex.RLock()
-	defer session.ConnMutex.RUnlock()
+	// Write through so that every state change seen by clients is also persisted
+	h.persistSession(session)
+
+	// Slides are generated concurrently, and a WebSocket connection supports only
+	// one writer at a time, so writes are serialized with the exclusive lock
+	session.ConnMutex.Lock()
+	defer session.ConnMutex.Unlock()
 
+	session.replay.add(message)
+	h.writeToConnections(session, message)
+}
+
+// broadcastTransient sends a message to connected clients without persisting it
+// or buffering it for replay. It is used for high-volume messages, such as
+// streamed markdown, that a later message supersedes.
+func (h *SlideHandler) broadcastTransient(session *SlideSession, message models.WebSocketMessage) {
+	session.ConnMutex.Lock()
+	defer session.ConnMutex.Unlock()
+	h.writeToConnections(session, message)
+}
+
+// writeToConnections writes the message to every connection of the session.
+// The caller must hold session.ConnMutex.
+func (h *SlideHandler) writeToConnections(session *SlideSession, message models.WebSocketMessage) {
 	for conn := range session.Connections {
+		// A write deadline keeps a half-open connection from stalling every broadcast
+		conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
 		if err := conn.WriteJSON(message); err != nil {
 			// Remove failed connection
 			go func(c *websocket.Conn) {
@@ -294,4 +1720,10 @@ func (h *SlideHandler) broadcastToSession(session *SlideSession, message models.
 			}(conn)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// isValidSpeed reports whether a narration speed is unset (0) or within the
+// range supported by the speech server.
+func isValidSpeed(speed float64) bool {
+	return speed == 0 || (speed >= 0.25 && speed <= 4.0)
+}
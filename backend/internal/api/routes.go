@@ -22,6 +22,7 @@ import (
 //   - /api/v1/speech/* - Speech synthesis endpoints (authenticated)
 //   - /ws/slides/* - WebSocket endpoint for real-time slide delivery
 //   - /cache/* - Static audio file serving
+//   - /health/ready - Readiness probe checking downstream dependencies
 //
 // Parameters:
 //   - router: the Gin engine instance to configure
@@ -31,6 +32,11 @@ func SetupRoutes(router *gin.Engine, cfg *config.Config) {
 	authHandler := handlers.NewAuthHandler(cfg)
 	slideHandler := handlers.NewSlideHandler(cfg)
 	mcpHandler := handlers.NewMCPHandler(cfg)
+	healthHandler := handlers.NewHealthHandler(cfg)
+
+	// Generation and synthesis trigger expensive AI/TTS calls, so they share a
+	// per-user rate limit
+	rateLimit := auth.RateLimit(cfg)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -45,6 +51,9 @@ func SetupRoutes(router *gin.Engine, cfg *config.Config) {
 			authGroup.POST("/logout", authHandler.Logout)
 		}
 
+		// Feature discovery for clients (no authentication required)
+		v1.GET("/capabilities", slideHandler.GetCapabilities)
+
 		// Project data routes (requires authentication)
 		projectGroup := v1.Group("/projects", auth.RequireAuth(cfg))
 		{
@@ -59,18 +68,31 @@ func SetupRoutes(router *gin.Engine, cfg *config.Config) {
 		// Slide generation routes (requires authentication)
 		slideGroup := v1.Group("/slides", auth.RequireAuth(cfg))
 		{
-			slideGroup.POST("/generate", slideHandler.GenerateSlides)
+			slideGroup.POST("/generate", rateLimit, slideHandler.GenerateSlides)
 			slideGroup.GET("/:slideId/status", slideHandler.GetSlideStatus)
+			slideGroup.GET("/:slideId/report", slideHandler.GetSlideReport)
+			slideGroup.GET("/:slideId/audio", slideHandler.GetSlideAudio)
+			slideGroup.GET("/:slideId/audio/full", slideHandler.GetFullSlideAudio)
+			slideGroup.GET("/:slideId/jsonld", slideHandler.GetSlideJSONLD)
+			slideGroup.GET("/:slideId/subtitles", slideHandler.GetSlideSubtitles)
+			slideGroup.GET("/:slideId/markdown", slideHandler.GetSlideMarkdown)
+			slideGroup.GET("/:slideId/export/zip", slideHandler.ExportSlideZip)
+			slideGroup.GET("/:slideId/teleprompter", slideHandler.GetSlideTeleprompter)
+			slideGroup.POST("/:slideId/regenerate", rateLimit, slideHandler.RegenerateSlide)
+			slideGroup.DELETE("/:slideId", slideHandler.DeleteSlideSession)
 		}
 
 		// Speech synthesis routes (requires authentication)
 		speechGroup := v1.Group("/speech", auth.RequireAuth(cfg))
 		{
-			speechGroup.POST("/synthesize", mcpHandler.SynthesizeSpeech)
+			speechGroup.POST("/synthesize", rateLimit, mcpHandler.SynthesizeSpeech)
 			speechGroup.GET("/audio/:filename", mcpHandler.GetAudioFile)
 		}
 	}
 
+	// Readiness probe; /health in main.go stays a dependency-free liveness probe
+	router.GET("/health/ready", healthHandler.Ready)
+
 	// Audio cache routes (no authentication required for cached audio files)
 	router.GET("/cache/:filename", mcpHandler.GetAudioFile)
 
@@ -4,6 +4,13 @@
 package auth
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -134,24 +141,151 @@ func validateToken(tokenString, secret string) (*models.JWTClaims, error) {
 	return nil, jwt.ErrInvalidKey
 }
 
+// ErrRefreshWindowExpired is returned by ParseRefreshableToken for a token
+// that expired longer ago than the refresh grace period, or that has no expiry
+var ErrRefreshWindowExpired = errors.New("token is too old to be refreshed")
+
+// ParseRefreshableToken validates the signature of a JWT token but, unlike
+// validateToken, accepts it for up to grace after expiry. It is used only to
+// renew a session whose application token has expired but whose Backlog
+// refresh token may still be valid. The sealed refresh token is opened, so
+// the returned claims hold it in plain text.
+//
+// Parameters:
+//   - tokenString: the JWT token to parse
+//   - secret: the secret key used to sign the token
+//   - grace: how long after expiry the token may still be refreshed
+//
+// Returns the JWTClaims if the signature is valid and the token is within the
+// refresh window, or an error otherwise.
+func ParseRefreshableToken(tokenString, secret string, grace time.Duration) (*models.JWTClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &models.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(secret), nil
+	}, jwt.WithLeeway(grace))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrRefreshWindowExpired
+		}
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*models.JWTClaims)
+	if !ok {
+		return nil, jwt.ErrInvalidKey
+	}
+	if claims.ExpiresAt == nil {
+		return nil, ErrRefreshWindowExpired
+	}
+	if claims.BacklogRefreshToken != "" {
+		if claims.BacklogRefreshToken, err = OpenRefreshToken(claims.BacklogRefreshToken, secret); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+// refreshTokenKey derives the AES-256 key that seals Backlog refresh tokens
+// from the JWT secret, so that no separate key has to be configured
+func refreshTokenKey(secret string) []byte {
+	key := sha256.Sum256([]byte("backlog-refresh-token:" + secret))
+	return key[:]
+}
+
+// SealRefreshToken encrypts a Backlog refresh token with AES-GCM for storage
+// in the JWT claims, which are only signed and readable by anyone holding the
+// token.
+//
+// Parameters:
+//   - refreshToken: the Backlog OAuth refresh token
+//   - secret: the JWT secret the encryption key is derived from
+//
+// Returns the base64url-encoded nonce and ciphertext.
+func SealRefreshToken(refreshToken, secret string) (string, error) {
+	block, err := aes.NewCipher(refreshTokenKey(secret))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(refreshToken), nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// OpenRefreshToken decrypts a refresh token sealed by SealRefreshToken.
+//
+// Parameters:
+//   - sealed: the base64url-encoded nonce and ciphertext
+//   - secret: the JWT secret the encryption key is derived from
+//
+// Returns the Backlog refresh token, or an error if it cannot be decrypted.
+func OpenRefreshToken(sealed, secret string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("invalid sealed refresh token: %w", err)
+	}
+	block, err := aes.NewCipher(refreshTokenKey(secret))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("invalid sealed refresh token: too short")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid sealed refresh token: %w", err)
+	}
+	return string(plain), nil
+}
+
+// defaultTokenTTL is used when no positive token lifetime is configured
+const defaultTokenTTL = 7 * 24 * time.Hour
+
 // GenerateToken generates a new JWT token for authenticated users.
-// It creates a JWT token containing the user ID and Backlog access token,
-// with a 7-day expiration time.
+// It creates a JWT token containing the user ID and Backlog OAuth tokens,
+// expiring after the given lifetime. The refresh token is sealed with
+// SealRefreshToken rather than stored in plain text.
 //
 // Parameters:
 //   - userID: the Backlog user ID to include in the token
 //   - backlogToken: the Backlog OAuth access token for API calls
+//   - refreshToken: the Backlog OAuth refresh token used by the refresh endpoint
 //   - secret: the secret key used to sign the JWT token
+//   - ttl: how long the token is valid (defaults to 7 days when not positive)
 //
 // Returns the signed JWT token string, or an error if token generation fails.
-func GenerateToken(userID int, backlogToken, secret string) (string, error) {
+func GenerateToken(userID int, backlogToken, refreshToken, secret string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+	sealedRefreshToken := ""
+	if refreshToken != "" {
+		var err error
+		if sealedRefreshToken, err = SealRefreshToken(refreshToken, secret); err != nil {
+			return "", err
+		}
+	}
 	now := time.Now()
 	claims := &models.JWTClaims{
-		UserID:       userID,
-		BacklogToken: backlogToken,
+		UserID:              userID,
+		BacklogToken:        backlogToken,
+		BacklogRefreshToken: sealedRefreshToken,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(24 * 7 * time.Hour)), // 7 days
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 		},
 	}
 
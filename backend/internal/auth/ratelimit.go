@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bucketSweepInterval is how often idle, fully refilled buckets are discarded
+const bucketSweepInterval = time.Minute
+
+// RateLimiter is a per-user token bucket limiter. Each user may make up to
+// burst requests at once, refilled at rps tokens per second.
+type RateLimiter struct {
+	rps       float64
+	burst     float64
+	mu        sync.Mutex
+	buckets   map[int]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket tracks the tokens left for one user as of the last update
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rps requests per second per user
+// with bursts of up to burst requests.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rps:       rps,
+		burst:     float64(burst),
+		buckets:   make(map[int]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token from the user's bucket. When the bucket is empty it
+// returns false along with how long the user must wait for the next token.
+func (l *RateLimiter) Allow(userID int) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	bucket, exists := l.buckets[userID]
+	if !exists {
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[userID] = bucket
+	}
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rps)
+	bucket.updated = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rps * float64(time.Second))
+	return false, wait
+}
+
+// sweep drops buckets that have refilled completely, since a new bucket starts
+// full anyway. It runs at most once per bucketSweepInterval.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for userID, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rps >= l.burst {
+			delete(l.buckets, userID)
+		}
+	}
+}
+
+// RateLimit is a middleware that throttles expensive endpoints per
+// authenticated user. It must run after RequireAuth, which stores the user ID
+// from the JWT claims. Requests over the limit receive 429 Too Many Requests
+// with a Retry-After header in seconds. A non-positive RateLimitRPS disables
+// the limit.
+func RateLimit(cfg *config.Config) gin.HandlerFunc {
+	if cfg.RateLimitRPS <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	limiter := NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
+
+	return func(c *gin.Context) {
+		allowed, wait := limiter.Allow(c.GetInt("userID"))
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Rate limit exceeded, please retry later",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -11,20 +11,58 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // MCPClient represents an MCP client for communicating with MCP servers.
 // It manages the HTTP connection, session state, and JSON-RPC protocol
 // communication with remote MCP servers.
+//
+// MCPClient is safe for concurrent use.
 type MCPClient struct {
 	serverURL string       // Base URL of the MCP server
 	client    *http.Client // HTTP client for network requests
-	sessionID string       // Session identifier for stateful connections
+	lastID    atomic.Int64 // Last request ID issued by generateID
+
+	maxAttempts    int           // Attempts per request for transient failures
+	retryBaseDelay time.Duration // Initial backoff delay between attempts
+
+	sessionMu   sync.RWMutex           // Guards sessionID and clientInfo
+	sessionID   string                 // Session identifier for stateful connections
+	clientInfo  map[string]interface{} // Client info from Initialize, reused on reconnect
+	initialized bool                   // Whether Initialize has completed
+	reconnectMu sync.Mutex             // Serializes session re-initialization
 }
 
+const (
+	// defaultMaxAttempts is the number of attempts per request, including the first
+	defaultMaxAttempts = 3
+	// defaultRetryBaseDelay is the backoff delay before the second attempt
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	// maxRetryDelay caps the exponential backoff between attempts
+	maxRetryDelay = 10 * time.Second
+)
+
+// errSessionLost indicates the server no longer knows the client's session,
+// typically because the MCP server restarted
+var errSessionLost = errors.New("MCP session not found")
+
+// transientError marks a failure that may succeed when retried, such as a
+// connection error or a 5xx response
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
 // MCPRequest represents an MCP JSON-RPC request structure.
 // It follows the JSON-RPC 2.0 specification with MCP-specific extensions
 // for method calls and parameter passing.
@@ -68,6 +106,24 @@ func NewMCPClient(serverURL string) *MCPClient {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		maxAttempts:    defaultMaxAttempts,
+		retryBaseDelay: defaultRetryBaseDelay,
+	}
+}
+
+// SetRetryPolicy configures how often requests are retried after connection
+// errors or 5xx responses. Non-positive values keep the current setting.
+// It must be called before the client is used concurrently.
+//
+// Parameters:
+//   - maxAttempts: Total attempts per request, including the first
+//   - baseDelay: Backoff delay before the second attempt, doubled for each further attempt
+func (c *MCPClient) SetRetryPolicy(maxAttempts int, baseDelay time.Duration) {
+	if maxAttempts > 0 {
+		c.maxAttempts = maxAttempts
+	}
+	if baseDelay > 0 {
+		c.retryBaseDelay = baseDelay
 	}
 }
 
@@ -81,9 +137,23 @@ func NewMCPClient(serverURL string) *MCPClient {
 //
 // Returns an error if the initialization handshake fails at any step.
 func (c *MCPClient) Initialize(ctx context.Context, clientInfo map[string]interface{}) error {
+	if err := c.initialize(ctx, clientInfo); err != nil {
+		return err
+	}
+
+	c.sessionMu.Lock()
+	c.clientInfo = clientInfo
+	c.initialized = true
+	c.sessionMu.Unlock()
+	return nil
+}
+
+// initialize performs the MCP handshake without reconnect handling, so that a
+// lost session during re-initialization does not recurse
+func (c *MCPClient) initialize(ctx context.Context, clientInfo map[string]interface{}) error {
 	request := MCPRequest{
 		JSONRPC: "2.0",
-		ID:      1,
+		ID:      c.generateID(),
 		Method:  "initialize",
 		Params: map[string]interface{}{
 			"protocolVersion": "2024-11-05",
@@ -92,7 +162,7 @@ func (c *MCPClient) Initialize(ctx context.Context, clientInfo map[string]interf
 		},
 	}
 
-	response, err := c.sendRequest(ctx, request)
+	response, err := c.do(ctx, request, false)
 	if err != nil {
 		return fmt.Errorf("failed to initialize MCP client: %w", err)
 	}
@@ -107,7 +177,7 @@ func (c *MCPClient) Initialize(ctx context.Context, clientInfo map[string]interf
 		Method:  "notifications/initialized",
 	}
 
-	_, err = c.sendRequest(ctx, notification)
+	_, err = c.do(ctx, notification, false)
 	if err != nil {
 		return fmt.Errorf("failed to send initialized notification: %w", err)
 	}
@@ -212,50 +282,161 @@ func (c *MCPClient) ListPrompts(ctx context.Context) (*MCPResponse, error) {
 	return c.sendRequest(ctx, request)
 }
 
-// sendRequest sends an MCP request to the server
+// sendRequest sends an MCP request to the server, retrying transient failures
+// and re-initializing the session if the server lost it
 func (c *MCPClient) sendRequest(ctx context.Context, request MCPRequest) (*MCPResponse, error) {
+	return c.do(ctx, request, true)
+}
+
+// do sends a request, retrying connection errors and 5xx responses with
+// exponential backoff. When the server reports that the session was lost and
+// reconnect is set, the session is re-initialized once and the request resent.
+func (c *MCPClient) do(ctx context.Context, request MCPRequest, reconnect bool) (*MCPResponse, error) {
 	requestBody, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.serverURL, bytes.NewBuffer(requestBody))
+	for attempt := 1; ; attempt++ {
+		response, usedSessionID, err := c.send(ctx, requestBody)
+		if err == nil {
+			return response, nil
+		}
+
+		if errors.Is(err, errSessionLost) && reconnect {
+			reconnect = false
+			if err := c.reconnect(ctx, usedSessionID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var transient *transientError
+		if !errors.As(err, &transient) || attempt >= c.maxAttempts {
+			return nil, err
+		}
+
+		delay := c.backoffDelay(attempt)
+		slog.Warn("MCP request failed, retrying", "server", c.serverURL, "method", request.Method,
+			"error", err, "retryIn", delay, "attempt", attempt+1, "maxAttempts", c.maxAttempts)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// send performs a single HTTP round trip and returns the decoded response
+// along with the session ID the request was sent with
+func (c *MCPClient) send(ctx context.Context, requestBody []byte) (*MCPResponse, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.serverURL, bytes.NewReader(requestBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, "", fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	if c.sessionID != "" {
-		req.Header.Set("Mcp-Session-Id", c.sessionID)
+	sessionID := c.getSessionID()
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
 	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
+		if ctx.Err() != nil {
+			return nil, sessionID, fmt.Errorf("failed to send HTTP request: %w", err)
+		}
+		return nil, sessionID, &transientError{fmt.Errorf("failed to send HTTP request: %w", err)}
 	}
 	defer resp.Body.Close()
 
+	// A 404 for a request that carried a session ID means the session expired
+	if resp.StatusCode == http.StatusNotFound && sessionID != "" {
+		return nil, sessionID, errSessionLost
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, sessionID, &transientError{fmt.Errorf("MCP server returned status %d", resp.StatusCode)}
+	}
+
 	// Extract session ID from response headers
-	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
-		c.sessionID = sessionID
+	if newSessionID := resp.Header.Get("Mcp-Session-Id"); newSessionID != "" {
+		c.setSessionID(newSessionID)
 	}
 
 	var mcpResponse MCPResponse
 	if err := json.NewDecoder(resp.Body).Decode(&mcpResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, sessionID, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return &mcpResponse, nil
+	if mcpResponse.Error != nil && strings.Contains(strings.ToLower(mcpResponse.Error.Message), "session not found") {
+		return nil, sessionID, errSessionLost
+	}
+
+	return &mcpResponse, sessionID, nil
 }
 
-// generateID generates a unique ID for requests
+// reconnect discards a lost session and repeats the Initialize handshake with
+// the original client info. Concurrent callers that lost the same session share
+// a single re-initialization.
+func (c *MCPClient) reconnect(ctx context.Context, lostSessionID string) error {
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+
+	c.sessionMu.Lock()
+	if c.sessionID != lostSessionID {
+		// Another request already established a new session
+		c.sessionMu.Unlock()
+		return nil
+	}
+	c.sessionID = ""
+	clientInfo, initialized := c.clientInfo, c.initialized
+	c.sessionMu.Unlock()
+
+	if !initialized {
+		// Without a prior handshake, resending without a session is enough
+		return nil
+	}
+
+	slog.Warn("MCP session lost, re-initializing", "server", c.serverURL)
+	if err := c.initialize(ctx, clientInfo); err != nil {
+		return fmt.Errorf("failed to re-initialize lost MCP session: %w", err)
+	}
+	return nil
+}
+
+// backoffDelay returns the exponential backoff delay after the given attempt
+func (c *MCPClient) backoffDelay(attempt int) time.Duration {
+	delay := c.retryBaseDelay << (attempt - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// generateID returns the next request ID. IDs increase monotonically per
+// client, so concurrent requests never share an ID.
 func (c *MCPClient) generateID() int64 {
-	return time.Now().UnixNano()
+	return c.lastID.Add(1)
+}
+
+// getSessionID returns the current session identifier
+func (c *MCPClient) getSessionID() string {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
+	return c.sessionID
+}
+
+// setSessionID stores the session identifier assigned by the server
+func (c *MCPClient) setSessionID(sessionID string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.sessionID = sessionID
 }
 
 // Close closes the MCP client connection
 func (c *MCPClient) Close(ctx context.Context) error {
-	if c.sessionID == "" {
+	sessionID := c.getSessionID()
+	if sessionID == "" {
 		return nil
 	}
 
@@ -264,7 +445,7 @@ func (c *MCPClient) Close(ctx context.Context) error {
 		return fmt.Errorf("failed to create close request: %w", err)
 	}
 
-	req.Header.Set("Mcp-Session-Id", c.sessionID)
+	req.Header.Set("Mcp-Session-Id", sessionID)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -272,6 +453,6 @@ func (c *MCPClient) Close(ctx context.Context) error {
 	}
 	defer resp.Body.Close()
 
-	c.sessionID = ""
+	c.setSessionID("")
 	return nil
 }
\ No newline at end of file
@@ -26,6 +26,9 @@ type TokenInfo struct {
 	Scope        string    `json:"scope"`        // OAuth2 scopes granted to the token
 }
 
+// RoleTypeAdministrator is the Backlog roleType of space administrators
+const RoleTypeAdministrator = 1
+
 // UserInfo represents Backlog user information retrieved from the API.
 // It contains user profile data and account details from Backlog.
 type UserInfo struct {
@@ -53,9 +56,10 @@ type AuthResponse struct {
 // JWTClaims represents JWT token claims for session management.
 // It extends the standard JWT claims with application-specific data.
 type JWTClaims struct {
-	UserID       int    `json:"userId"`       // Backlog user ID for user identification
-	BacklogToken string `json:"backlogToken"` // Backlog access token for API calls
-	jwt.RegisteredClaims                      // Standard JWT claims (exp, iat, etc.)
+	UserID               int    `json:"userId"`                        // Backlog user ID for user identification
+	BacklogToken         string `json:"backlogToken"`                  // Backlog access token for API calls
+	BacklogRefreshToken  string `json:"backlogRefreshToken,omitempty"` // Backlog refresh token, sealed with AES-GCM, used to renew the access token
+	jwt.RegisteredClaims        // Standard JWT claims (exp, iat, etc.)
 }
 
 // JWT Claims interface implementation methods
@@ -95,4 +99,15 @@ func (c *JWTClaims) GetSubject() (string, error) {
 // It delegates to the embedded RegisteredClaims for standard JWT aud claim handling.
 func (c *JWTClaims) GetAudience() (jwt.ClaimStrings, error) {
 	return c.RegisteredClaims.GetAudience()
-}
\ No newline at end of file
+}
+
+// AICredentials are AI provider credentials supplied by a tenant for its own
+// requests, replacing the server's shared credentials. They are never persisted.
+type AICredentials struct {
+	Provider           string `json:"provider"`                     // AI service: "openai", "bedrock", "gemini", or "anthropic" (defaults to the configured provider)
+	APIKey             string `json:"apiKey,omitempty"`             // API key for OpenAI, Gemini, or Anthropic
+	AWSAccessKeyID     string `json:"awsAccessKeyId,omitempty"`     // AWS access key for Bedrock
+	AWSSecretAccessKey string `json:"awsSecretAccessKey,omitempty"` // AWS secret key for Bedrock
+	AWSSessionToken    string `json:"awsSessionToken,omitempty"`    // Optional AWS session token for temporary Bedrock credentials
+	AWSRegion          string `json:"awsRegion,omitempty"`          // AWS region for Bedrock (defaults to the configured region)
+}
@@ -54,8 +54,47 @@ const (
 	// ThemeSummaryPlan provides project summaries, key achievements,
 	// and future planning recommendations
 	ThemeSummaryPlan SlideTheme = "summary_plan"
+	
+	// ThemePeriodComparison compares issue statistics for the latest week
+	// against the week before it
+	ThemePeriodComparison SlideTheme = "period_comparison"
+
+	// ThemeSpaceOverview summarizes the whole Backlog space rather than one
+	// project: users, project count, disk usage, and licence
+	ThemeSpaceOverview SlideTheme = "space_overview"
+
+	// ThemePortfolioComparison compares the progress of several projects side
+	// by side for a portfolio view
+	ThemePortfolioComparison SlideTheme = "portfolio_comparison"
 )
 
+// AllSlideThemes lists every built-in slide theme
+var AllSlideThemes = []SlideTheme{
+	ThemeProjectOverview,
+	ThemeProjectProgress,
+	ThemeIssueManagement,
+	ThemeRiskAnalysis,
+	ThemeTeamCollaboration,
+	ThemeDocumentManagement,
+	ThemeCodebaseActivity,
+	ThemeNotifications,
+	ThemePredictiveAnalysis,
+	ThemeSummaryPlan,
+	ThemePeriodComparison,
+	ThemeSpaceOverview,
+	ThemePortfolioComparison,
+}
+
+// IsKnown reports whether the theme is one of the built-in slide themes
+func (t SlideTheme) IsKnown() bool {
+	for _, theme := range AllSlideThemes {
+		if t == theme {
+			return true
+		}
+	}
+	return false
+}
+
 // ProjectID is a custom type that can handle both string and number types from JSON.
 // Backlog APIs may return project IDs as either strings or numbers, so this type
 // provides flexible unmarshaling to ensure compatibility with different API responses.
@@ -95,9 +134,24 @@ func (p ProjectID) String() string {
 // SlideGenerationRequest represents a client request to generate presentation slides.
 // It specifies which project to analyze, what themes to include, and the target language.
 type SlideGenerationRequest struct {
-	ProjectID ProjectID    `json:"projectId" binding:"required"` // Backlog project identifier
-	Themes    []SlideTheme `json:"themes" binding:"required"`    // List of slide themes to generate
-	Language  string       `json:"language" binding:"required"`  // Target language ("ja" or "en")
+	ProjectID   ProjectID       `json:"projectId" binding:"required"` // Backlog project identifier
+	Themes      []SlideTheme    `json:"themes" binding:"required"`    // List of slide themes to generate
+	Language    string          `json:"language" binding:"required"`  // Target language (ja, en, es, fr, hi, it, pt, zh), or "auto" to use the user's Backlog language
+	Speed       float64         `json:"speed,omitempty"`              // Deck-level narration speed multiplier (1.0 = normal)
+	SlideSpeeds map[int]float64 `json:"slideSpeeds,omitempty"`        // Per-slide speed overrides keyed by slide index
+	ForceRegenerate bool        `json:"forceRegenerate,omitempty"`    // Generate every slide anew instead of reusing cached slides
+	TransitionCues  bool        `json:"transitionCues,omitempty"`     // End each narration with a pause cue for auto-advancing playback
+	AutoOrder       bool        `json:"autoOrder,omitempty"`          // Reorder themes into the configured canonical deck flow
+	ProjectIDs      []ProjectID `json:"projectIds,omitempty"`         // Further projects compared with ProjectID on portfolio_comparison slides
+	Template        *DeckTemplate `json:"template,omitempty"`         // Deck template shaping the generated deck
+}
+
+// DeckTemplate describes the shape of a generated deck. When TargetSlides is
+// above the number of requested themes, themes are split across several slides
+// so that the deck reaches the target.
+type DeckTemplate struct {
+	Name         string `json:"name,omitempty"`         // Template name, for display only
+	TargetSlides int    `json:"targetSlides,omitempty"` // Number of slides the deck aims for; 0 keeps one slide per theme
 }
 
 // SlideGenerationResponse represents the server response to a slide generation request.
@@ -106,6 +160,14 @@ type SlideGenerationResponse struct {
 	SlideID      string `json:"slideId"`      // Unique identifier for this generation session
 	Status       string `json:"status"`       // Current generation status
 	WebSocketURL string `json:"websocketUrl"` // WebSocket endpoint for real-time updates
+	Language     string `json:"language"`     // Deck language, resolved when "auto" was requested
+}
+
+// SlideRegenerationRequest represents a request to regenerate a single slide of an
+// existing deck. Either SlideIndex or Theme must identify the slide to replace.
+type SlideRegenerationRequest struct {
+	SlideIndex *int       `json:"slideIndex,omitempty"` // Position of the slide to regenerate (0-based)
+	Theme      SlideTheme `json:"theme,omitempty"`      // Theme of the slide to regenerate, used when no index is given
 }
 
 // SlideContent represents a complete slide with both markdown source and rendered HTML.
@@ -115,15 +177,55 @@ type SlideContent struct {
 	Theme       SlideTheme `json:"theme"`       // Theme that generated this slide
 	Title       string     `json:"title"`       // Slide title for navigation and display
 	Markdown    string     `json:"markdown"`    // Source markdown content
+	PlainText   string     `json:"plainText"`   // Markdown stripped to plain text for search and accessibility
 	HTML        string     `json:"html"`        // Rendered HTML content (LLM-generated)
+	ContentHash string     `json:"contentHash"` // Stable hash of title and markdown for change detection
+	TokensUsed  int        `json:"tokensUsed"`  // Estimated AI tokens (prompt and response) spent on the slide
+	Limitations []string   `json:"limitations,omitempty"` // Data sources that could not be accessed for this slide
+	DiagramAltTexts []DiagramAltText `json:"diagramAltTexts,omitempty"` // Screen reader descriptions of the slide's diagrams, in markdown order
 	GeneratedAt time.Time  `json:"generatedAt"` // Timestamp when slide was created
 }
 
+// DiagramAltText is an accessible text description of one diagram on a slide
+type DiagramAltText struct {
+	Index   int    `json:"index"`   // Position of the diagram among the slide's diagrams (0-based)
+	Kind    string `json:"kind"`    // Diagram source: "mermaid" or "chart" (Chart.js)
+	AltText string `json:"altText"` // Description of what the diagram shows
+}
+
+// SlideSessionRecord is the persistable snapshot of a slide generation session.
+// It holds everything needed to restore a deck after a backend restart,
+// excluding live WebSocket connections.
+type SlideSessionRecord struct {
+	ID             string            `json:"id"`                       // Unique identifier for the generation session
+	ProjectID      ProjectID         `json:"projectId"`                // Backlog project the deck was generated for
+	ProjectIDs     []ProjectID       `json:"projectIds,omitempty"`     // Further projects compared on portfolio comparison slides
+	Themes         []SlideTheme      `json:"themes"`                   // Requested slide themes in presentation order
+	Language       string            `json:"language"`                 // Target language for the deck
+	Speed          float64           `json:"speed,omitempty"`          // Deck-level narration speed multiplier
+	SlideSpeeds    map[int]float64   `json:"slideSpeeds,omitempty"`    // Per-slide narration speed overrides
+	Temperature    *float64          `json:"temperature,omitempty"`    // Admin override of the AI sampling temperature
+	TransitionCues bool              `json:"transitionCues,omitempty"` // Narration ends with a transition cue
+	Status         string            `json:"status"`                   // Current generation status
+	Slides         []*SlideContent   `json:"slides"`                   // Generated slide content
+	Narrations     []*SlideNarration `json:"narrations"`               // Generated narration text
+	AudioFiles     []*SlideAudio     `json:"audioFiles"`               // Generated audio metadata
+	Warnings       []*SlideWarning   `json:"warnings,omitempty"`       // Non-fatal problems recorded during generation
+	Timings        []*ThemeTiming    `json:"timings,omitempty"`        // Per-slide generation stage durations
+	CreatedAt      time.Time         `json:"createdAt"`                // Timestamp when the session was created
+	UpdatedAt      time.Time         `json:"updatedAt"`                // Timestamp of the last persisted change
+	CompletedAt    time.Time         `json:"completedAt,omitempty"`    // Timestamp when generation finished or failed
+	Version        int64             `json:"version,omitempty"`        // Snapshot sequence number; stores keep the highest version saved
+}
+
 // SlideNarration represents narration text for a slide
 type SlideNarration struct {
-	SlideIndex int    `json:"slideIndex"`
-	Text       string `json:"text"`
-	Language   string `json:"language"`
+	SlideIndex      int     `json:"slideIndex"`
+	Text            string  `json:"text"`
+	Language        string  `json:"language"`
+	Speed           float64 `json:"speed,omitempty"`           // Speech speed multiplier used for audio synthesis
+	TransitionPause int     `json:"transitionPause,omitempty"` // Seconds to hold the slide after the narration ends
+	TokensUsed      int     `json:"tokensUsed"`                // Estimated AI tokens (prompt and response) spent on the narration
 }
 
 // SlideAudio represents audio information for a slide
@@ -131,6 +233,247 @@ type SlideAudio struct {
 	SlideIndex int    `json:"slideIndex"`
 	AudioURL   string `json:"audioUrl"`
 	Duration   int    `json:"duration"` // in seconds
+	AdvanceAfter int  `json:"advanceAfter,omitempty"` // Seconds from the start of the audio until an auto-advancing player moves on
+}
+
+// SlideWarning represents a non-fatal problem recorded while generating a slide,
+// such as a failed narration or audio synthesis
+type SlideWarning struct {
+	SlideIndex int    `json:"slideIndex"`
+	Message    string `json:"message"`
+}
+
+// SlideReport summarizes the generated output for a single slide
+type SlideReport struct {
+	Index         int        `json:"index"`
+	Title         string     `json:"title"`
+	Theme         SlideTheme `json:"theme"`
+	WordCount     int        `json:"wordCount"`     // Words in the slide's plain text
+	AudioDuration int        `json:"audioDuration"` // in seconds, 0 when no audio was generated
+	TokensUsed    int        `json:"tokensUsed"`    // Estimated tokens for slide content and narration
+	Warnings      []string   `json:"warnings"`
+}
+
+// Generation stages timed for each slide
+const (
+	TimingStageContent   = "content"
+	TimingStageNarration = "narration"
+	TimingStageAudio     = "audio"
+)
+
+// ThemeTiming records how long each generation stage of a slide took, in
+// milliseconds. Stages that did not complete are reported as 0.
+type ThemeTiming struct {
+	Index       int        `json:"index"`
+	Theme       SlideTheme `json:"theme"`
+	ContentMs   int64      `json:"contentMs"`
+	NarrationMs int64      `json:"narrationMs"`
+	AudioMs     int64      `json:"audioMs"` // Synthesis time, excluding time queued behind other audio
+	TotalMs     int64      `json:"totalMs"`
+}
+
+// GenerationReport is a one-page summary of a completed slide generation session
+type GenerationReport struct {
+	SlideID            string         `json:"slideId"`
+	ProjectID          ProjectID      `json:"projectId"`
+	Status             string         `json:"status"`
+	Language           string         `json:"language"`
+	Slides             []*SlideReport `json:"slides"`
+	TotalSlides        int            `json:"totalSlides"`
+	TotalWords         int            `json:"totalWords"`
+	TotalAudioDuration int            `json:"totalAudioDuration"` // in seconds
+	TotalTokens        int            `json:"totalTokens"`        // estimated, see SlideContent.TokensUsed
+	EstimatedCost      float64        `json:"estimatedCost"`      // USD for TotalTokens at CostPerMillion
+	CostPerMillion     float64        `json:"costPerMillion"`     // USD per million tokens used for the estimate
+	Warnings           []string       `json:"warnings"`           // warnings not tied to a generated slide
+	Timings            []*ThemeTiming `json:"timings"`            // per-slide stage durations, ordered by index
+}
+
+// PresentationJSONLD is a slide deck described as a schema.org
+// PresentationDigitalDocument for structured sharing
+type PresentationJSONLD struct {
+	Context      string         `json:"@context"`
+	Type         string         `json:"@type"`
+	Identifier   string         `json:"identifier"`
+	Name         string         `json:"name"`
+	About        string         `json:"about"` // Backlog project key
+	InLanguage   string         `json:"inLanguage,omitempty"`
+	Keywords     []SlideTheme   `json:"keywords,omitempty"`
+	DateCreated  string         `json:"dateCreated"`
+	DateModified string         `json:"dateModified,omitempty"`
+	HasPart      []*SlideJSONLD `json:"hasPart"` // Slides in presentation order
+}
+
+// SlideJSONLD is a single slide of a PresentationJSONLD deck
+type SlideJSONLD struct {
+	Type            string       `json:"@type"`
+	Position        int          `json:"position"`
+	Name            string       `json:"name"`
+	Genre           SlideTheme   `json:"genre,omitempty"`
+	Text            string       `json:"text"` // Slide content as plain text
+	DateCreated     string       `json:"dateCreated,omitempty"`
+	AssociatedMedia *AudioJSONLD `json:"associatedMedia,omitempty"`
+}
+
+// AudioJSONLD is the narration audio of a slide as a schema.org AudioObject
+type AudioJSONLD struct {
+	Type       string `json:"@type"`
+	ContentURL string `json:"contentUrl"`
+	Duration   string `json:"duration,omitempty"`   // ISO 8601 duration, e.g. "PT42S"
+	Transcript string `json:"transcript,omitempty"` // Narration text
+}
+
+// IssueStats represents issue completion statistics used for progress analysis.
+// Issues are grouped into open, in-progress, and closed status categories.
+type IssueStats struct {
+	TotalIssues        int     `json:"totalIssues"`
+	OpenIssues         int     `json:"openIssues"`
+	InProgressIssues   int     `json:"inProgressIssues"` // in progress, resolved, or custom statuses
+	CompletedIssues    int     `json:"completedIssues"`
+	OverdueIssues      int     `json:"overdueIssues"` // not closed and past their due date
+	ExcludedDuplicates int     `json:"excludedDuplicates"`
+	CompletionRate     float64 `json:"completionRate"` // percentage from 0 to 100
+}
+
+// DeckManifestSlide describes one slide in an exported deck bundle; file
+// fields are paths inside the bundle
+type DeckManifestSlide struct {
+	Index         int        `json:"index"`
+	Title         string     `json:"title"`
+	Theme         SlideTheme `json:"theme"`
+	MarkdownFile  string     `json:"markdownFile"`
+	HTMLFile      string     `json:"htmlFile,omitempty"`
+	AudioFile     string     `json:"audioFile,omitempty"`     // Empty when the slide has no usable audio
+	AudioDuration int        `json:"audioDuration,omitempty"` // Audio length in seconds
+	Narration     string     `json:"narration,omitempty"`
+}
+
+// DeckManifest is the manifest.json of an exported deck bundle
+type DeckManifest struct {
+	SlideID    string               `json:"slideId"`
+	ProjectID  ProjectID            `json:"projectId"`
+	Language   string               `json:"language"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	ExportedAt time.Time            `json:"exportedAt"`
+	Slides     []*DeckManifestSlide `json:"slides"`
+}
+
+// TeleprompterEntry pairs a slide with its narration for a presenter's
+// teleprompter view, timed for auto-scrolling
+type TeleprompterEntry struct {
+	Index           int        `json:"index"`
+	Title           string     `json:"title"`
+	Theme           SlideTheme `json:"theme"`
+	Narration       string     `json:"narration"`
+	Lines           []string   `json:"lines"`           // Narration split into sentences
+	StartSeconds    int        `json:"startSeconds"`    // Offset from the start of the presentation
+	DurationSeconds int        `json:"durationSeconds"` // Time to spend on the slide
+	TimingSource    string     `json:"timingSource"`    // "audio" when measured from audio, "estimate" otherwise
+}
+
+// Teleprompter is the speaker-mode view of a deck, slides in presentation order
+type Teleprompter struct {
+	SlideID      string               `json:"slideId"`
+	Language     string               `json:"language"`
+	Entries      []*TeleprompterEntry `json:"entries"`
+	TotalSeconds int                  `json:"totalSeconds"`
+}
+
+// Timing sources of a TeleprompterEntry
+const (
+	TimingSourceAudio    = "audio"
+	TimingSourceEstimate = "estimate"
+)
+
+// IssueStatsDelta is the change in issue statistics from one period to the next
+type IssueStatsDelta struct {
+	TotalIssues      int     `json:"totalIssues"`
+	OpenIssues       int     `json:"openIssues"`
+	InProgressIssues int     `json:"inProgressIssues"`
+	CompletedIssues  int     `json:"completedIssues"`
+	OverdueIssues    int     `json:"overdueIssues"`
+	CompletionRate   float64 `json:"completionRate"` // percentage points
+}
+
+// PeriodStats holds issue statistics for issues updated within a date range
+type PeriodStats struct {
+	Since string      `json:"since"` // YYYY-MM-DD, inclusive
+	Until string      `json:"until"` // YYYY-MM-DD, inclusive
+	Stats *IssueStats `json:"stats"`
+}
+
+// PortfolioProject holds one project's progress in a portfolio comparison
+type PortfolioProject struct {
+	ProjectID  string      `json:"projectId"`            // Identifier the project was requested by
+	ProjectKey string      `json:"projectKey,omitempty"` // Backlog project key
+	Name       string      `json:"name,omitempty"`       // Project display name
+	Stats      *IssueStats `json:"stats"`                // Issue statistics of the project's latest issues
+}
+
+// PeriodComparison compares issue statistics between two consecutive periods
+type PeriodComparison struct {
+	Previous PeriodStats     `json:"previous"`
+	Current  PeriodStats     `json:"current"`
+	Delta    IssueStatsDelta `json:"delta"` // current minus previous
+}
+
+// BurndownSeries is the number of unfinished issues at the end of each day,
+// computed from issue creation dates and the last update of closed issues
+type BurndownSeries struct {
+	Labels    []string `json:"labels"`    // Dates in YYYY-MM-DD format, oldest first
+	Remaining []int    `json:"remaining"` // Issues created but not yet closed at the end of each date
+}
+
+// RiskIssue represents an issue flagged by one or more risk signals
+type RiskIssue struct {
+	IssueKey string   `json:"issueKey"`
+	Summary  string   `json:"summary"`
+	Signals  []string `json:"signals"` // e.g., "overdue", "due_soon", "unassigned_high_priority", "stalled"
+	Score    int      `json:"score"`   // weighted sum of the issue's signals
+}
+
+// RiskSummary represents risk signals computed from issue due dates, priorities,
+// assignees, and update activity, used to ground the risk-analysis slide
+type RiskSummary struct {
+	OverdueIssues          int          `json:"overdueIssues"`
+	DueSoonIssues          int          `json:"dueSoonIssues"`
+	UnassignedHighPriority int          `json:"unassignedHighPriority"`
+	StalledIssues          int          `json:"stalledIssues"`
+	TotalScore             int          `json:"totalScore"`
+	TopRisks               []*RiskIssue `json:"topRisks"` // highest scoring issues first
+}
+
+// IssuePage is one page of a project's issues for client-side browsing
+type IssuePage struct {
+	Issues []interface{} `json:"issues"`
+	Offset int           `json:"offset"` // Position of the first issue in the full list
+	Count  int           `json:"count"`  // Requested page size
+	Total  int           `json:"total"`  // Number of issues in the project
+}
+
+// IssueNode is an issue in a parent/child issue tree
+type IssueNode struct {
+	ID       int          `json:"id"`
+	IssueKey string       `json:"issueKey"`
+	Summary  string       `json:"summary"`
+	Children []*IssueNode `json:"children"` // Child issues ordered by ID
+}
+
+// IssueHierarchy is a project's issues arranged by parent issue. Parent
+// references that loop are reported in Cycles and broken so the trees are finite.
+type IssueHierarchy struct {
+	Roots  []*IssueNode `json:"roots"`  // Issues without a parent in the list, ordered by ID
+	Cycles [][]string   `json:"cycles"` // Issue keys of each parent cycle, following parent references
+}
+
+// WatcherStats represents watcher counts per issue used for engagement metrics.
+// Backlog only exposes watch lists per user, so counts reflect the watch lists
+// that could be read rather than necessarily every watcher.
+type WatcherStats struct {
+	Scope         string         `json:"scope"`          // Whose watch lists were counted ("space_users" or "current_user")
+	WatchedIssues int            `json:"watchedIssues"`  // Number of distinct issues with at least one watcher
+	Counts        map[string]int `json:"counts"`         // Watcher count keyed by issue key
+	Note          string         `json:"note,omitempty"` // Explanation of data limitations
 }
 
 // SlideGenerationStarted represents the start of slide generation
@@ -139,12 +482,38 @@ type SlideGenerationStarted struct {
 	Theme      SlideTheme `json:"theme"`
 }
 
+// SlidePartial carries a fragment of slide markdown streamed from the AI
+// provider. Partials are not replayed; the final slide_content message is authoritative.
+type SlidePartial struct {
+	SlideIndex int    `json:"slideIndex"`
+	Delta      string `json:"delta"`
+}
+
 // PresentationComplete represents completion of slide generation
 type PresentationComplete struct {
 	TotalSlides int    `json:"totalSlides"`
 	Duration    string `json:"duration"`
 }
 
+// GenerationProgress represents overall deck progress, sent after each slide
+// finishes (successfully or not) including its narration and audio
+type GenerationProgress struct {
+	Completed int `json:"completed"` // Number of slides finished so far
+	Total     int `json:"total"`     // Number of slides in the deck
+	Percent   int `json:"percent"`   // Completed slides as a percentage from 0 to 100
+}
+
+// SessionState summarizes a session when a WebSocket client connects so that
+// reconnecting clients can resync before earlier messages are replayed
+type SessionState struct {
+	Status      string `json:"status"`      // generating, completed or error
+	TotalSlides int    `json:"totalSlides"` // Number of slides in the deck
+	Slides      int    `json:"slides"`      // Slides whose content has been generated
+	Narrations  int    `json:"narrations"`  // Slides whose narration has been generated
+	AudioFiles  int    `json:"audioFiles"`  // Slides whose audio has been synthesized
+	Warnings    int    `json:"warnings"`    // Non-fatal warnings recorded so far
+}
+
 // WebSocketMessage represents messages sent through WebSocket
 type WebSocketMessage struct {
 	Type string      `json:"type"`
@@ -155,9 +524,12 @@ type WebSocketMessage struct {
 const (
 	MessageTypeSlideGenerationStarted = "slide_generation_started"
 	MessageTypeSlideContent           = "slide_content"
+	MessageTypeSlidePartial           = "slide_partial"
 	MessageTypeSlideNarration        = "slide_narration"
 	MessageTypeSlideAudio            = "slide_audio"
 	MessageTypePresentationComplete   = "presentation_complete"
+	MessageTypeProgress               = "progress"
+	MessageTypeSessionState           = "session_state"
 	MessageTypeError                 = "error"
 )
 
@@ -165,4 +537,12 @@ const (
 type ErrorMessage struct {
 	Message string `json:"message"`
 	Code    string `json:"code"`
-}
\ No newline at end of file
+	Status  int    `json:"status,omitempty"` // HTTP-style status for errors that map to one (e.g., 413)
+	Hint    string `json:"hint,omitempty"`   // User-facing suggestion for resolving the error
+}
+
+// Error codes carried by ErrorMessage
+const (
+	ErrorCodeGeneration   = "GENERATION_ERROR"
+	ErrorCodeDataTooLarge = "DATA_TOO_LARGE"
+)
\ No newline at end of file
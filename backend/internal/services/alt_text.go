@@ -0,0 +1,315 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// maxAltTextItems is the number of data points or diagram nodes listed
+// individually before a description falls back to a summary
+const maxAltTextItems = 8
+
+var (
+	mermaidNodeLabel = regexp.MustCompile(`\w+\s*[\[\(\{>]+"?([^\]\)\}"]+)"?[\]\)\}]+`)
+	mermaidPieSlice  = regexp.MustCompile(`^"([^"]+)"\s*:\s*([\d.]+)`)
+)
+
+// DiagramAltTexts describes each Mermaid diagram and Chart.js chart in slide
+// markdown for screen readers. Descriptions are computed from the diagram
+// definitions themselves, so they always match the rendered data and cost no
+// AI tokens. Japanese decks get Japanese descriptions and other languages get
+// English ones.
+//
+// Parameters:
+//   - markdown: Source markdown content of a slide
+//   - language: Deck language
+//
+// Returns one alt text per diagram in markdown order, or nil if there are none.
+func DiagramAltTexts(markdown, language string) []models.DiagramAltText {
+	var altTexts []models.DiagramAltText
+	var block []string
+	fence, inBlock := "", false
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "```") {
+			if inBlock {
+				block = append(block, line)
+			}
+			continue
+		}
+		if !inBlock {
+			fence, inBlock, block = strings.TrimSpace(strings.TrimPrefix(trimmed, "```")), true, nil
+			continue
+		}
+		inBlock = false
+
+		source := strings.Join(block, "\n")
+		var altText models.DiagramAltText
+		switch fence {
+		case "mermaid":
+			altText = models.DiagramAltText{Kind: "mermaid", AltText: mermaidAltText(source, language)}
+		case "json", "chart", "chartjs":
+			text, ok := chartAltText(source, language)
+			if !ok {
+				continue
+			}
+			altText = models.DiagramAltText{Kind: "chart", AltText: text}
+		default:
+			continue
+		}
+		altText.Index = len(altTexts)
+		altTexts = append(altTexts, altText)
+	}
+	return altTexts
+}
+
+// chartAltText describes a Chart.js configuration. Datasets may hold numbers or
+// {x, y} points; other data, such as gaps, is described only by its size. It
+// reports false when the JSON block is not a chart configuration.
+func chartAltText(source, language string) (string, bool) {
+	var chart struct {
+		Type string `json:"type"`
+		Data struct {
+			Labels   []interface{} `json:"labels"`
+			Datasets []struct {
+				Label string            `json:"label"`
+				Data  []json.RawMessage `json:"data"`
+			} `json:"datasets"`
+		} `json:"data"`
+		Options struct {
+			Plugins struct {
+				Title struct {
+					Text string `json:"text"`
+				} `json:"title"`
+			} `json:"plugins"`
+		} `json:"options"`
+	}
+	if err := json.Unmarshal([]byte(source), &chart); err != nil || chart.Type == "" || len(chart.Data.Datasets) == 0 {
+		return "", false
+	}
+
+	labels := make([]string, len(chart.Data.Labels))
+	for i, label := range chart.Data.Labels {
+		labels[i] = fmt.Sprint(label)
+	}
+
+	parts := []string{titled(chartTypeName(chart.Type, language), chart.Options.Plugins.Title.Text, language)}
+	for _, dataset := range chart.Data.Datasets {
+		if len(dataset.Data) == 0 {
+			continue
+		}
+		values, points, ok := seriesValues(dataset.Data)
+		switch {
+		case !ok && language == "ja":
+			parts = append(parts, fmt.Sprintf("%s: %d点のデータ。", dataset.Label, len(dataset.Data)))
+		case !ok:
+			parts = append(parts, fmt.Sprintf("%s: %d data points.", dataset.Label, len(dataset.Data)))
+		case len(labels) == 0:
+			parts = append(parts, describeSeries(dataset.Label, points, values, language))
+		default:
+			parts = append(parts, describeSeries(dataset.Label, labels, values, language))
+		}
+	}
+	if language == "ja" {
+		return strings.Join(parts, ""), true
+	}
+	return strings.Join(parts, " "), true
+}
+
+// seriesValues reads the values of a Chart.js dataset given as numbers or as
+// {x, y} points, along with the x of each point. It reports false when any
+// value is missing or of another shape.
+func seriesValues(data []json.RawMessage) (values []float64, points []string, ok bool) {
+	for _, raw := range data {
+		var value float64
+		if err := json.Unmarshal(raw, &value); err == nil && string(raw) != "null" {
+			values = append(values, value)
+			points = append(points, strconv.Itoa(len(values)))
+			continue
+		}
+		var point struct {
+			X interface{} `json:"x"`
+			Y *float64    `json:"y"`
+		}
+		if err := json.Unmarshal(raw, &point); err != nil || point.Y == nil {
+			return nil, nil, false
+		}
+		values = append(values, *point.Y)
+		if point.X != nil {
+			points = append(points, fmt.Sprint(point.X))
+		} else {
+			points = append(points, strconv.Itoa(len(values)))
+		}
+	}
+	return values, points, true
+}
+
+// describeSeries lists each value of a data series, or summarizes the first,
+// last, lowest, and highest values when there are too many to list
+func describeSeries(name string, labels []string, values []float64, language string) string {
+	label := func(i int) string {
+		if i < len(labels) {
+			return labels[i]
+		}
+		return strconv.Itoa(i + 1)
+	}
+
+	if len(values) <= maxAltTextItems {
+		items := make([]string, len(values))
+		for i, value := range values {
+			items[i] = fmt.Sprintf("%s %s", label(i), formatValue(value))
+		}
+		if language == "ja" {
+			return fmt.Sprintf("%s: %s。", name, strings.Join(items, "、"))
+		}
+		return fmt.Sprintf("%s: %s.", name, strings.Join(items, ", "))
+	}
+
+	low, high := values[0], values[0]
+	for _, value := range values {
+		if value < low {
+			low = value
+		}
+		if value > high {
+			high = value
+		}
+	}
+	first, last := 0, len(values)-1
+	if language == "ja" {
+		return fmt.Sprintf("%s: %d点、%s時点 %s から %s時点 %s（最小 %s、最大 %s）。",
+			name, len(values), label(first), formatValue(values[first]), label(last), formatValue(values[last]), formatValue(low), formatValue(high))
+	}
+	return fmt.Sprintf("%s: %d points, from %s at %s to %s at %s (lowest %s, highest %s).",
+		name, len(values), formatValue(values[first]), label(first), formatValue(values[last]), label(last), formatValue(low), formatValue(high))
+}
+
+// mermaidAltText describes a Mermaid diagram from its definition: pie chart
+// slices with their values, or the labels of the nodes of other diagrams
+func mermaidAltText(source, language string) string {
+	var kind, title string
+	var items []string
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "%%") {
+			continue
+		}
+		if kind == "" {
+			fields := strings.Fields(trimmed)
+			kind = fields[0]
+			if kind == "pie" && len(fields) > 2 && fields[1] == "title" {
+				title = strings.Join(fields[2:], " ")
+			}
+			continue
+		}
+		if rest, ok := strings.CutPrefix(trimmed, "title "); ok {
+			title = strings.TrimSpace(rest)
+			continue
+		}
+
+		var found []string
+		switch {
+		case kind == "pie":
+			if match := mermaidPieSlice.FindStringSubmatch(trimmed); match != nil {
+				found = []string{match[1] + " " + match[2]}
+			}
+		case kind == "gantt":
+			if name, _, ok := strings.Cut(trimmed, ":"); ok && !strings.Contains(name, "Format") && name != "excludes" {
+				found = []string{strings.TrimSpace(name)}
+			}
+		default:
+			for _, match := range mermaidNodeLabel.FindAllStringSubmatch(trimmed, -1) {
+				found = append(found, strings.TrimSpace(match[1]))
+			}
+		}
+		for _, item := range found {
+			if item != "" && !seen[item] {
+				seen[item] = true
+				items = append(items, item)
+			}
+		}
+	}
+
+	description := titled(mermaidKindName(kind, language), title, language)
+	if len(items) == 0 {
+		return description
+	}
+	more := ""
+	if len(items) > maxAltTextItems {
+		if language == "ja" {
+			more = fmt.Sprintf(" ほか%d件", len(items)-maxAltTextItems)
+		} else {
+			more = fmt.Sprintf(", and %d more", len(items)-maxAltTextItems)
+		}
+		items = items[:maxAltTextItems]
+	}
+	if language == "ja" {
+		return fmt.Sprintf("%s項目: %s%s。", description, strings.Join(items, "、"), more)
+	}
+	return fmt.Sprintf("%s Items: %s%s.", description, strings.Join(items, ", "), more)
+}
+
+// titled names a diagram kind with its title, if any, as the opening sentence
+func titled(kind, title, language string) string {
+	if language == "ja" {
+		if title != "" {
+			return fmt.Sprintf("%s「%s」。", kind, title)
+		}
+		return kind + "。"
+	}
+	if title != "" {
+		return fmt.Sprintf("%s titled %q.", kind, title)
+	}
+	return kind + "."
+}
+
+// chartTypeName names a Chart.js chart type for alt text
+func chartTypeName(chartType, language string) string {
+	names := map[string][2]string{
+		"line":     {"Line chart", "折れ線グラフ"},
+		"bar":      {"Bar chart", "棒グラフ"},
+		"pie":      {"Pie chart", "円グラフ"},
+		"doughnut": {"Doughnut chart", "ドーナツグラフ"},
+		"radar":    {"Radar chart", "レーダーチャート"},
+	}
+	name, ok := names[chartType]
+	if !ok {
+		name = [2]string{"Chart", "グラフ"}
+	}
+	if language == "ja" {
+		return name[1]
+	}
+	return name[0]
+}
+
+// mermaidKindName names a Mermaid diagram type for alt text
+func mermaidKindName(kind, language string) string {
+	names := map[string][2]string{
+		"graph":           {"Flowchart", "フローチャート"},
+		"flowchart":       {"Flowchart", "フローチャート"},
+		"pie":             {"Pie chart", "円グラフ"},
+		"gantt":           {"Gantt chart", "ガントチャート"},
+		"sequenceDiagram": {"Sequence diagram", "シーケンス図"},
+		"timeline":        {"Timeline", "タイムライン"},
+	}
+	name, ok := names[kind]
+	if !ok {
+		name = [2]string{"Diagram", "図"}
+	}
+	if language == "ja" {
+		return name[1]
+	}
+	return name[0]
+}
+
+// formatValue formats a data value without a trailing ".0" for whole numbers
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
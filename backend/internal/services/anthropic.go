@@ -0,0 +1,133 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// anthropicAPIVersion is the Messages API version sent in the anthropic-version header
+const anthropicAPIVersion = "2023-06-01"
+
+// AnthropicService calls the Anthropic Messages API directly with an API key,
+// for users who have Claude access without an AWS account.
+type AnthropicService struct {
+	config *config.Config
+	client *http.Client
+}
+
+// AnthropicErrorResponse represents an error returned by the Anthropic API
+type AnthropicErrorResponse struct {
+	Type  string `json:"type"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func NewAnthropicService(cfg *config.Config) *AnthropicService {
+	return &AnthropicService{
+		config: cfg,
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// GenerateText sends the prompt to the Anthropic Messages API and returns the
+// text of the response.
+func (s *AnthropicService) GenerateText(prompt string) (string, error) {
+	return s.GenerateTextWithTemperature(prompt, DefaultTemperature)
+}
+
+// GenerateTextWithTemperature is GenerateText with an explicit sampling temperature
+func (s *AnthropicService) GenerateTextWithTemperature(prompt string, temperature float64) (string, error) {
+	if s.config.AnthropicAPIKey == "" {
+		return "", fmt.Errorf("Anthropic API key not configured")
+	}
+
+	request := ClaudeMessageRequest{
+		Model:       s.model(),
+		MaxTokens:   1500,
+		Temperature: temperature,
+		Messages: []Message{
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	slog.Debug("Making Anthropic API call", "model", request.Model)
+
+	resp, err := doWithRetry(s.client, s.config, "Anthropic", func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", s.baseURL()+"/messages", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", s.config.AnthropicAPIKey)
+		req.Header.Set("anthropic-version", anthropicAPIVersion)
+		return req, nil
+	})
+	if err != nil {
+		slog.Error("Anthropic API call error", "error", err)
+		return "", fmt.Errorf("failed to call Anthropic API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body bytes.Buffer
+	body.ReadFrom(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		var errorResponse AnthropicErrorResponse
+		if err := json.Unmarshal(body.Bytes(), &errorResponse); err == nil && errorResponse.Error.Message != "" {
+			return "", fmt.Errorf("Anthropic API returned status %d: %s", resp.StatusCode, errorResponse.Error.Message)
+		}
+		return "", fmt.Errorf("Anthropic API returned status %d", resp.StatusCode)
+	}
+
+	var response ClaudeMessageResponse
+	if err := json.Unmarshal(body.Bytes(), &response); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	var text strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no content in response")
+	}
+
+	slog.Debug("Anthropic API call successful")
+	return text.String(), nil
+}
+
+func (s *AnthropicService) model() string {
+	if s.config.AnthropicModel != "" {
+		return s.config.AnthropicModel
+	}
+	return "claude-3-haiku-20240307"
+}
+
+func (s *AnthropicService) baseURL() string {
+	if s.config.AnthropicBaseURL != "" {
+		return strings.TrimRight(s.config.AnthropicBaseURL, "/")
+	}
+	return "https://api.anthropic.com/v1"
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// MaxAudioGap is the longest silence accepted between slides in a full track
+const MaxAudioGap = 10 * time.Second
+
+// localAudioPrefix is the URL prefix of audio generated by the built-in
+// fallback TTS and stored in the backend's own cache directory
+const localAudioPrefix = "/api/v1/speech/audio/"
+
+// WAVFormat describes the sample layout of PCM WAV audio
+type WAVFormat struct {
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+}
+
+// WAVAudio is decoded WAV audio: its format and raw sample data
+type WAVAudio struct {
+	Format WAVFormat
+	Data   []byte
+}
+
+// Duration returns the playing time of the audio
+func (a *WAVAudio) Duration() time.Duration {
+	if a.Format.ByteRate == 0 {
+		return 0
+	}
+	return time.Duration(len(a.Data)) * time.Second / time.Duration(a.Format.ByteRate)
+}
+
+// FullAudioTrack is the narration of a whole deck joined into one WAV file
+type FullAudioTrack struct {
+	WAV           []byte        // Complete WAV file
+	Duration      time.Duration // Playing time including gaps
+	SkippedSlides []int         // Slides whose audio was missing or unusable
+}
+
+// ParseWAV decodes a RIFF WAV file, reading its fmt and data chunks and
+// skipping any others.
+//
+// Parameters:
+//   - data: The complete WAV file
+//
+// Returns the decoded audio, or an error if the file is not a valid WAV file.
+func ParseWAV(data []byte) (*WAVAudio, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, errors.New("not a RIFF WAVE file")
+	}
+
+	audio := &WAVAudio{}
+	var hasFormat, hasData bool
+	for offset := 12; offset+8 <= len(data); {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		start := offset + 8
+		end := start + chunkSize
+		if chunkSize < 0 || end > len(data) {
+			// Streaming encoders may leave the data size unset; use the rest of the file
+			if chunkID != "data" {
+				return nil, fmt.Errorf("truncated %q chunk", chunkID)
+			}
+			end = len(data)
+		}
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize < 16 {
+				return nil, errors.New("fmt chunk is too short")
+			}
+			if err := binary.Read(bytes.NewReader(data[start:start+16]), binary.LittleEndian, &audio.Format); err != nil {
+				return nil, fmt.Errorf("failed to read fmt chunk: %w", err)
+			}
+			hasFormat = true
+		case "data":
+			audio.Data = data[start:end]
+			hasData = true
+		}
+
+		// Chunks are padded to an even size
+		offset = end + chunkSize%2
+	}
+
+	if !hasFormat || !hasData {
+		return nil, errors.New("missing fmt or data chunk")
+	}
+	if audio.Format.BlockAlign == 0 || audio.Format.ByteRate == 0 {
+		return nil, errors.New("invalid WAV format")
+	}
+	return audio, nil
+}
+
+// EncodeWAV writes audio as a canonical 44-byte-header WAV file
+func EncodeWAV(audio *WAVAudio) []byte {
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, uint32(36+len(audio.Data)))
+	b.WriteString("WAVEfmt ")
+	binary.Write(&b, binary.LittleEndian, uint32(16))
+	binary.Write(&b, binary.LittleEndian, audio.Format)
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, uint32(len(audio.Data)))
+	b.Write(audio.Data)
+	return b.Bytes()
+}
+
+// silence returns sample data for duration d of silence in the given format
+func silence(format WAVFormat, d time.Duration) []byte {
+	frames := int64(d) * int64(format.SampleRate) / int64(time.Second)
+	data := make([]byte, frames*int64(format.BlockAlign))
+	// Unsigned 8-bit PCM is centred on 128 rather than 0
+	if format.BitsPerSample == 8 {
+		for i := range data {
+			data[i] = 0x80
+		}
+	}
+	return data
+}
+
+// BuildFullAudioTrack joins the audio of every slide in the session into one
+// WAV file in slide order, separated by gap of silence. Slides without audio,
+// and audio that cannot be fetched, decoded, or that does not match the format
+// of the first usable slide are skipped and reported.
+//
+// Parameters:
+//   - record: Snapshot of the slide session
+//   - gap: Silence inserted between consecutive slides
+//
+// Returns the combined track, or an error if no slide has usable audio.
+func (s *SlideService) BuildFullAudioTrack(record *models.SlideSessionRecord, gap time.Duration) (*FullAudioTrack, error) {
+	audioFiles := make(map[int]*models.SlideAudio, len(record.AudioFiles))
+	for _, audio := range record.AudioFiles {
+		audioFiles[audio.SlideIndex] = audio
+	}
+	indexes := make([]int, 0, len(record.Slides))
+	for _, slide := range record.Slides {
+		indexes = append(indexes, slide.Index)
+	}
+	sort.Ints(indexes)
+
+	track := &FullAudioTrack{SkippedSlides: make([]int, 0)}
+	var combined *WAVAudio
+	for _, index := range indexes {
+		audio, exists := audioFiles[index]
+		if !exists {
+			track.SkippedSlides = append(track.SkippedSlides, index)
+			continue
+		}
+
+		data, err := s.mcpService.FetchAudio(audio.AudioURL)
+		if err == nil {
+			var wav *WAVAudio
+			if wav, err = ParseWAV(data); err == nil {
+				switch {
+				case combined == nil:
+					combined = &WAVAudio{Format: wav.Format, Data: append([]byte(nil), wav.Data...)}
+				case wav.Format != combined.Format:
+					err = fmt.Errorf("format %+v does not match %+v", wav.Format, combined.Format)
+				default:
+					combined.Data = append(combined.Data, silence(combined.Format, gap)...)
+					combined.Data = append(combined.Data, wav.Data...)
+				}
+			}
+		}
+		if err != nil {
+			slog.Warn("Skipping slide audio in full track", "slideIndex", index, "audioURL", audio.AudioURL, "error", err)
+			track.SkippedSlides = append(track.SkippedSlides, index)
+		}
+	}
+
+	if combined == nil {
+		return nil, errors.New("no slide has usable audio")
+	}
+	track.WAV = EncodeWAV(combined)
+	track.Duration = combined.Duration()
+	return track, nil
+}
+
+// FetchAudio returns the contents of a synthesized audio file
+func (s *MCPService) FetchAudio(audioURL string) ([]byte, error) {
+	return s.speechService.FetchAudio(audioURL)
+}
+
+// FetchAudio returns the contents of a synthesized audio file. Audio from the
+// built-in fallback TTS is read from the local cache; anything else is
+// downloaded from the speech server.
+//
+// Parameters:
+//   - audioURL: The audio URL returned by SynthesizeSpeech
+//
+// Returns the audio file contents.
+func (s *SpeechService) FetchAudio(audioURL string) ([]byte, error) {
+	if strings.HasPrefix(audioURL, localAudioPrefix) {
+		audioPath, err := s.ServeAudioFile(filepath.Base(audioURL))
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(audioPath)
+	}
+
+	resp, err := s.client.Get(s.config.MCPSpeechURL + audioURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download audio: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("speech server returned status %d for audio", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
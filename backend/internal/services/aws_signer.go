@@ -12,10 +12,11 @@ import (
 )
 
 type AWSV4Signer struct {
-	AccessKey string
-	SecretKey string
-	Region    string
-	Service   string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string // Optional token for temporary (STS) credentials
+	Region       string
+	Service      string
 }
 
 func (s *AWSV4Signer) SignRequest(req *http.Request, payload []byte) error {
@@ -24,9 +25,13 @@ func (s *AWSV4Signer) SignRequest(req *http.Request, payload []byte) error {
 	// Set required headers
 	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
 	req.Header.Set("Host", req.URL.Host)
+	// Temporary credentials must send their session token as a signed header
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
 	
 	// Create canonical request
-	canonicalRequest := s.createCanonicalRequest(req, payload)
+	canonicalRequest, signedHeaders := s.createCanonicalRequest(req, payload)
 	
 	// Create string to sign
 	stringToSign := s.createStringToSign(now, canonicalRequest)
@@ -35,13 +40,13 @@ func (s *AWSV4Signer) SignRequest(req *http.Request, payload []byte) error {
 	signature := s.calculateSignature(now, stringToSign)
 	
 	// Set authorization header
-	authHeader := s.createAuthorizationHeader(now, signature)
+	authHeader := s.createAuthorizationHeader(now, signedHeaders, signature)
 	req.Header.Set("Authorization", authHeader)
 	
 	return nil
 }
 
-func (s *AWSV4Signer) createCanonicalRequest(req *http.Request, payload []byte) string {
+func (s *AWSV4Signer) createCanonicalRequest(req *http.Request, payload []byte) (string, string) {
 	// HTTP method
 	method := req.Method
 	
@@ -61,7 +66,7 @@ func (s *AWSV4Signer) createCanonicalRequest(req *http.Request, payload []byte)
 	payloadHash := fmt.Sprintf("%x", sha256.Sum256(payload))
 	
 	return fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s",
-		method, uri, queryString, canonicalHeaders, signedHeaders, payloadHash)
+		method, uri, queryString, canonicalHeaders, signedHeaders, payloadHash), signedHeaders
 }
 
 func (s *AWSV4Signer) createCanonicalQueryString(values url.Values) string {
@@ -136,21 +141,16 @@ func (s *AWSV4Signer) calculateSignature(now time.Time, stringToSign string) str
 	return fmt.Sprintf("%x", signature)
 }
 
-func (s *AWSV4Signer) createAuthorizationHeader(now time.Time, signature string) string {
+// createAuthorizationHeader builds the Authorization header. signedHeaders must
+// be the list used in the canonical request, or AWS rejects the signature.
+func (s *AWSV4Signer) createAuthorizationHeader(now time.Time, signedHeaders, signature string) string {
 	algorithm := "AWS4-HMAC-SHA256"
 	credential := fmt.Sprintf("%s/%s", s.AccessKey, s.getCredentialScope(now))
-	signedHeaders := s.getSignedHeadersList()
 	
 	return fmt.Sprintf("%s Credential=%s, SignedHeaders=%s, Signature=%s",
 		algorithm, credential, signedHeaders, signature)
 }
 
-func (s *AWSV4Signer) getSignedHeadersList() string {
-	// This should match the headers used in canonical headers
-	// For simplicity, we'll use common headers
-	return "host;x-amz-date"
-}
-
 func (s *AWSV4Signer) hmacSHA256(key []byte, data string) []byte {
 	h := hmac.New(sha256.New, key)
 	h.Write([]byte(data))
@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"os/exec"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -19,25 +21,38 @@ import (
 	"github.com/google/uuid"
 )
 
-// BacklogMCPWrapper wraps the stdio Backlog MCP Server as an HTTP service
+// maxMCPMessageSize bounds a single JSON-RPC line read from the subprocess;
+// tool results such as issue lists can be far larger than bufio's default
+const maxMCPMessageSize = 16 * 1024 * 1024
+
+// BacklogMCPWrapper wraps the stdio Backlog MCP Server as an HTTP service.
+// When config.UsesLocalBacklogMCP is true it runs backlog-server as a
+// subprocess; otherwise the external bridge container handles MCP traffic.
+
 type BacklogMCPWrapper struct {
-	config      *config.Config
-	process     *exec.Cmd
-	stdin       io.WriteCloser
-	stdout      io.ReadCloser
-	scanner     *bufio.Scanner
-	requestID   int64
-	sessions    map[string]*MCPSession
-	sessionMux  sync.RWMutex
-	ctx         context.Context
-	cancel      context.CancelFunc
-	isRunning   bool
+	config    *config.Config
+	process   *exec.Cmd
+	stdin     io.WriteCloser
+	stdout    io.ReadCloser
+	scanner   *bufio.Scanner
+	requestID int64
+	// Response channels of in-flight requests keyed by their wrapper-assigned
+	// JSON-RPC ID, which is unique across all sessions
+	pending    map[int64]chan *MCPResponse
+	pendingMu  sync.Mutex
+	sessions   map[string]*MCPSession
+	sessionMux sync.RWMutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	isRunning  atomic.Bool
+	startMu    sync.Mutex // Serializes Start so only one subprocess is launched
+	stopMu     sync.Mutex // Serializes Stop against handleMessages exiting
+	writeMu    sync.Mutex // Keeps concurrent JSON-RPC lines from interleaving on stdin
 }
 
+// MCPSession is an HTTP client session identified by the Mcp-Session-Id header
 type MCPSession struct {
-	ID        string
-	responses map[int64]chan *MCPResponse
-	respMutex sync.RWMutex
+	ID string
 }
 
 type MCPRequest struct {
@@ -64,49 +79,135 @@ func NewBacklogMCPWrapper(cfg *config.Config) *BacklogMCPWrapper {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &BacklogMCPWrapper{
 		config:   cfg,
+		pending:  make(map[int64]chan *MCPResponse),
 		sessions: make(map[string]*MCPSession),
 		ctx:      ctx,
 		cancel:   cancel,
 	}
 }
 
+// Start launches the backlog-server subprocess in local mode and performs the
+// MCP handshake. It does nothing if the wrapper is already running.
 func (w *BacklogMCPWrapper) Start() error {
-	if w.isRunning {
+	w.startMu.Lock()
+	defer w.startMu.Unlock()
+
+	if w.isRunning.Load() {
+		return nil
+	}
+
+	if !w.config.UsesLocalBacklogMCP() {
+		// In Docker environment, we don't start the process but mark as running
+		// The external backlog-mcp-server container handles the MCP communication
+		w.isRunning.Store(true)
+		slog.Info("Backlog MCP Wrapper marked as started (using external container)")
 		return nil
 	}
 
-	// In Docker environment, we don't start the process but mark as running
-	// The external backlog-mcp-server container handles the MCP communication
-	w.isRunning = true
+	return w.startProcess()
+}
+
+// startProcess execs BACKLOG_MCP_COMMAND with its stdin and stdout wired to the
+// wrapper. The subprocess inherits the environment, so it authenticates with
+// BACKLOG_DOMAIN and BACKLOG_ACCESS_TOKEN or BACKLOG_API_KEY.
+func (w *BacklogMCPWrapper) startProcess() error {
+	fields := strings.Fields(w.config.BacklogMCPCommand)
+	if len(fields) == 0 {
+		return fmt.Errorf("BACKLOG_MCP_COMMAND is empty")
+	}
+
+	// A previous subprocess may have exited and cancelled the old context
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to open backlog-server stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to open backlog-server stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return fmt.Errorf("failed to start backlog-server: %w", err)
+	}
+
+	w.stopMu.Lock()
+	w.ctx, w.cancel = ctx, cancel
+	w.process = cmd
+	w.stdin = stdin
+	w.stdout = stdout
+	w.stopMu.Unlock()
+	w.scanner = bufio.NewScanner(stdout)
+	w.scanner.Buffer(make([]byte, 64*1024), maxMCPMessageSize)
+	w.isRunning.Store(true)
+
+	go w.handleMessages()
+
+	if err := w.initialize(); err != nil {
+		w.Stop()
+		return err
+	}
 
-	log.Printf("Backlog MCP Wrapper marked as started (using external container)")
+	slog.Info("Backlog MCP server started as a local subprocess", "command", fields[0], "pid", cmd.Process.Pid)
 	return nil
 }
 
 func (w *BacklogMCPWrapper) Stop() error {
+	w.stopMu.Lock()
+	defer w.stopMu.Unlock()
+
 	w.cancel()
-	w.isRunning = false
+	w.isRunning.Store(false)
 	
 	if w.stdin != nil {
 		w.stdin.Close()
+		w.stdin = nil
 	}
 	if w.stdout != nil {
 		w.stdout.Close()
+		w.stdout = nil
 	}
 	if w.process != nil {
 		w.process.Process.Kill()
 		w.process.Wait()
+		w.process = nil
 	}
 	return nil
 }
 
-func (w *BacklogMCPWrapper) initialize() error {
-	// Create a temporary session for initialization
-	session := &MCPSession{
-		ID:        "init",
-		responses: make(map[int64]chan *MCPResponse),
+// CallTool calls a Backlog tool on the local backlog-server subprocess,
+// starting it on first use.
+//
+// Returns the raw tools/call result.
+func (w *BacklogMCPWrapper) CallTool(name string, arguments map[string]interface{}) (json.RawMessage, error) {
+	if err := w.Start(); err != nil {
+		return nil, err
 	}
-	
+	return w.sendRequest("tools/call", map[string]interface{}{
+		"name":      name,
+		"arguments": arguments,
+	})
+}
+
+// session returns the session with the given ID, creating it if needed
+func (w *BacklogMCPWrapper) session(id string) *MCPSession {
+	w.sessionMux.Lock()
+	defer w.sessionMux.Unlock()
+
+	session, exists := w.sessions[id]
+	if !exists {
+		session = &MCPSession{ID: id}
+		w.sessions[id] = session
+	}
+	return session
+}
+
+func (w *BacklogMCPWrapper) initialize() error {
 	initParams := map[string]interface{}{
 		"protocolVersion": "2024-11-05",
 		"capabilities": map[string]interface{}{
@@ -120,7 +221,7 @@ func (w *BacklogMCPWrapper) initialize() error {
 		},
 	}
 
-	_, err := w.sendRequest(session, "initialize", initParams)
+	_, err := w.sendRequest("initialize", initParams)
 	if err != nil {
 		return fmt.Errorf("initialize failed: %w", err)
 	}
@@ -134,8 +235,12 @@ func (w *BacklogMCPWrapper) initialize() error {
 	return w.sendMessage(notification)
 }
 
+// handleMessages reads responses from the subprocess and delivers each one to
+// the request that is waiting for its ID. When the subprocess exits, requests
+// still in flight fail immediately instead of waiting for their timeout.
 func (w *BacklogMCPWrapper) handleMessages() {
 	defer w.Stop()
+	defer w.failPending(&MCPError{Code: -32000, Message: "backlog-server exited"})
 
 	for w.scanner.Scan() {
 		line := w.scanner.Text()
@@ -145,29 +250,41 @@ func (w *BacklogMCPWrapper) handleMessages() {
 
 		var response MCPResponse
 		if err := json.Unmarshal([]byte(line), &response); err != nil {
-			log.Printf("Failed to parse MCP response: %v, line: %s", err, line)
+			slog.Warn("Failed to parse MCP response", "error", err)
+			slog.Debug("Unparsed MCP response", "line", line)
 			continue
 		}
 
-		// Broadcast to all sessions
-		w.sessionMux.RLock()
-		for _, session := range w.sessions {
-			session.respMutex.RLock()
-			if ch, ok := session.responses[response.ID]; ok {
-				select {
-				case ch <- &response:
-				default:
-				}
-				delete(session.responses, response.ID)
-			}
-			session.respMutex.RUnlock()
+		if ch, ok := w.takePending(response.ID); ok {
+			ch <- &response
 		}
-		w.sessionMux.RUnlock()
 	}
 }
 
-func (w *BacklogMCPWrapper) sendRequest(session *MCPSession, method string, params interface{}) (json.RawMessage, error) {
-	if !w.isRunning {
+// takePending removes and returns the response channel of a pending request
+func (w *BacklogMCPWrapper) takePending(id int64) (chan *MCPResponse, bool) {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+	ch, ok := w.pending[id]
+	delete(w.pending, id)
+	return ch, ok
+}
+
+// failPending answers every pending request with mcpErr
+func (w *BacklogMCPWrapper) failPending(mcpErr *MCPError) {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+	for id, ch := range w.pending {
+		ch <- &MCPResponse{JSONRPC: "2.0", ID: id, Error: mcpErr}
+		delete(w.pending, id)
+	}
+}
+
+// sendRequest sends a JSON-RPC request to the subprocess and waits for the
+// response with the same ID. IDs are assigned by the wrapper, so concurrent
+// requests from different sessions never collide.
+func (w *BacklogMCPWrapper) sendRequest(method string, params interface{}) (json.RawMessage, error) {
+	if !w.isRunning.Load() {
 		return nil, fmt.Errorf("MCP wrapper is not running")
 	}
 
@@ -180,17 +297,15 @@ func (w *BacklogMCPWrapper) sendRequest(session *MCPSession, method string, para
 		Params:  params,
 	}
 
-	// Create response channel
+	// Create response channel; it is buffered so delivery never blocks handleMessages
 	respCh := make(chan *MCPResponse, 1)
-	session.respMutex.Lock()
-	session.responses[id] = respCh
-	session.respMutex.Unlock()
+	w.pendingMu.Lock()
+	w.pending[id] = respCh
+	w.pendingMu.Unlock()
 
 	// Send request
 	if err := w.sendMessage(request); err != nil {
-		session.respMutex.Lock()
-		delete(session.responses, id)
-		session.respMutex.Unlock()
+		w.takePending(id)
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
@@ -202,9 +317,7 @@ func (w *BacklogMCPWrapper) sendRequest(session *MCPSession, method string, para
 		}
 		return response.Result, nil
 	case <-time.After(30 * time.Second):
-		session.respMutex.Lock()
-		delete(session.responses, id)
-		session.respMutex.Unlock()
+		w.takePending(id)
 		return nil, fmt.Errorf("request timeout")
 	}
 }
@@ -215,7 +328,16 @@ func (w *BacklogMCPWrapper) sendMessage(message interface{}) error {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	_, err = w.stdin.Write(append(data, '\n'))
+	w.stopMu.Lock()
+	stdin := w.stdin
+	w.stopMu.Unlock()
+	if stdin == nil {
+		return fmt.Errorf("backlog-server is not running")
+	}
+
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	_, err = stdin.Write(append(data, '\n'))
 	if err != nil {
 		return fmt.Errorf("failed to write to stdin: %w", err)
 	}
@@ -232,17 +354,7 @@ func (w *BacklogMCPWrapper) HandleHTTP(c *gin.Context) {
 		c.Header("Mcp-Session-Id", sessionID)
 	}
 
-	// Get or create session
-	w.sessionMux.Lock()
-	session, exists := w.sessions[sessionID]
-	if !exists {
-		session = &MCPSession{
-			ID:        sessionID,
-			responses: make(map[int64]chan *MCPResponse),
-		}
-		w.sessions[sessionID] = session
-	}
-	w.sessionMux.Unlock()
+	w.session(sessionID)
 
 	var request MCPRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -250,7 +362,7 @@ func (w *BacklogMCPWrapper) HandleHTTP(c *gin.Context) {
 		return
 	}
 
-	result, err := w.sendRequest(session, request.Method, request.Params)
+	result, err := w.sendRequest(request.Method, request.Params)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
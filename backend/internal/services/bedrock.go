@@ -2,9 +2,10 @@ package services
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"intelligent-presenter-backend/pkg/config"
@@ -30,11 +31,11 @@ type BedrockResponse struct {
 }
 
 type ClaudeMessageRequest struct {
-	Model         string    `json:"model"`
+	Model         string    `json:"model,omitempty"`
 	MaxTokens     int       `json:"max_tokens"`
 	Temperature   float64   `json:"temperature"`
 	Messages      []Message `json:"messages"`
-	AnthropicVersion string `json:"anthropic_version"`
+	AnthropicVersion string `json:"anthropic_version,omitempty"`
 }
 
 type Message struct {
@@ -70,129 +71,70 @@ func NewBedrockService(cfg *config.Config) *BedrockService {
 	}
 }
 
+// GenerateText generates text with the configured Bedrock model, using the
+// request and response schema of the model's family (Claude, Titan, Llama, or Mistral).
 func (s *BedrockService) GenerateText(prompt string) (string, error) {
-	// Use Claude-3 Messages API format for newer models
-	if s.isClaudeMessagesModel() {
-		return s.generateWithMessages(prompt)
-	}
-	
-	// Use legacy text completion for older models
-	return s.generateWithCompletion(prompt)
-}
-
-func (s *BedrockService) isClaudeMessagesModel() bool {
-	modelID := s.config.BedrockModelID
-	return modelID == "anthropic.claude-3-haiku-20240307-v1:0" ||
-		   modelID == "anthropic.claude-3-sonnet-20240229-v1:0" ||
-		   modelID == "anthropic.claude-3-opus-20240229-v1:0" ||
-		   modelID == "anthropic.claude-3-5-sonnet-20240620-v1:0"
+	return s.GenerateTextWithTemperature(prompt, DefaultTemperature)
 }
 
-func (s *BedrockService) generateWithMessages(prompt string) (string, error) {
-	request := ClaudeMessageRequest{
-		Model:       s.config.BedrockModelID,
-		MaxTokens:   1500,
-		Temperature: 0.7,
-		Messages: []Message{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
-		AnthropicVersion: "bedrock-2023-05-31",
-	}
-
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	resp, err := s.callBedrock(jsonData)
+// GenerateTextWithTemperature is GenerateText with an explicit sampling temperature
+func (s *BedrockService) GenerateTextWithTemperature(prompt string, temperature float64) (string, error) {
+	requestBody, err := BuildBedrockRequest(s.config.BedrockModelID, prompt, temperature)
 	if err != nil {
 		return "", err
 	}
 
-	var response ClaudeMessageResponse
-	if err := json.Unmarshal(resp, &response); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if len(response.Content) == 0 {
-		return "", fmt.Errorf("no content in response")
-	}
-
-	return response.Content[0].Text, nil
-}
-
-func (s *BedrockService) generateWithCompletion(prompt string) (string, error) {
-	// Format prompt for Claude completion models
-	formattedPrompt := fmt.Sprintf("\n\nHuman: %s\n\nAssistant:", prompt)
-	
-	request := BedrockRequest{
-		Prompt:            formattedPrompt,
-		MaxTokensToSample: 1500,
-		Temperature:       0.7,
-		TopP:              0.9,
-		TopK:              250,
-		StopSequences:     []string{"\n\nHuman:"},
-	}
-
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	resp, err := s.callBedrock(jsonData)
+	resp, err := s.callBedrock(requestBody)
 	if err != nil {
 		return "", err
 	}
 
-	var response BedrockResponse
-	if err := json.Unmarshal(resp, &response); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return response.Completion, nil
+	return ParseBedrockResponse(s.config.BedrockModelID, resp)
 }
 
 func (s *BedrockService) callBedrock(jsonData []byte) ([]byte, error) {
-	url := fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com/model/%s/invoke",
-		s.config.AWSRegion, s.config.BedrockModelID)
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	endpoint := fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com", s.config.AWSRegion)
+	if s.config.BedrockEndpoint != "" {
+		endpoint = strings.TrimRight(s.config.BedrockEndpoint, "/")
 	}
+	url := fmt.Sprintf("%s/model/%s/invoke", endpoint, s.config.BedrockModelID)
 
-	// Add AWS Signature V4 headers
-	if err := s.signRequest(req, jsonData); err != nil {
-		return nil, fmt.Errorf("failed to sign request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	fmt.Printf("Making Bedrock API call to model: %s\n", s.config.BedrockModelID)
+	slog.Debug("Making Bedrock API call", "model", s.config.BedrockModelID)
 	
-	resp, err := s.client.Do(req)
+	// Sign every attempt separately since signatures are time-bound
+	resp, err := doWithRetry(s.client, s.config, "Bedrock", func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Add AWS Signature V4 headers
+		if err := s.signRequest(req, jsonData); err != nil {
+			return nil, fmt.Errorf("failed to sign request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		fmt.Printf("Bedrock API call error: %v\n", err)
+		slog.Error("Bedrock API call error", "error", err)
 		return nil, fmt.Errorf("failed to call Bedrock API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		fmt.Printf("Bedrock API error - Status: %d\n", resp.StatusCode)
 		var errorBytes bytes.Buffer
 		errorBytes.ReadFrom(resp.Body)
-		fmt.Printf("Bedrock error response: %s\n", errorBytes.String())
+		slog.Error("Bedrock API error", "status", resp.StatusCode)
+		slog.Debug("Bedrock error response", "body", errorBytes.String())
 		return nil, fmt.Errorf("Bedrock API returned status %d", resp.StatusCode)
 	}
 
 	var responseBody bytes.Buffer
 	responseBody.ReadFrom(resp.Body)
 	
-	fmt.Printf("Bedrock API call successful\n")
+	slog.Debug("Bedrock API call successful")
 	return responseBody.Bytes(), nil
 }
 
@@ -209,10 +151,11 @@ func (s *BedrockService) signRequest(req *http.Request, payload []byte) error {
 
 	// Create AWS Signature V4
 	signer := &AWSV4Signer{
-		AccessKey: accessKey,
-		SecretKey: secretKey,
-		Region:    region,
-		Service:   "bedrock",
+		AccessKey:    accessKey,
+		SecretKey:    secretKey,
+		SessionToken: s.config.AWSSessionToken,
+		Region:       region,
+		Service:      "bedrock",
 	}
 
 	return signer.SignRequest(req, payload)
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Bedrock model families with distinct request and response schemas
+const (
+	BedrockFamilyClaudeMessages   = "claude-messages"   // Claude 3 and later (Messages API)
+	BedrockFamilyClaudeCompletion = "claude-completion" // Claude v2 and Instant (Text Completions API)
+	BedrockFamilyTitan            = "titan"             // Amazon Titan Text
+	BedrockFamilyLlama            = "llama"             // Meta Llama 2 and later
+	BedrockFamilyMistral          = "mistral"           // Mistral and Mixtral
+)
+
+// Generation settings shared by every Bedrock model family
+const (
+	bedrockMaxTokens = 1500
+	bedrockTopP      = 0.9
+)
+
+// TitanRequest is the Amazon Titan Text request body
+type TitanRequest struct {
+	InputText            string                `json:"inputText"`
+	TextGenerationConfig TitanGenerationConfig `json:"textGenerationConfig"`
+}
+
+// TitanGenerationConfig holds Titan Text sampling parameters
+type TitanGenerationConfig struct {
+	MaxTokenCount int     `json:"maxTokenCount"`
+	Temperature   float64 `json:"temperature"`
+	TopP          float64 `json:"topP"`
+}
+
+// TitanResponse is the Amazon Titan Text response body
+type TitanResponse struct {
+	Results []struct {
+		OutputText       string `json:"outputText"`
+		CompletionReason string `json:"completionReason"`
+	} `json:"results"`
+}
+
+// LlamaRequest is the Meta Llama request body
+type LlamaRequest struct {
+	Prompt      string  `json:"prompt"`
+	MaxGenLen   int     `json:"max_gen_len"`
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p"`
+}
+
+// LlamaResponse is the Meta Llama response body
+type LlamaResponse struct {
+	Generation string `json:"generation"`
+	StopReason string `json:"stop_reason"`
+}
+
+// MistralRequest is the Mistral request body
+type MistralRequest struct {
+	Prompt      string  `json:"prompt"`
+	MaxTokens   int     `json:"max_tokens"`
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p"`
+}
+
+// MistralResponse is the Mistral response body
+type MistralResponse struct {
+	Outputs []struct {
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"outputs"`
+}
+
+// BedrockModelFamily identifies the request/response schema of a Bedrock model
+// from its ID. Cross-region inference profile prefixes such as "us." are ignored.
+//
+// Returns the family name, or an empty string for unsupported models.
+func BedrockModelFamily(modelID string) string {
+	switch {
+	case strings.Contains(modelID, "anthropic.claude-v2"), strings.Contains(modelID, "anthropic.claude-instant"):
+		return BedrockFamilyClaudeCompletion
+	case strings.Contains(modelID, "anthropic.claude"):
+		return BedrockFamilyClaudeMessages
+	case strings.Contains(modelID, "amazon.titan-text"):
+		return BedrockFamilyTitan
+	case strings.Contains(modelID, "meta.llama"):
+		return BedrockFamilyLlama
+	case strings.Contains(modelID, "mistral."):
+		return BedrockFamilyMistral
+	default:
+		return ""
+	}
+}
+
+// BuildBedrockRequest creates the InvokeModel request body for the model's family.
+//
+// Parameters:
+//   - modelID: Bedrock model identifier selecting the request schema
+//   - prompt: The user prompt
+//   - temperature: Sampling temperature
+//
+// Returns the JSON request body, or an error for unsupported models.
+func BuildBedrockRequest(modelID, prompt string, temperature float64) ([]byte, error) {
+	var request interface{}
+	switch BedrockModelFamily(modelID) {
+	case BedrockFamilyClaudeMessages:
+		request = ClaudeMessageRequest{
+			MaxTokens:   bedrockMaxTokens,
+			Temperature: temperature,
+			Messages: []Message{
+				{
+					Role:    "user",
+					Content: prompt,
+				},
+			},
+			AnthropicVersion: "bedrock-2023-05-31",
+		}
+	case BedrockFamilyClaudeCompletion:
+		request = BedrockRequest{
+			Prompt:            fmt.Sprintf("\n\nHuman: %s\n\nAssistant:", prompt),
+			MaxTokensToSample: bedrockMaxTokens,
+			Temperature:       temperature,
+			TopP:              bedrockTopP,
+			TopK:              250,
+			StopSequences:     []string{"\n\nHuman:"},
+		}
+	case BedrockFamilyTitan:
+		request = TitanRequest{
+			InputText: prompt,
+			TextGenerationConfig: TitanGenerationConfig{
+				MaxTokenCount: bedrockMaxTokens,
+				Temperature:   temperature,
+				TopP:          bedrockTopP,
+			},
+		}
+	case BedrockFamilyLlama:
+		request = LlamaRequest{
+			Prompt:      llamaPrompt(modelID, prompt),
+			MaxGenLen:   bedrockMaxTokens,
+			Temperature: temperature,
+			TopP:        bedrockTopP,
+		}
+	case BedrockFamilyMistral:
+		request = MistralRequest{
+			Prompt:      fmt.Sprintf("<s>[INST] %s [/INST]", prompt),
+			MaxTokens:   bedrockMaxTokens,
+			Temperature: temperature,
+			TopP:        bedrockTopP,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Bedrock model %q", modelID)
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return body, nil
+}
+
+// llamaPrompt wraps the prompt in the chat template of the Llama generation
+func llamaPrompt(modelID, prompt string) string {
+	if strings.Contains(modelID, "meta.llama2") {
+		return fmt.Sprintf("<s>[INST] %s [/INST]", prompt)
+	}
+	return "<|begin_of_text|><|start_header_id|>user<|end_header_id|>\n\n" + prompt +
+		"<|eot_id|><|start_header_id|>assistant<|end_header_id|>\n\n"
+}
+
+// ParseBedrockResponse extracts the generated text from an InvokeModel response
+// body according to the model's family.
+//
+// Parameters:
+//   - modelID: Bedrock model identifier selecting the response schema
+//   - body: The raw response body
+//
+// Returns the generated text, or an error if the body is malformed or empty.
+func ParseBedrockResponse(modelID string, body []byte) (string, error) {
+	var text string
+	switch BedrockModelFamily(modelID) {
+	case BedrockFamilyClaudeMessages:
+		var response ClaudeMessageResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if len(response.Content) > 0 {
+			text = response.Content[0].Text
+		}
+	case BedrockFamilyClaudeCompletion:
+		var response BedrockResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		text = response.Completion
+	case BedrockFamilyTitan:
+		var response TitanResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if len(response.Results) > 0 {
+			text = response.Results[0].OutputText
+		}
+	case BedrockFamilyLlama:
+		var response LlamaResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		text = response.Generation
+	case BedrockFamilyMistral:
+		var response MistralResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if len(response.Outputs) > 0 {
+			text = response.Outputs[0].Text
+		}
+	default:
+		return "", fmt.Errorf("unsupported Bedrock model %q", modelID)
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("no content in response")
+	}
+	return strings.TrimSpace(text), nil
+}
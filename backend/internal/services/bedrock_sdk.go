@@ -2,9 +2,8 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
+	"log/slog"
 
 	"intelligent-presenter-backend/pkg/config"
 
@@ -12,6 +11,7 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 )
 
 type BedrockSDKService struct {
@@ -28,7 +28,7 @@ func NewBedrockSDKService(cfg *config.Config) (*BedrockSDKService, error) {
 		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
 			cfg.AWSAccessKeyID,
 			cfg.AWSSecretAccessKey,
-			"", // no session token
+			cfg.AWSSessionToken,
 		)),
 	)
 	if err != nil {
@@ -44,27 +44,20 @@ func NewBedrockSDKService(cfg *config.Config) (*BedrockSDKService, error) {
 	}, nil
 }
 
+// GenerateText generates text with the configured Bedrock model, using the
+// request and response schema of the model's family (Claude, Titan, Llama, or Mistral).
 func (s *BedrockSDKService) GenerateText(prompt string) (string, error) {
-	// Use Claude-3 Messages API format for Bedrock (without model field)
-	request := map[string]interface{}{
-		"max_tokens":         1500,
-		"temperature":        0.7,
-		"messages": []Message{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
-		"anthropic_version": "bedrock-2023-05-31",
-	}
+	return s.GenerateTextWithTemperature(prompt, DefaultTemperature)
+}
 
-	// Marshal the request
-	requestBody, err := json.Marshal(request)
+// GenerateTextWithTemperature is GenerateText with an explicit sampling temperature
+func (s *BedrockSDKService) GenerateTextWithTemperature(prompt string, temperature float64) (string, error) {
+	requestBody, err := BuildBedrockRequest(s.config.BedrockModelID, prompt, temperature)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", err
 	}
 
-	fmt.Printf("Making Bedrock API call using AWS SDK to model: %s\n", s.config.BedrockModelID)
+	slog.Debug("Making Bedrock API call using AWS SDK", "model", s.config.BedrockModelID)
 
 	// Call Bedrock using AWS SDK
 	output, err := s.client.InvokeModel(context.TODO(), &bedrockruntime.InvokeModelInput{
@@ -75,25 +68,70 @@ func (s *BedrockSDKService) GenerateText(prompt string) (string, error) {
 	})
 
 	if err != nil {
-		fmt.Printf("Bedrock SDK API call error: %v\n", err)
+		slog.Error("Bedrock SDK API call error", "error", err)
 		return "", fmt.Errorf("failed to call Bedrock API: %w", err)
 	}
 
-	// Parse the response
-	var response ClaudeMessageResponse
-	if err := json.Unmarshal(output.Body, &response); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	text, err := ParseBedrockResponse(s.config.BedrockModelID, output.Body)
+	if err != nil {
+		return "", err
+	}
+
+	slog.Debug("Bedrock SDK API call successful")
+	return text, nil
+}
+
+// GenerateTextStream generates text with InvokeModelWithResponseStream, passing
+// each fragment to onDelta as it arrives.
+//
+// Returns the full completion once the stream ends, or the first stream error.
+func (s *BedrockSDKService) GenerateTextStream(prompt string, temperature float64, onDelta TextDeltaFunc) (string, error) {
+	if BedrockModelFamily(s.config.BedrockModelID) != BedrockFamilyClaudeMessages {
+		return "", fmt.Errorf("streaming is only supported for Claude Messages models, not %q", s.config.BedrockModelID)
+	}
+	requestBody, err := BuildBedrockRequest(s.config.BedrockModelID, prompt, temperature)
+	if err != nil {
+		return "", err
+	}
+
+	slog.Debug("Making streaming Bedrock API call using AWS SDK", "model", s.config.BedrockModelID)
+
+	output, err := s.client.InvokeModelWithResponseStream(context.TODO(), &bedrockruntime.InvokeModelWithResponseStreamInput{
+		ModelId:     aws.String(s.config.BedrockModelID),
+		ContentType: aws.String("application/json"),
+		Accept:      aws.String("application/json"),
+		Body:        requestBody,
+	})
+	if err != nil {
+		slog.Error("Bedrock SDK streaming API call error", "error", err)
+		return "", fmt.Errorf("failed to call Bedrock streaming API: %w", err)
 	}
 
-	if len(response.Content) == 0 {
+	stream := output.GetStream()
+	defer stream.Close()
+
+	var decoder ClaudeStreamDecoder
+	for event := range stream.Events() {
+		chunk, ok := event.(*types.ResponseStreamMemberChunk)
+		if !ok {
+			continue
+		}
+		delta, err := decoder.Decode(chunk.Value.Bytes)
+		if err != nil {
+			return "", err
+		}
+		if delta != "" && onDelta != nil {
+			onDelta(delta)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return "", fmt.Errorf("Bedrock response stream failed: %w", err)
+	}
+
+	if decoder.Text() == "" {
 		return "", fmt.Errorf("no content in response")
 	}
 
-	fmt.Printf("Bedrock SDK API call successful\n")
-	return response.Content[0].Text, nil
+	slog.Debug("Bedrock SDK streaming API call successful")
+	return decoder.Text(), nil
 }
-
-func (s *BedrockSDKService) isClaudeMessagesModel() bool {
-	modelID := s.config.BedrockModelID
-	return strings.Contains(modelID, "claude-3")
-}
\ No newline at end of file
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// burndownDays is the number of days covered by the progress burndown chart
+const burndownDays = 30
+
+// ComputeBurndown calculates how many issues remained unfinished at the end of
+// each day, from the earliest issue creation date (or windowDays ago, whichever is
+// later) up to now. Backlog does not record when an issue was closed, so the
+// last update of a closed issue is used as its closing time.
+//
+// Parameters:
+//   - issues: Backlog issue objects as returned by the get_issues tool
+//   - windowDays: Maximum number of days covered by the series
+//   - now: Reference time used as the last day of the series
+//
+// Returns the daily series, or nil when no issue has a creation date.
+func ComputeBurndown(issues []interface{}, windowDays int, now time.Time) *models.BurndownSeries {
+	type issueSpan struct {
+		created  time.Time
+		closed   time.Time
+		isClosed bool
+	}
+
+	var spans []issueSpan
+	var earliest time.Time
+	for _, item := range issues {
+		issue, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		created, ok := parseBacklogTime(issue["created"])
+		if !ok {
+			continue
+		}
+		span := issueSpan{created: created}
+		if isClosedIssue(issue) {
+			span.closed, span.isClosed = parseBacklogTime(issue["updated"])
+		}
+		spans = append(spans, span)
+		if earliest.IsZero() || created.Before(earliest) {
+			earliest = created
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	today := startOfDay(now)
+	start := startOfDay(earliest.In(now.Location()))
+	if windowStart := today.AddDate(0, 0, -(windowDays - 1)); start.Before(windowStart) {
+		start = windowStart
+	}
+
+	series := &models.BurndownSeries{}
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		remaining := 0
+		for _, span := range spans {
+			if span.created.Before(end) && !(span.isClosed && span.closed.Before(end)) {
+				remaining++
+			}
+		}
+		series.Labels = append(series.Labels, day.Format("2006-01-02"))
+		series.Remaining = append(series.Remaining, remaining)
+	}
+	return series
+}
+
+// BurndownChartConfig builds a Chart.js line chart configuration for a burndown series
+func BurndownChartConfig(series *models.BurndownSeries, language string) map[string]interface{} {
+	title, label := "Issue Burndown", "Remaining issues"
+	if language == "ja" {
+		title, label = "課題バーンダウン", "残課題数"
+	}
+
+	return map[string]interface{}{
+		"type": "line",
+		"data": map[string]interface{}{
+			"labels": series.Labels,
+			"datasets": []map[string]interface{}{
+				{
+					"label":       label,
+					"data":        series.Remaining,
+					"fill":        false,
+					"tension":     0,
+					"borderColor": "#4a90d9",
+				},
+			},
+		},
+		"options": map[string]interface{}{
+			"plugins": map[string]interface{}{
+				"title": map[string]interface{}{"display": true, "text": title},
+			},
+			"scales": map[string]interface{}{
+				"y": map[string]interface{}{"beginAtZero": true, "ticks": map[string]interface{}{"precision": 0}},
+			},
+		},
+	}
+}
+
+// appendChartConfig appends a Chart.js configuration to slide markdown as a
+// JSON code block, which the frontend renders as a chart
+func appendChartConfig(markdown string, chartConfig map[string]interface{}) (string, error) {
+	configJSON, err := json.MarshalIndent(chartConfig, "", "  ")
+	if err != nil {
+		return markdown, fmt.Errorf("failed to marshal chart config: %w", err)
+	}
+	return fmt.Sprintf("%s\n\n```json\n%s\n```\n", strings.TrimRight(markdown, "\n"), configJSON), nil
+}
+
+// startOfDay truncates a time to midnight in its location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"fmt"
+)
+
+// commentPageSize is the largest page the Backlog comments API returns
+const commentPageSize = 100
+
+// defaultMaxSummaryComments caps aggregated comments when no limit is configured
+const defaultMaxSummaryComments = 200
+
+// GetRecentComments collects comments from the project's most recently updated
+// issues for use in LLM summaries. The total number of comments is capped by
+// the MaxSummaryComments configuration.
+func (s *MCPService) GetRecentComments(projectID, backlogToken string) ([]interface{}, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := s.callBacklogToolHTTP("get_issues", map[string]interface{}{
+		"projectId": []string{projectID},
+		"count":     20,
+		"sort":      "updated",
+		"order":     "desc",
+	}, backlogToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+
+	issueList, ok := issues.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected issues format: %T", issues)
+	}
+
+	var issueKeys []string
+	for _, item := range issueList {
+		if issue, ok := item.(map[string]interface{}); ok {
+			if key, _ := issue["issueKey"].(string); key != "" {
+				issueKeys = append(issueKeys, key)
+			}
+		}
+	}
+
+	return s.GetIssueComments(issueKeys, backlogToken)
+}
+
+// GetIssueComments pages through the comments of each issue, newest first, and
+// stops as soon as the configured cap on aggregated comments is reached so that
+// busy issues cannot trigger thousands of requests. Pages of an issue are paced
+// and retried on rate limiting like GetAllIssues.
+//
+// Parameters:
+//   - issueKeys: Issues whose comments are collected, in priority order
+//   - backlogToken: OAuth access token for Backlog API
+//
+// Returns the collected comments reduced to the fields used in prompts.
+func (s *MCPService) GetIssueComments(issueKeys []string, backlogToken string) ([]interface{}, error) {
+	limit := s.maxSummaryComments()
+	comments := make([]interface{}, 0)
+
+	for _, issueKey := range issueKeys {
+		maxID := 0
+		for len(comments) < limit {
+			pageSize := limit - len(comments)
+			if pageSize > commentPageSize {
+				pageSize = commentPageSize
+			}
+
+			args := map[string]interface{}{
+				"issueIdOrKey": issueKey,
+				"count":        pageSize,
+				"order":        "desc",
+			}
+			if maxID > 0 {
+				args["maxId"] = maxID - 1
+				s.waitBetweenPages()
+			}
+
+			result, err := s.callBacklogPage("get_issue_comments", args, backlogToken)
+			if err != nil {
+				return comments, fmt.Errorf("failed to get comments for %s: %w", issueKey, err)
+			}
+			page, ok := result.([]interface{})
+			if !ok {
+				return comments, fmt.Errorf("unexpected comments format for %s: %T", issueKey, result)
+			}
+
+			for _, item := range page {
+				comment, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if id, ok := comment["id"].(float64); ok && (maxID == 0 || int(id) < maxID) {
+					maxID = int(id)
+				}
+				comments = append(comments, summarizeComment(issueKey, comment))
+			}
+
+			// A short page means the issue has no older comments
+			if len(page) < pageSize || maxID == 0 {
+				break
+			}
+		}
+		if len(comments) >= limit {
+			break
+		}
+	}
+
+	return comments, nil
+}
+
+// summarizeComment keeps only the comment fields that matter for summaries
+func summarizeComment(issueKey string, comment map[string]interface{}) map[string]interface{} {
+	summary := map[string]interface{}{
+		"issueKey": issueKey,
+		"content":  comment["content"],
+		"created":  comment["created"],
+	}
+	if user, ok := comment["createdUser"].(map[string]interface{}); ok {
+		summary["author"] = user["name"]
+	}
+	return summary
+}
+
+// maxSummaryComments returns the cap on comments aggregated for summaries
+func (s *MCPService) maxSummaryComments() int {
+	if s.config.MaxSummaryComments > 0 {
+		return s.config.MaxSummaryComments
+	}
+	return defaultMaxSummaryComments
+}
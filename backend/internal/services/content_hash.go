@@ -0,0 +1,24 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ContentHash returns a stable hash of a slide's title and markdown so that
+// clients can tell whether a regenerated slide actually changed. The fields
+// are separated by a NUL byte so that moving text between the title and the
+// markdown yields a different hash.
+//
+// Parameters:
+//   - title: Slide title
+//   - markdown: Source markdown content of the slide
+//
+// Returns the hex-encoded SHA-256 digest.
+func ContentHash(title, markdown string) string {
+	hash := sha256.New()
+	hash.Write([]byte(title))
+	hash.Write([]byte{0})
+	hash.Write([]byte(markdown))
+	return hex.EncodeToString(hash.Sum(nil))
+}
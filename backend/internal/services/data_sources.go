@@ -0,0 +1,69 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// BacklogToolError is returned when a Backlog MCP tool call fails. StatusCode
+// holds the Backlog API status reported by the bridge, or 0 when unknown.
+// RetryAfter holds the bridge's Retry-After delay, or -1 when none was sent.
+type BacklogToolError struct {
+	Tool       string
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *BacklogToolError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("MCP tool %s failed (Backlog status %d): %s", e.Tool, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("MCP tool %s failed: %s", e.Tool, e.Message)
+}
+
+// IsPermissionError reports whether err was caused by Backlog denying access
+// to the requested data (HTTP 401 or 403).
+func IsPermissionError(err error) bool {
+	var toolErr *BacklogToolError
+	if !errors.As(err, &toolErr) {
+		return false
+	}
+	return toolErr.StatusCode == http.StatusUnauthorized || toolErr.StatusCode == http.StatusForbidden
+}
+
+// IsRateLimitError reports whether err was caused by Backlog rate limiting
+// (HTTP 429), either reported by the bridge or returned by the bridge itself.
+func IsRateLimitError(err error) bool {
+	var toolErr *BacklogToolError
+	return errors.As(err, &toolErr) && toolErr.StatusCode == http.StatusTooManyRequests
+}
+
+// fetchDataSource fetches one data source for a slide and stores it under key.
+// When the token lacks permission for the source, a limitation is recorded in
+// data["limitations"] instead of failing, so the slide is generated with the
+// remaining data. Other errors are returned to the caller.
+//
+// Parameters:
+//   - data: Project data collected for the slide
+//   - key: Key under which the fetched data is stored
+//   - source: Human-readable name of the data source used in the limitation note
+//   - fetch: Retrieves the data
+func fetchDataSource(data map[string]interface{}, key, source string, fetch func() (interface{}, error)) error {
+	value, err := fetch()
+	if err == nil {
+		data[key] = value
+		return nil
+	}
+	if !IsPermissionError(err) {
+		return err
+	}
+
+	slog.Warn("No permission for data source, continuing without it", "source", source, "error", err)
+	limitations, _ := data["limitations"].([]string)
+	data["limitations"] = append(limitations, fmt.Sprintf("%s data is not available (access denied for this account)", source))
+	return nil
+}
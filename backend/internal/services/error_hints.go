@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"strings"
+)
+
+// remediationHints maps fragments of internal error messages to what a user
+// can do about them. The first matching fragment wins.
+var remediationHints = []struct {
+	fragment string
+	hint     string
+}{
+	{"credentials not configured", "AI provider credentials are missing. Ask your administrator to configure an AI provider."},
+	{"API key not configured", "AI provider credentials are missing. Ask your administrator to configure an AI provider."},
+	{"UnrecognizedClientException", "The AI provider rejected its credentials. Ask your administrator to check the AI provider configuration."},
+	{"AccessDeniedException", "The AI provider denied access to the model. Ask your administrator to enable model access."},
+	{"unsupported Bedrock model", "The configured AI model is not supported. Ask your administrator to choose a supported model."},
+	{"speech server", "The speech server is unavailable. Try again later or ask your administrator to check the speech server."},
+	{"timeout", "The request timed out. Try again in a few minutes."},
+}
+
+// RemediationHint returns a user-facing suggestion for resolving a generation
+// error, or an empty string when there is no specific advice.
+//
+// Parameters:
+//   - err: The error returned by slide, narration, or audio generation
+//
+// Returns the hint text.
+func RemediationHint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	switch {
+	case errors.Is(err, ErrDataTooLarge):
+		return "The project has too much data for one slide. Choose fewer themes or a smaller project."
+	case errors.Is(err, ErrSpeechNotConfigured):
+		return "No speech server is configured. Ask your administrator to set MCP_SPEECH_URL; slides are still available without narration audio."
+	case errors.Is(err, ErrAudioDisabled):
+		return "Audio is disabled on this server. Slides are still available without narration audio."
+	case IsRateLimitError(err):
+		return "Backlog's API rate limit was reached. Wait a few minutes and try again."
+	case IsPermissionError(err):
+		return "Your Backlog account cannot access this data. Sign in again or ask a space administrator for access."
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, entry := range remediationHints {
+		if strings.Contains(message, strings.ToLower(entry.fragment)) {
+			return entry.hint
+		}
+	}
+	return ""
+}
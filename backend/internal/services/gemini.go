@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"intelligent-presenter-backend/pkg/config"
+)
+
+type GeminiService struct {
+	config *config.Config
+	client *http.Client
+}
+
+type GeminiRequest struct {
+	Contents         []GeminiContent        `json:"contents"`
+	GenerationConfig GeminiGenerationConfig `json:"generationConfig"`
+}
+
+type GeminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []GeminiPart `json:"parts"`
+}
+
+type GeminiPart struct {
+	Text string `json:"text"`
+}
+
+type GeminiGenerationConfig struct {
+	Temperature     float64 `json:"temperature"`
+	MaxOutputTokens int     `json:"maxOutputTokens"`
+}
+
+type GeminiResponse struct {
+	Candidates []struct {
+		Content      GeminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback *struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback,omitempty"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error,omitempty"`
+}
+
+func NewGeminiService(cfg *config.Config) *GeminiService {
+	return &GeminiService{
+		config: cfg,
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// GenerateText sends the prompt to the Gemini generateContent API and returns
+// the text of the first candidate.
+func (s *GeminiService) GenerateText(prompt string) (string, error) {
+	return s.GenerateTextWithTemperature(prompt, DefaultTemperature)
+}
+
+// GenerateTextWithTemperature is GenerateText with an explicit sampling temperature
+func (s *GeminiService) GenerateTextWithTemperature(prompt string, temperature float64) (string, error) {
+	if s.config.GeminiAPIKey == "" {
+		return "", fmt.Errorf("Gemini API key not configured")
+	}
+
+	request := GeminiRequest{
+		Contents: []GeminiContent{
+			{
+				Role:  "user",
+				Parts: []GeminiPart{{Text: prompt}},
+			},
+		},
+		GenerationConfig: GeminiGenerationConfig{
+			Temperature:     temperature,
+			MaxOutputTokens: 1500,
+		},
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/models/%s:generateContent", s.baseURL(), s.model())
+
+	slog.Debug("Making Gemini API call", "model", s.model())
+
+	resp, err := doWithRetry(s.client, s.config, "Gemini", func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-goog-api-key", s.config.GeminiAPIKey)
+		return req, nil
+	})
+	if err != nil {
+		slog.Error("Gemini API call error", "error", err)
+		return "", fmt.Errorf("failed to call Gemini API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response GeminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to decode Gemini response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if response.Error != nil {
+			return "", fmt.Errorf("Gemini API returned status %d: %s", resp.StatusCode, response.Error.Message)
+		}
+		return "", fmt.Errorf("Gemini API returned status %d", resp.StatusCode)
+	}
+
+	if response.PromptFeedback != nil && response.PromptFeedback.BlockReason != "" {
+		return "", fmt.Errorf("Gemini blocked the prompt: %s", response.PromptFeedback.BlockReason)
+	}
+
+	if len(response.Candidates) == 0 {
+		return "", fmt.Errorf("no candidates in Gemini response")
+	}
+
+	var text strings.Builder
+	for _, part := range response.Candidates[0].Content.Parts {
+		text.WriteString(part.Text)
+	}
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no content in Gemini response (finish reason: %s)", response.Candidates[0].FinishReason)
+	}
+
+	slog.Debug("Gemini API call successful")
+	return text.String(), nil
+}
+
+func (s *GeminiService) model() string {
+	if s.config.GeminiModel != "" {
+		return s.config.GeminiModel
+	}
+	return "gemini-1.5-flash"
+}
+
+func (s *GeminiService) baseURL() string {
+	if s.config.GeminiBaseURL != "" {
+		return strings.TrimRight(s.config.GeminiBaseURL, "/")
+	}
+	return "https://generativelanguage.googleapis.com/v1beta"
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// Sampling temperature used when a generation does not override it, and the
+// range accepted for overrides. The range is the one supported by every provider.
+const (
+	DefaultTemperature = 0.7
+	MinTemperature     = 0.0
+	MaxTemperature     = 1.0
+)
+
+// GenerationOptions holds per-generation settings for AI calls
+type GenerationOptions struct {
+	Temperature *float64      // Sampling temperature override; nil uses DefaultTemperature
+	OnDelta     TextDeltaFunc // Receives streamed fragments of slide markdown; may be nil
+	Prefetched  *ThemeDataSet // Project data fetched ahead of generation; nil fetches on demand
+	BypassCache bool          // Regenerate even if a slide for identical project data is cached
+	Part        int           // 1-based part of a theme split across several slides
+	Parts       int           // Number of slides the theme is split across; 0 or 1 when not split
+}
+
+// temperature returns the sampling temperature for the generation
+func (o GenerationOptions) temperature() float64 {
+	if o.Temperature != nil {
+		return *o.Temperature
+	}
+	return DefaultTemperature
+}
+
+// cacheTheme returns the theme under which the generated slide is cached, so
+// that each part of a split theme is cached separately
+func (o GenerationOptions) cacheTheme(theme models.SlideTheme) models.SlideTheme {
+	if o.Parts <= 1 {
+		return theme
+	}
+	return models.SlideTheme(fmt.Sprintf("%s#%d/%d", theme, o.Part, o.Parts))
+}
+
+// IsValidTemperature reports whether t is within the accepted override range
+func IsValidTemperature(t float64) bool {
+	return t >= MinTemperature && t <= MaxTemperature
+}
+
+// IsSpaceAdministrator reports whether the user owning the access token is an
+// administrator of the Backlog space. Lookup failures are treated as not an
+// administrator.
+func (s *SlideService) IsSpaceAdministrator(backlogToken string) bool {
+	user, err := s.mcpService.GetMyself(backlogToken)
+	if err != nil {
+		return false
+	}
+	return user.RoleType == models.RoleTypeAdministrator
+}
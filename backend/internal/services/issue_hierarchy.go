@@ -0,0 +1,125 @@
+package services
+
+import (
+	"sort"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// BuildIssueHierarchy assembles Backlog issues into parent/child trees using
+// their parentIssueId. Issues whose parent is missing from the list become
+// roots. Inconsistent data can make parent references loop; each such cycle is
+// reported and broken at its lowest issue ID, which becomes a root, so walking
+// the returned trees always terminates.
+//
+// Parameters:
+//   - issues: Backlog issue objects as returned by the get_issues tool
+//
+// Returns the issue trees, ordered by issue ID, and any parent cycles found.
+func BuildIssueHierarchy(issues []interface{}) *models.IssueHierarchy {
+	nodes := make(map[int]*models.IssueNode)
+	parents := make(map[int]int)
+	for _, item := range issues {
+		issue, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := issue["id"].(float64)
+		if !ok {
+			continue
+		}
+		node := &models.IssueNode{ID: int(id), Children: make([]*models.IssueNode, 0)}
+		node.IssueKey, _ = issue["issueKey"].(string)
+		node.Summary, _ = issue["summary"].(string)
+		nodes[node.ID] = node
+		if parentID, ok := issue["parentIssueId"].(float64); ok {
+			parents[node.ID] = int(parentID)
+		}
+	}
+
+	ids := make([]int, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	hierarchy := &models.IssueHierarchy{
+		Roots:  make([]*models.IssueNode, 0),
+		Cycles: make([][]string, 0),
+	}
+
+	// Follow each parent chain once. A chain that reaches an issue already on
+	// the current path has looped back on itself.
+	const (
+		onPath = iota + 1
+		done
+	)
+	state := make(map[int]int, len(nodes))
+	for _, start := range ids {
+		var path []int
+		id := start
+		for {
+			if _, exists := nodes[id]; !exists || state[id] == done {
+				break
+			}
+			if state[id] == onPath {
+				cycle := cycleFrom(path, id)
+				// Break the cycle by detaching its lowest issue from its parent
+				delete(parents, cycle[0])
+				hierarchy.Cycles = append(hierarchy.Cycles, cycleKeys(cycle, nodes))
+				break
+			}
+			state[id] = onPath
+			path = append(path, id)
+			parentID, hasParent := parents[id]
+			if !hasParent {
+				break
+			}
+			id = parentID
+		}
+		for _, visited := range path {
+			state[visited] = done
+		}
+	}
+
+	for _, id := range ids {
+		if parentID, hasParent := parents[id]; hasParent {
+			if parent, exists := nodes[parentID]; exists {
+				parent.Children = append(parent.Children, nodes[id])
+				continue
+			}
+		}
+		hierarchy.Roots = append(hierarchy.Roots, nodes[id])
+	}
+	return hierarchy
+}
+
+// cycleFrom returns the issue IDs of the cycle that closes at id, starting with
+// the lowest ID and following parent references
+func cycleFrom(path []int, id int) []int {
+	start := 0
+	for i, member := range path {
+		if member == id {
+			start = i
+			break
+		}
+	}
+	cycle := path[start:]
+
+	lowest := 0
+	for i, member := range cycle {
+		if member < cycle[lowest] {
+			lowest = i
+		}
+	}
+	return append(append([]int(nil), cycle[lowest:]...), cycle[:lowest]...)
+}
+
+// cycleKeys returns the issue keys of the given issue IDs
+func cycleKeys(cycle []int, nodes map[int]*models.IssueNode) []string {
+	keys := make([]string, 0, len(cycle))
+	for _, id := range cycle {
+		keys = append(keys, nodes[id].IssueKey)
+	}
+	return keys
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// issuePageSize is the largest page the Backlog issues API returns
+const issuePageSize = 100
+
+// ErrInvalidIssuePage is returned for a negative offset or a page size
+// outside 1 to issuePageSize
+var ErrInvalidIssuePage = fmt.Errorf("offset must not be negative and count must be between 1 and %d", issuePageSize)
+
+// maxStatsIssues bounds how many issues are paged through to compute project
+// statistics, keeping the number of Backlog calls per slide bounded
+const maxStatsIssues = 1000
+
+// maxPageAttempts bounds how often one page is requested when Backlog rate limits it
+const maxPageAttempts = 3
+
+// GetAllIssues pages through the project's issues with offset pagination until
+// a short page is returned or maxIssues issues have been collected. Pages are
+// paced by BACKLOG_PAGE_DELAY, and a rate-limited page is retried after the
+// Retry-After delay so that large projects do not exhaust the Backlog API limit.
+//
+// Parameters:
+//   - projectID: The Backlog project identifier
+//   - backlogToken: OAuth access token for Backlog API
+//   - maxIssues: Upper bound on issues collected
+//
+// Returns the collected issues, together with an error if a page could not be fetched.
+func (s *MCPService) GetAllIssues(projectID, backlogToken string, maxIssues int) ([]interface{}, error) {
+	return s.getAllIssues(projectID, backlogToken, nil, maxIssues)
+}
+
+// getAllIssues pages through the project's issues like GetAllIssues, adding
+// the given get_issues filters (e.g., updatedSince) to every page request.
+func (s *MCPService) getAllIssues(projectID, backlogToken string, filters map[string]interface{}, maxIssues int) ([]interface{}, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]interface{}, 0)
+
+	for offset := 0; len(issues) < maxIssues; offset += issuePageSize {
+		if offset > 0 {
+			s.waitBetweenPages()
+		}
+
+		pageSize := maxIssues - len(issues)
+		if pageSize > issuePageSize {
+			pageSize = issuePageSize
+		}
+		arguments := map[string]interface{}{
+			"projectId": []string{projectID},
+			"count":     pageSize,
+			"offset":    offset,
+		}
+		for key, value := range filters {
+			arguments[key] = value
+		}
+		result, err := s.callBacklogPage("get_issues", arguments, backlogToken)
+		if err != nil {
+			return issues, fmt.Errorf("failed to get issues at offset %d: %w", offset, err)
+		}
+		page, ok := result.([]interface{})
+		if !ok {
+			return issues, fmt.Errorf("unexpected issues format at offset %d: %T", offset, result)
+		}
+
+		issues = append(issues, page...)
+		// A short page means there are no more issues
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	return issues, nil
+}
+
+// issueSample returns at most one page of issues, so that raw issue lists
+// passed on with statistics computed over more issues stay prompt-sized
+func issueSample(issues []interface{}) []interface{} {
+	if len(issues) > issuePageSize {
+		return issues[:issuePageSize]
+	}
+	return issues
+}
+
+// GetIssuePage fetches one page of the project's issues, most recently
+// updated first, together with the project's total issue count.
+//
+// Parameters:
+//   - projectID: The Backlog project identifier
+//   - backlogToken: OAuth access token for Backlog API
+//   - offset: Number of issues to skip
+//   - count: Page size, at most 100
+//
+// Returns the page, or ErrInvalidIssuePage for out-of-range arguments.
+func (s *MCPService) GetIssuePage(projectID, backlogToken string, offset, count int) (*models.IssuePage, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 || count < 1 || count > issuePageSize {
+		return nil, ErrInvalidIssuePage
+	}
+
+	result, err := s.callBacklogPage("get_issues", map[string]interface{}{
+		"projectId": []string{projectID},
+		"offset":    offset,
+		"count":     count,
+		"sort":      "updated",
+		"order":     "desc",
+	}, backlogToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues at offset %d: %w", offset, err)
+	}
+	issues, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected issues format at offset %d: %T", offset, result)
+	}
+
+	countResult, err := s.callBacklogToolHTTP("count_issues", map[string]interface{}{
+		"projectId": []string{projectID},
+	}, backlogToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count issues: %w", err)
+	}
+	counted, ok := countResult.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected issue count format: %T", countResult)
+	}
+	total, _ := counted["count"].(float64)
+
+	return &models.IssuePage{
+		Issues: issues,
+		Offset: offset,
+		Count:  count,
+		Total:  int(total),
+	}, nil
+}
+
+// callBacklogPage calls a Backlog tool for one page of results, waiting and
+// retrying when Backlog rate limits the request. The server's Retry-After
+// delay is used when present, and exponential backoff otherwise.
+func (s *MCPService) callBacklogPage(toolName string, arguments map[string]interface{}, backlogToken string) (interface{}, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := s.callBacklogToolHTTP(toolName, arguments, backlogToken)
+		if err == nil || !IsRateLimitError(err) || attempt >= maxPageAttempts {
+			return result, err
+		}
+
+		var toolErr *BacklogToolError
+		errors.As(err, &toolErr)
+		delay := toolErr.RetryAfter
+		if delay < 0 {
+			delay = backoffDelay(time.Second, attempt)
+		}
+		slog.Warn("Backlog rate limited a page request", "tool", toolName,
+			"retryIn", delay, "attempt", attempt+1, "maxAttempts", maxPageAttempts)
+		time.Sleep(delay)
+	}
+}
+
+// waitBetweenPages pauses between page requests of a paginated fetch
+func (s *MCPService) waitBetweenPages() {
+	if s.config.BacklogPageDelay > 0 {
+		time.Sleep(s.config.BacklogPageDelay)
+	}
+}
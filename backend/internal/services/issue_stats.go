@@ -0,0 +1,114 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// Backlog built-in status and resolution identifiers
+const (
+	backlogStatusOpen          = 1
+	backlogStatusClosed        = 4
+	backlogResolutionDuplicate = 3
+)
+
+// ComputeIssueStats calculates completion statistics for a list of Backlog issues.
+// Issues are tallied by status category (open, in progress, closed) and issues
+// that are not closed but past their due date are counted as overdue.
+// When excludeDuplicates is true, issues resolved as "Duplicate" are left out of
+// all counts so they do not skew the completion rate.
+//
+// Parameters:
+//   - issues: Backlog issue objects as returned by the get_issues tool
+//   - excludeDuplicates: Whether to drop duplicate-resolution issues
+//   - now: Reference time used to determine overdue issues
+//
+// Returns the computed statistics and the issues that were counted.
+func ComputeIssueStats(issues []interface{}, excludeDuplicates bool, now time.Time) (*models.IssueStats, []interface{}) {
+	stats := &models.IssueStats{}
+	counted := make([]interface{}, 0, len(issues))
+
+	for _, item := range issues {
+		issue, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if excludeDuplicates && isDuplicateIssue(issue) {
+			stats.ExcludedDuplicates++
+			continue
+		}
+
+		counted = append(counted, item)
+		stats.TotalIssues++
+
+		switch {
+		case isClosedIssue(issue):
+			stats.CompletedIssues++
+			continue
+		case isOpenIssue(issue):
+			stats.OpenIssues++
+		default:
+			stats.InProgressIssues++
+		}
+
+		if isOverdueIssue(issue, now) {
+			stats.OverdueIssues++
+		}
+	}
+
+	if stats.TotalIssues > 0 {
+		stats.CompletionRate = float64(stats.CompletedIssues) * 100 / float64(stats.TotalIssues)
+	}
+	return stats, counted
+}
+
+// isOpenIssue reports whether the issue is in the built-in open status
+func isOpenIssue(issue map[string]interface{}) bool {
+	return matchesBacklogField(issue["status"], backlogStatusOpen, "未対応", "open")
+}
+
+// isClosedIssue reports whether the issue is in the built-in closed status
+func isClosedIssue(issue map[string]interface{}) bool {
+	return matchesBacklogField(issue["status"], backlogStatusClosed, "完了", "closed")
+}
+
+// isDuplicateIssue reports whether the issue was resolved as a duplicate
+func isDuplicateIssue(issue map[string]interface{}) bool {
+	return matchesBacklogField(issue["resolution"], backlogResolutionDuplicate, "重複", "duplicate")
+}
+
+// isOverdueIssue reports whether the issue's due date is before now
+func isOverdueIssue(issue map[string]interface{}, now time.Time) bool {
+	dueDateStr, ok := issue["dueDate"].(string)
+	if !ok || dueDateStr == "" {
+		return false
+	}
+	dueDate, err := time.Parse(time.RFC3339, dueDateStr)
+	if err != nil {
+		return false
+	}
+	// Backlog due dates have day precision, so an issue is overdue from the following day
+	return now.After(dueDate.AddDate(0, 0, 1))
+}
+
+// matchesBacklogField checks a Backlog {id, name} object against a built-in ID
+// or one of the localized names
+func matchesBacklogField(field interface{}, id int, names ...string) bool {
+	obj, ok := field.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if fieldID, ok := obj["id"].(float64); ok && int(fieldID) == id {
+		return true
+	}
+	if name, ok := obj["name"].(string); ok {
+		for _, candidate := range names {
+			if strings.EqualFold(strings.TrimSpace(name), candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// BuildPresentationJSONLD describes a slide deck as a schema.org
+// PresentationDigitalDocument. Each generated slide becomes a part in
+// presentation order, with its narration audio and transcript attached as an
+// AudioObject when available. Slides that failed to generate are omitted.
+//
+// Parameters:
+//   - record: Snapshot of the slide session
+//
+// Returns the JSON-LD document.
+func BuildPresentationJSONLD(record *models.SlideSessionRecord) *models.PresentationJSONLD {
+	narrations := make(map[int]string, len(record.Narrations))
+	for _, narration := range record.Narrations {
+		narrations[narration.SlideIndex] = narration.Text
+	}
+	audioFiles := make(map[int]*models.SlideAudio, len(record.AudioFiles))
+	for _, audio := range record.AudioFiles {
+		audioFiles[audio.SlideIndex] = audio
+	}
+
+	parts := make([]*models.SlideJSONLD, 0, len(record.Slides))
+	for _, slide := range record.Slides {
+		part := &models.SlideJSONLD{
+			Type:     "CreativeWork",
+			Position: slide.Index + 1,
+			Name:     slide.Title,
+			Genre:    slide.Theme,
+			Text:     slide.PlainText,
+		}
+		if !slide.GeneratedAt.IsZero() {
+			part.DateCreated = slide.GeneratedAt.UTC().Format(time.RFC3339)
+		}
+		if audio, exists := audioFiles[slide.Index]; exists {
+			part.AssociatedMedia = &models.AudioJSONLD{
+				Type:       "AudioObject",
+				ContentURL: audio.AudioURL,
+				Transcript: narrations[slide.Index],
+			}
+			if audio.Duration > 0 {
+				part.AssociatedMedia.Duration = fmt.Sprintf("PT%dS", audio.Duration)
+			}
+		}
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Position < parts[j].Position
+	})
+
+	document := &models.PresentationJSONLD{
+		Context:     "https://schema.org",
+		Type:        "PresentationDigitalDocument",
+		Identifier:  record.ID,
+		Name:        fmt.Sprintf("%s presentation", record.ProjectID),
+		About:       record.ProjectID.String(),
+		InLanguage:  record.Language,
+		Keywords:    record.Themes,
+		DateCreated: record.CreatedAt.UTC().Format(time.RFC3339),
+		HasPart:     parts,
+	}
+	if !record.CompletedAt.IsZero() {
+		document.DateModified = record.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	return document
+}
@@ -0,0 +1,61 @@
+package services
+
+import "sort"
+
+// languageNames maps the supported slide and narration languages to their
+// English and native names. The set matches the languages the speech server
+// can synthesize. Japanese and English have dedicated prompt templates; the
+// others use the English templates with an instruction to write in the
+// target language.
+var languageNames = map[string]struct {
+	English string
+	Native  string
+}{
+	"ja": {"Japanese", "日本語"},
+	"en": {"English", "English"},
+	"es": {"Spanish", "Español"},
+	"fr": {"French", "Français"},
+	"hi": {"Hindi", "हिन्दी"},
+	"it": {"Italian", "Italiano"},
+	"pt": {"Portuguese", "Português"},
+	"zh": {"Chinese", "中文"},
+}
+
+// IsSupportedLanguage reports whether slides and narration can be generated in
+// the language
+func IsSupportedLanguage(language string) bool {
+	_, exists := languageNames[language]
+	return exists
+}
+
+// SupportedLanguages returns the codes of all supported languages, sorted
+func SupportedLanguages() []string {
+	languages := make([]string, 0, len(languageNames))
+	for language := range languageNames {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// languageDisplayName returns the language's name for use in English prompts,
+// such as "Spanish (Español)"
+func languageDisplayName(language string) string {
+	names, exists := languageNames[language]
+	if !exists {
+		return language
+	}
+	if names.English == names.Native {
+		return names.English
+	}
+	return names.English + " (" + names.Native + ")"
+}
+
+// targetLanguageInstruction returns the instruction added to English prompt
+// templates to produce output in another language, or an empty string for English
+func targetLanguageInstruction(language string) string {
+	if language == "en" || language == "" {
+		return ""
+	}
+	return "\nWrite all of the output, including the title, in " + languageDisplayName(language) + ".\n"
+}
@@ -0,0 +1,32 @@
+package services
+
+// ConcurrencyLimiter bounds how many operations may run at the same time.
+// A nil limiter or one created with a non-positive limit does not restrict concurrency.
+type ConcurrencyLimiter struct {
+	slots chan struct{}
+}
+
+// NewConcurrencyLimiter creates a limiter allowing up to limit concurrent operations.
+// A limit of zero or less means unlimited.
+func NewConcurrencyLimiter(limit int) *ConcurrencyLimiter {
+	if limit <= 0 {
+		return &ConcurrencyLimiter{}
+	}
+	return &ConcurrencyLimiter{slots: make(chan struct{}, limit)}
+}
+
+// Acquire blocks until a slot is available.
+func (l *ConcurrencyLimiter) Acquire() {
+	if l == nil || l.slots == nil {
+		return
+	}
+	l.slots <- struct{}{}
+}
+
+// Release frees a slot previously obtained with Acquire.
+func (l *ConcurrencyLimiter) Release() {
+	if l == nil || l.slots == nil {
+		return
+	}
+	<-l.slots
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// slideSeparator separates slides in exported markdown, as in Slidev decks
+const slideSeparator = "\n---\n\n"
+
+// BuildDeckMarkdown joins the markdown of every generated slide in index order
+// into one document, with slides separated by "---" lines.
+//
+// Parameters:
+//   - record: Snapshot of the slide session
+//
+// Returns the deck markdown.
+func BuildDeckMarkdown(record *models.SlideSessionRecord) string {
+	slides := append([]*models.SlideContent(nil), record.Slides...)
+	sort.Slice(slides, func(i, j int) bool {
+		return slides[i].Index < slides[j].Index
+	})
+
+	parts := make([]string, 0, len(slides))
+	for _, slide := range slides {
+		parts = append(parts, strings.TrimSpace(slide.Markdown)+"\n")
+	}
+	return strings.Join(parts, slideSeparator)
+}
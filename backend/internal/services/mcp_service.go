@@ -4,18 +4,57 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"intelligent-presenter-backend/pkg/config"
+	"intelligent-presenter-backend/pkg/logging"
 )
 
+// Errors returned for project requests whose identifiers are missing
+var (
+	ErrEmptyProjectID    = errors.New("project ID must not be empty")
+	ErrEmptyBacklogToken = errors.New("Backlog access token must not be empty")
+)
+
+// ErrLocalBacklogMCPInProduction is returned for Backlog tool calls in
+// production when BACKLOG_MCP_COMMAND would run them with the server's own
+// credentials instead of the user's access token
+var ErrLocalBacklogMCPInProduction = errors.New("the local backlog-server is for development only; set MCP_BACKLOG_URL")
+
+// ValidateProjectInput trims surrounding whitespace from a project ID and a
+// Backlog access token and checks that neither is empty, so that requests which
+// cannot succeed are rejected before any Backlog API call is made.
+//
+// Parameters:
+//   - projectID: The Backlog project identifier
+//   - backlogToken: OAuth access token for Backlog API
+//
+// Returns the trimmed project ID and token, or ErrEmptyProjectID or
+// ErrEmptyBacklogToken.
+func ValidateProjectInput(projectID, backlogToken string) (string, string, error) {
+	projectID = strings.TrimSpace(projectID)
+	backlogToken = strings.TrimSpace(backlogToken)
+	if projectID == "" {
+		return "", "", ErrEmptyProjectID
+	}
+	if backlogToken == "" {
+		return "", "", ErrEmptyBacklogToken
+	}
+	return projectID, backlogToken, nil
+}
+
 type MCPService struct {
 	config          *config.Config
 	backlogWrapper  *BacklogMCPWrapper
 	speechService   *SpeechService
+	spaceCache      *spaceCache
 }
 
 func NewMCPService(cfg *config.Config) *MCPService {
@@ -23,6 +62,7 @@ func NewMCPService(cfg *config.Config) *MCPService {
 		config:         cfg,
 		backlogWrapper: NewBacklogMCPWrapper(cfg),
 		speechService:  NewSpeechService(cfg),
+		spaceCache:     newSpaceCache(),
 	}
 }
 
@@ -44,44 +84,72 @@ func (s *MCPService) GetProjects(backlogToken string) (interface{}, error) {
 }
 
 func (s *MCPService) GetProjectOverview(projectID, backlogToken string) (interface{}, error) {
-	projectData := make(map[string]interface{})
-	
-	// Get project details using HTTP bridge
-	project, err := s.callBacklogToolHTTP("get_project", map[string]interface{}{
-		"projectIdOrKey": projectID,
-	}, backlogToken)
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project: %w", err)
+		return nil, err
+	}
+	projectData := make(map[string]interface{})
+
+	// The project, space, and users calls are independent, so fetch them concurrently
+	var (
+		wg                             sync.WaitGroup
+		project, space, users          interface{}
+		projectErr, spaceErr, usersErr error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		project, projectErr = s.callBacklogToolHTTP("get_project", map[string]interface{}{
+			"projectIdOrKey": projectID,
+		}, backlogToken)
+	}()
+	go func() {
+		defer wg.Done()
+		space, spaceErr = s.getSpace(backlogToken)
+	}()
+	go func() {
+		defer wg.Done()
+		users, usersErr = s.callBacklogToolHTTP("get_users", map[string]interface{}{}, backlogToken)
+	}()
+	wg.Wait()
+
+	// Project details are required; space info and users are best-effort
+	if projectErr != nil {
+		return nil, fmt.Errorf("failed to get project: %w", projectErr)
 	}
 	projectData["project"] = project
-	
-	// Get space info
-	space, err := s.callBacklogToolHTTP("get_space", map[string]interface{}{}, backlogToken)
-	if err == nil {
+	if spaceErr == nil {
 		projectData["space"] = space
 	}
-	
-	// Get project users
-	users, err := s.callBacklogToolHTTP("get_users", map[string]interface{}{}, backlogToken)
-	if err == nil {
+	if usersErr == nil {
 		projectData["users"] = users
 	}
-	
+
 	return projectData, nil
 }
 
 func (s *MCPService) GetProjectProgress(projectID, backlogToken string) (interface{}, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
 	progressData := make(map[string]interface{})
 	
-	// Get issues for progress analysis
-	issues, err := s.callBacklogToolHTTP("get_issues", map[string]interface{}{
-		"projectId": []string{projectID},
-		"count":     100,
-	}, backlogToken)
+	// Page through the issues so that progress covers more than the first page
+	issueList, err := s.GetAllIssues(projectID, backlogToken, maxStatsIssues)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issues: %w", err)
 	}
-	progressData["issues"] = issues
+
+	// Compute grounded progress numbers (status categories, completion, overdue)
+	// so the prompt does not rely on the LLM to infer them from raw issues
+	now := time.Now()
+	stats, counted := ComputeIssueStats(issueList, s.config.ExcludeDuplicateIssues, now)
+	progressData["issues"] = issueSample(counted)
+	progressData["stats"] = stats
+	if burndown := ComputeBurndown(counted, burndownDays, now); burndown != nil {
+		progressData["burndown"] = burndown
+	}
 	
 	// Get issue count
 	issueCount, err := s.callBacklogToolHTTP("count_issues", map[string]interface{}{
@@ -95,6 +163,10 @@ func (s *MCPService) GetProjectProgress(projectID, backlogToken string) (interfa
 }
 
 func (s *MCPService) GetProjectIssues(projectID, backlogToken string) (interface{}, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
 	issueData := make(map[string]interface{})
 	
 	// Get recent issues
@@ -127,6 +199,10 @@ func (s *MCPService) GetProjectIssues(projectID, backlogToken string) (interface
 }
 
 func (s *MCPService) GetProjectTeam(projectID, backlogToken string) (interface{}, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
 	teamData := make(map[string]interface{})
 	
 	// Get project users
@@ -150,7 +226,40 @@ func (s *MCPService) GetProjectTeam(projectID, backlogToken string) (interface{}
 	return teamData, nil
 }
 
+// GetProjectCodebase retrieves the project's Git repositories. Accounts without
+// Git access get a permission error, which callers may treat as a limitation.
+func (s *MCPService) GetProjectCodebase(projectID, backlogToken string) (interface{}, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+	repositories, err := s.callBacklogToolHTTP("get_git_repositories", map[string]interface{}{
+		"projectKey": projectID,
+	}, backlogToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repositories: %w", err)
+	}
+	codebase := map[string]interface{}{
+		"repositories": repositories,
+	}
+
+	// Pull request counts across the repositories are best-effort
+	pullRequests, err := s.callBacklogToolHTTP("get_project_pull_request_summary", map[string]interface{}{
+		"projectKey": projectID,
+	}, backlogToken)
+	if err == nil {
+		codebase["pullRequests"] = pullRequests
+	} else {
+		slog.Warn("Failed to get pull request summary", "projectID", projectID, "error", err)
+	}
+	return codebase, nil
+}
+
 func (s *MCPService) GetProjectRisks(projectID, backlogToken string) (interface{}, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
 	riskData := make(map[string]interface{})
 	
 	// Get overdue/high priority issues as risks
@@ -165,20 +274,27 @@ func (s *MCPService) GetProjectRisks(projectID, backlogToken string) (interface{
 	}
 	riskData["highPriorityIssues"] = overdueIssues
 	
-	// Get all issues for risk analysis
-	allIssues, err := s.callBacklogToolHTTP("get_issues", map[string]interface{}{
-		"projectId": []string{projectID},
-		"count":     100,
-	}, backlogToken)
+	// Page through all issues for risk analysis
+	var scored interface{} = overdueIssues
+	allIssues, err := s.GetAllIssues(projectID, backlogToken, maxStatsIssues)
 	if err == nil {
-		riskData["allIssues"] = allIssues
+		riskData["allIssues"] = issueSample(allIssues)
+		scored = allIssues
+	} else {
+		slog.Warn("Failed to get all issues for risk analysis", "projectID", projectID, "error", err)
+	}
+
+	// Score overdue, due-soon, unassigned, and stalled issues so the risk slide
+	// is grounded in data; fall back to the high-priority list when needed
+	if issueList, ok := scored.([]interface{}); ok {
+		riskData["riskSignals"] = ComputeRiskSignals(issueList, RiskThresholdsFromConfig(s.config), time.Now())
 	}
 	
 	return riskData, nil
 }
 
-func (s *MCPService) SynthesizeSpeech(text, language, voice string) (string, error) {
-	return s.speechService.SynthesizeSpeech(text, language, voice)
+func (s *MCPService) SynthesizeSpeech(text, language, voice string, speed float64) (string, error) {
+	return s.speechService.SynthesizeSpeech(text, language, voice, speed)
 }
 
 func (s *MCPService) ServeAudioFile(filename string) (string, error) {
@@ -189,6 +305,21 @@ func (s *MCPService) ServeAudioFile(filename string) (string, error) {
 
 
 func (s *MCPService) callBacklogToolHTTP(toolName string, arguments map[string]interface{}, accessToken ...string) (interface{}, error) {
+    // In local mode the subprocess authenticates with its own credentials and
+    // cannot act as the requesting user, so it is refused in production
+    if s.config.UsesLocalBacklogMCP() {
+        if s.config.IsProduction() {
+            return nil, ErrLocalBacklogMCPInProduction
+        }
+        slog.Debug("Calling Backlog tool on local server", "tool", toolName, "args", logging.RedactMap(arguments))
+        result, err := s.backlogWrapper.CallTool(toolName, arguments)
+        if err != nil {
+            slog.Error("Backlog tool call failed", "tool", toolName, "error", err)
+            return nil, fmt.Errorf("local Backlog MCP call failed: %w", err)
+        }
+        return parseBacklogToolResult(result)
+    }
+
     client := &http.Client{
         Timeout: 30 * time.Second,
     }
@@ -209,6 +340,8 @@ func (s *MCPService) callBacklogToolHTTP(toolName string, arguments map[string]i
         return nil, fmt.Errorf("failed to marshal request: %w", err)
     }
 
+    slog.Debug("Calling Backlog tool", "tool", toolName, "args", logging.RedactMap(arguments))
+
     // Use the HTTP Bridge endpoint
     url := s.config.MCPBacklogURL + "/mcp/call"
     req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
@@ -220,6 +353,7 @@ func (s *MCPService) callBacklogToolHTTP(toolName string, arguments map[string]i
 
     resp, err := client.Do(req)
     if err != nil {
+        slog.Error("Backlog tool call failed", "tool", toolName, "error", err)
         return nil, fmt.Errorf("failed to make HTTP request: %w", err)
     }
     defer resp.Body.Close()
@@ -230,6 +364,21 @@ func (s *MCPService) callBacklogToolHTTP(toolName string, arguments map[string]i
     }
 
     if resp.StatusCode != http.StatusOK {
+        // The bridge reports tool failures as { error, status } where status is
+        // the Backlog API status, used to detect permission errors
+        var errorResp struct {
+            Error  string `json:"error"`
+            Status int    `json:"status,omitempty"`
+        }
+        slog.Warn("Backlog tool returned an error", "tool", toolName, "status", resp.StatusCode)
+        if err := json.Unmarshal(bodyBytes, &errorResp); err == nil && errorResp.Error != "" {
+            return nil, &BacklogToolError{Tool: toolName, StatusCode: errorResp.Status, Message: errorResp.Error,
+                RetryAfter: retryAfterDelay(resp.Header.Get("Retry-After"))}
+        }
+        if resp.StatusCode == http.StatusTooManyRequests {
+            return nil, &BacklogToolError{Tool: toolName, StatusCode: resp.StatusCode, Message: "rate limited",
+                RetryAfter: retryAfterDelay(resp.Header.Get("Retry-After"))}
+        }
         return nil, fmt.Errorf("MCP HTTP error %d: %s", resp.StatusCode, string(bodyBytes))
     }
 
@@ -245,6 +394,12 @@ func (s *MCPService) callBacklogToolHTTP(toolName string, arguments map[string]i
         return nil, fmt.Errorf("MCP bridge error: %s", bridgeResp.Error)
     }
 
+    return parseBacklogToolResult(bridgeResp.Result)
+}
+
+// parseBacklogToolResult extracts the data of a Backlog tools/call result,
+// decoding JSON text content where possible
+func parseBacklogToolResult(result json.RawMessage) (interface{}, error) {
     // Parse the actual tool result (JSON-RPC result from MCP server)
     var toolResult struct {
         Content []struct {
@@ -254,7 +409,7 @@ func (s *MCPService) callBacklogToolHTTP(toolName string, arguments map[string]i
         } `json:"content"`
     }
 
-    if err := json.Unmarshal(bridgeResp.Result, &toolResult); err != nil {
+    if err := json.Unmarshal(result, &toolResult); err != nil {
         return nil, fmt.Errorf("failed to parse tool result: %w", err)
     }
 
@@ -272,7 +427,7 @@ func (s *MCPService) callBacklogToolHTTP(toolName string, arguments map[string]i
         }
     }
 
-    return bridgeResp.Result, nil
+    return result, nil
 }
 
 func (s *MCPService) Close(ctx context.Context) error {
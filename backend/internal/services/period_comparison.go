@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// comparisonPeriodDays is the length of each period in a period comparison
+const comparisonPeriodDays = 7
+
+// backlogDateFormat is the date format of Backlog's date range filters
+const backlogDateFormat = "2006-01-02"
+
+// GetPeriodComparison compares the statistics of issues updated in the last
+// comparisonPeriodDays days, ending today, with those updated in the period
+// immediately before it.
+//
+// Parameters:
+//   - projectID: The Backlog project identifier
+//   - backlogToken: OAuth access token for Backlog API
+//   - now: Reference time; the current period ends on its date
+//
+// Returns the statistics of both periods and their delta.
+func (s *MCPService) GetPeriodComparison(projectID, backlogToken string, now time.Time) (*models.PeriodComparison, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+	currentUntil := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	currentSince := currentUntil.AddDate(0, 0, -(comparisonPeriodDays - 1))
+	previousUntil := currentSince.AddDate(0, 0, -1)
+	previousSince := previousUntil.AddDate(0, 0, -(comparisonPeriodDays - 1))
+
+	previous, err := s.getPeriodStats(projectID, backlogToken, previousSince, previousUntil)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.getPeriodStats(projectID, backlogToken, currentSince, currentUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PeriodComparison{
+		Previous: *previous,
+		Current:  *current,
+		Delta:    ComparePeriodStats(previous.Stats, current.Stats),
+	}, nil
+}
+
+// getPeriodStats computes issue statistics for issues updated between since and
+// until, both inclusive, paging through up to maxStatsIssues of them. Overdue
+// issues are judged as of the end of the period.
+func (s *MCPService) getPeriodStats(projectID, backlogToken string, since, until time.Time) (*models.PeriodStats, error) {
+	period := &models.PeriodStats{
+		Since: since.Format(backlogDateFormat),
+		Until: until.Format(backlogDateFormat),
+	}
+
+	issueList, err := s.getAllIssues(projectID, backlogToken, map[string]interface{}{
+		"updatedSince": period.Since,
+		"updatedUntil": period.Until,
+	}, maxStatsIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues updated %s to %s: %w", period.Since, period.Until, err)
+	}
+
+	period.Stats, _ = ComputeIssueStats(issueList, s.config.ExcludeDuplicateIssues, until.AddDate(0, 0, 1))
+	return period, nil
+}
+
+// ComparePeriodStats computes the change in issue statistics from the previous
+// period to the current one. Positive values mean the current period is higher.
+//
+// Parameters:
+//   - previous: Statistics of the earlier period
+//   - current: Statistics of the later period
+//
+// Returns the current statistics minus the previous ones.
+func ComparePeriodStats(previous, current *models.IssueStats) models.IssueStatsDelta {
+	return models.IssueStatsDelta{
+		TotalIssues:      current.TotalIssues - previous.TotalIssues,
+		OpenIssues:       current.OpenIssues - previous.OpenIssues,
+		InProgressIssues: current.InProgressIssues - previous.InProgressIssues,
+		CompletedIssues:  current.CompletedIssues - previous.CompletedIssues,
+		OverdueIssues:    current.OverdueIssues - previous.OverdueIssues,
+		CompletionRate:   current.CompletionRate - previous.CompletionRate,
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownHeadingPattern = regexp.MustCompile(`^#{1,6}\s+`)
+	markdownBulletPattern  = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+	markdownQuotePattern   = regexp.MustCompile(`^\s*>\s?`)
+	markdownRulePattern    = regexp.MustCompile(`^\s*(?:-{3,}|\*{3,}|_{3,})\s*$`)
+	markdownTableRule      = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(?:\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	markdownImagePattern   = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLinkPattern    = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	markdownEmphasis       = regexp.MustCompile(`(\*\*|__|\*|_|~~)([^*_~]+)(\*\*|__|\*|_|~~)`)
+	markdownInlineCode     = regexp.MustCompile("`([^`]*)`")
+)
+
+// StripMarkdown converts slide markdown into plain text for consumers such as
+// search indexing and screen readers. Headings, list markers, emphasis, links,
+// and table syntax are removed while their text is kept; fenced code blocks
+// (including Mermaid and Chart.js definitions) are dropped entirely.
+//
+// Parameters:
+//   - markdown: Source markdown content of a slide
+//
+// Returns the plain text with one line per non-empty markdown line.
+func StripMarkdown(markdown string) string {
+	var lines []string
+	inCodeBlock := false
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock || trimmed == "" || markdownRulePattern.MatchString(trimmed) || markdownTableRule.MatchString(trimmed) {
+			continue
+		}
+
+		text := markdownHeadingPattern.ReplaceAllString(trimmed, "")
+		text = markdownQuotePattern.ReplaceAllString(text, "")
+		text = markdownBulletPattern.ReplaceAllString(text, "")
+		text = markdownImagePattern.ReplaceAllString(text, "$1")
+		text = markdownLinkPattern.ReplaceAllString(text, "$1")
+		text = markdownEmphasis.ReplaceAllString(text, "$2")
+		text = markdownInlineCode.ReplaceAllString(text, "$1")
+
+		// Flatten table rows into space-separated cells
+		if strings.HasPrefix(text, "|") {
+			cells := strings.Split(strings.Trim(text, "|"), "|")
+			for i, cell := range cells {
+				cells[i] = strings.TrimSpace(cell)
+			}
+			text = strings.Join(cells, " ")
+		}
+
+		if text = strings.TrimSpace(text); text != "" {
+			lines = append(lines, text)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// MaxPortfolioProjects is the most projects one portfolio comparison slide
+// compares, keeping the prompt and the number of Backlog calls bounded
+const MaxPortfolioProjects = 10
+
+// GetPortfolioProject fetches a project and computes the statistics of its
+// issues, paging through up to maxStatsIssues of them, for a portfolio
+// comparison. Only the statistics are kept, not the issues themselves, so that
+// several projects fit in one prompt.
+//
+// Parameters:
+//   - projectID: The Backlog project identifier
+//   - backlogToken: OAuth access token for Backlog API
+//   - now: Reference time for judging overdue issues
+//
+// Returns the project's progress summary.
+func (s *MCPService) GetPortfolioProject(projectID, backlogToken string, now time.Time) (*models.PortfolioProject, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg                    sync.WaitGroup
+		project               interface{}
+		issueList             []interface{}
+		projectErr, issuesErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		project, projectErr = s.callBacklogToolHTTP("get_project", map[string]interface{}{
+			"projectIdOrKey": projectID,
+		}, backlogToken)
+	}()
+	go func() {
+		defer wg.Done()
+		issueList, issuesErr = s.GetAllIssues(projectID, backlogToken, maxStatsIssues)
+	}()
+	wg.Wait()
+
+	if projectErr != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, projectErr)
+	}
+	if issuesErr != nil {
+		return nil, fmt.Errorf("failed to get issues of project %s: %w", projectID, issuesErr)
+	}
+
+	summary := &models.PortfolioProject{ProjectID: projectID}
+	if details, ok := project.(map[string]interface{}); ok {
+		summary.ProjectKey, _ = details["projectKey"].(string)
+		summary.Name, _ = details["name"].(string)
+	}
+	summary.Stats, _ = ComputeIssueStats(issueList, s.config.ExcludeDuplicateIssues, now)
+	return summary, nil
+}
+
+// getPortfolioData fetches the progress of each project concurrently, keeping
+// the given order. Projects that cannot be fetched are noted as limitations so
+// that the comparison still covers the rest; it fails only when no project
+// could be fetched.
+func (s *SlideService) getPortfolioData(projectIDs []string, backlogToken string) (map[string]interface{}, error) {
+	if len(projectIDs) == 0 {
+		return nil, ErrEmptyProjectID
+	}
+	if len(projectIDs) > MaxPortfolioProjects {
+		return nil, fmt.Errorf("a portfolio comparison covers at most %d projects, got %d", MaxPortfolioProjects, len(projectIDs))
+	}
+
+	now := time.Now()
+	results := make([]*models.PortfolioProject, len(projectIDs))
+	errs := make([]error, len(projectIDs))
+	limiter := NewConcurrencyLimiter(s.prefetchConcurrency())
+
+	var wg sync.WaitGroup
+	for i, projectID := range projectIDs {
+		wg.Add(1)
+		go func(i int, projectID string) {
+			defer wg.Done()
+			limiter.Acquire()
+			defer limiter.Release()
+			results[i], errs[i] = s.mcpService.GetPortfolioProject(projectID, backlogToken, now)
+		}(i, projectID)
+	}
+	wg.Wait()
+
+	data := make(map[string]interface{})
+	projects := make([]*models.PortfolioProject, 0, len(projectIDs))
+	var limitations []string
+	for i, project := range results {
+		if errs[i] != nil {
+			slog.Warn("Failed to get project for portfolio comparison", "projectID", projectIDs[i], "error", errs[i])
+			limitations = append(limitations, fmt.Sprintf("Project %s data is not available", projectIDs[i]))
+			continue
+		}
+		projects = append(projects, project)
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("failed to get any portfolio project: %w", errs[0])
+	}
+
+	data["projects"] = projects
+	if len(limitations) > 0 {
+		data["limitations"] = limitations
+	}
+	return data, nil
+}
+
+// PortfolioChartConfig builds a Chart.js stacked bar chart comparing the
+// completed, in-progress, and open issues of each project in a portfolio.
+//
+// Parameters:
+//   - projects: Projects of the portfolio, in display order
+//   - language: Deck language for the chart title and labels
+//
+// Returns the chart configuration.
+func PortfolioChartConfig(projects []*models.PortfolioProject, language string) map[string]interface{} {
+	title := "Portfolio Progress"
+	names := [3]string{"Completed", "In progress", "Open"}
+	if language == "ja" {
+		title = "プロジェクト別進捗"
+		names = [3]string{"完了", "処理中", "未対応"}
+	}
+
+	labels := make([]string, len(projects))
+	completed := make([]int, len(projects))
+	inProgress := make([]int, len(projects))
+	open := make([]int, len(projects))
+	for i, project := range projects {
+		labels[i] = portfolioLabel(project)
+		if project.Stats != nil {
+			completed[i] = project.Stats.CompletedIssues
+			inProgress[i] = project.Stats.InProgressIssues
+			open[i] = project.Stats.OpenIssues
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "bar",
+		"data": map[string]interface{}{
+			"labels": labels,
+			"datasets": []map[string]interface{}{
+				{"label": names[0], "data": completed, "backgroundColor": "#4caf50"},
+				{"label": names[1], "data": inProgress, "backgroundColor": "#2196f3"},
+				{"label": names[2], "data": open, "backgroundColor": "#ff9800"},
+			},
+		},
+		"options": map[string]interface{}{
+			"plugins": map[string]interface{}{
+				"title": map[string]interface{}{"display": true, "text": title},
+			},
+			"scales": map[string]interface{}{
+				"x": map[string]interface{}{"stacked": true},
+				"y": map[string]interface{}{"stacked": true, "beginAtZero": true},
+			},
+		},
+	}
+}
+
+// portfolioLabel names a project on the portfolio chart by its key, falling
+// back to its name and then the identifier it was requested by
+func portfolioLabel(project *models.PortfolioProject) string {
+	for _, label := range []string{project.ProjectKey, project.Name} {
+		if label = strings.TrimSpace(label); label != "" {
+			return label
+		}
+	}
+	return project.ProjectID
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"log/slog"
+	"sync"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// defaultPrefetchConcurrency bounds concurrent theme data fetches when
+// PREFETCH_MAX_CONCURRENCY is not set
+const defaultPrefetchConcurrency = 4
+
+// ThemeDataSet holds project data fetched ahead of generation, keyed by theme.
+// A nil set holds no data.
+type ThemeDataSet struct {
+	mu   sync.Mutex
+	data map[models.SlideTheme]map[string]interface{}
+}
+
+// get returns a copy of the prefetched data for the theme, so that each slide
+// can remove entries without affecting other slides of the same theme
+func (d *ThemeDataSet) get(theme models.SlideTheme) (map[string]interface{}, bool) {
+	if d == nil {
+		return nil, false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, exists := d.data[theme]
+	if !exists {
+		return nil, false
+	}
+	projectData := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		projectData[key] = value
+	}
+	return projectData, true
+}
+
+// Len returns the number of themes with prefetched data
+func (d *ThemeDataSet) Len() int {
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.data)
+}
+
+// PrefetchThemeData fetches the Backlog data of every theme concurrently so
+// that data fetching overlaps instead of waiting behind each slide's AI call.
+// Concurrency is bounded by PREFETCH_MAX_CONCURRENCY. Themes whose data cannot
+// be fetched are left out; their slides fetch the data again when generated
+// and report the error then.
+//
+// Parameters:
+//   - projectID: The Backlog project identifier
+//   - themes: Themes of the deck; duplicates are fetched once
+//   - backlogToken: OAuth access token for Backlog API
+//
+// Returns the prefetched data, to be passed in GenerationOptions.Prefetched.
+func (s *SlideService) PrefetchThemeData(projectID string, themes []models.SlideTheme, backlogToken string) *ThemeDataSet {
+	set := &ThemeDataSet{data: make(map[models.SlideTheme]map[string]interface{}, len(themes))}
+	limiter := NewConcurrencyLimiter(s.prefetchConcurrency())
+
+	var wg sync.WaitGroup
+	seen := make(map[models.SlideTheme]bool, len(themes))
+	for _, theme := range themes {
+		// Portfolio data depends on the projects compared, so it is fetched
+		// when the slide is generated
+		if seen[theme] || theme == models.ThemePortfolioComparison {
+			continue
+		}
+		seen[theme] = true
+
+		wg.Add(1)
+		go func(theme models.SlideTheme) {
+			defer wg.Done()
+			limiter.Acquire()
+			defer limiter.Release()
+
+			data, err := s.getProjectDataForTheme(projectID, theme, backlogToken)
+			if err != nil {
+				slog.Warn("Failed to prefetch theme data", "theme", theme, "error", err)
+				return
+			}
+			set.mu.Lock()
+			set.data[theme] = data
+			set.mu.Unlock()
+		}(theme)
+	}
+	wg.Wait()
+
+	slog.Debug("Prefetched theme data", "themes", len(seen), "fetched", set.Len())
+	return set
+}
+
+// prefetchConcurrency returns how many themes' data may be fetched at once
+func (s *SlideService) prefetchConcurrency() int {
+	if s.config.PrefetchMaxConcurrency > 0 {
+		return s.config.PrefetchMaxConcurrency
+	}
+	return defaultPrefetchConcurrency
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// ErrDataTooLarge is returned when project data exceeds the prompt size limit
+// and ON_OVERSIZE_DATA is set to "error"
+var ErrDataTooLarge = errors.New("project data exceeds the prompt size limit")
+
+// promptDataMaxBytes returns the maximum size of project data embedded in a prompt
+func (s *SlideService) promptDataMaxBytes() int {
+	if s.config.PromptDataMaxBytes > 0 {
+		return s.config.PromptDataMaxBytes
+	}
+	return 8000
+}
+
+// CheckPromptDataSize reports whether the project data fits the prompt size
+// limit. Oversized data is only an error when ON_OVERSIZE_DATA is "error";
+// otherwise it is truncated when the prompt is built.
+//
+// Returns an error wrapping ErrDataTooLarge when the data must be rejected.
+func (s *SlideService) CheckPromptDataSize(projectData map[string]interface{}) error {
+	if s.config.OnOversizeData != config.OversizeDataError {
+		return nil
+	}
+	dataJSON, _ := json.Marshal(projectData)
+	if limit := s.promptDataMaxBytes(); len(dataJSON) > limit {
+		return fmt.Errorf("%w: %d bytes exceeds the %d byte limit, narrow the scope or choose fewer themes",
+			ErrDataTooLarge, len(dataJSON), limit)
+	}
+	return nil
+}
+
+// promptDataJSON serializes project data for the prompt, truncating it to the
+// size limit to prevent context overflow
+func (s *SlideService) promptDataJSON(projectData map[string]interface{}) []byte {
+	dataJSON, _ := json.Marshal(projectData)
+	limit := s.promptDataMaxBytes()
+	if len(dataJSON) <= limit {
+		return dataJSON
+	}
+
+	// Back off to a rune boundary so multibyte text is not split mid-character
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(dataJSON[cut]) {
+		cut--
+	}
+	dataJSON = dataJSON[:cut]
+	return append(dataJSON, []byte("...}")...) // Close JSON properly
+}
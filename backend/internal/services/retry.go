@@ -0,0 +1,105 @@
+package services
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// maxRetryDelay caps both exponential backoff and server-provided Retry-After delays
+const maxRetryDelay = 30 * time.Second
+
+// doWithRetry sends an HTTP request, retrying with exponential backoff when the
+// response status is 429 or 5xx. A fresh request is built for every attempt via
+// newRequest so that bodies and signatures are never reused. When the server
+// sends a Retry-After header its delay is used instead of the computed backoff.
+//
+// Parameters:
+//   - client: HTTP client used to send the request
+//   - cfg: Configuration providing AIMaxAttempts and AIRetryBaseDelay
+//   - label: Name of the upstream service used in log messages
+//   - newRequest: Builds the request for each attempt
+//
+// Returns the final response (which may still be a non-2xx response after the
+// last attempt) or the error from building or sending the request.
+func doWithRetry(client *http.Client, cfg *config.Config, label string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	maxAttempts := cfg.AIMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	baseDelay := cfg.AIRetryBaseDelay
+	if baseDelay <= 0 {
+		baseDelay = time.Second
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !isRetryableStatus(resp.StatusCode) || attempt >= maxAttempts {
+			return resp, nil
+		}
+
+		delay := retryAfterDelay(resp.Header.Get("Retry-After"))
+		if delay < 0 {
+			delay = backoffDelay(baseDelay, attempt)
+		}
+
+		// Drain the failed response so the connection can be reused
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		slog.Warn("AI API returned a retryable status", "provider", label, "status", resp.StatusCode,
+			"retryIn", delay, "attempt", attempt+1, "maxAttempts", maxAttempts)
+		time.Sleep(delay)
+	}
+}
+
+// isRetryableStatus reports whether the status code indicates a transient failure
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// backoffDelay returns the exponential backoff delay for the given attempt number
+func backoffDelay(baseDelay time.Duration, attempt int) time.Duration {
+	delay := baseDelay << (attempt - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// retryAfterDelay parses a Retry-After header given either in seconds or as an
+// HTTP date. It returns -1 when the header is missing or cannot be parsed.
+func retryAfterDelay(value string) time.Duration {
+	if value == "" {
+		return -1
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return -1
+	}
+
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// Backlog built-in high priority identifier
+const backlogPriorityHigh = 2
+
+// Weights applied to each risk signal when scoring an issue
+var riskSignalWeights = map[string]int{
+	"overdue":                  3,
+	"unassigned_high_priority": 2,
+	"stalled":                  2,
+	"due_soon":                 1,
+}
+
+// maxTopRisks limits how many flagged issues are included in the summary
+const maxTopRisks = 10
+
+// RiskThresholds holds the day thresholds used to detect risk signals
+type RiskThresholds struct {
+	DueSoonDays    int // Open issues due within this many days are flagged as due soon
+	UnassignedDays int // High-priority issues unassigned for longer than this are flagged
+	StalledDays    int // Open issues without updates for longer than this are flagged as stalled
+}
+
+// RiskThresholdsFromConfig builds risk thresholds from configuration, using
+// defaults for values that are not set.
+func RiskThresholdsFromConfig(cfg *config.Config) RiskThresholds {
+	thresholds := RiskThresholds{DueSoonDays: 3, UnassignedDays: 3, StalledDays: 14}
+	if cfg.RiskDueSoonDays > 0 {
+		thresholds.DueSoonDays = cfg.RiskDueSoonDays
+	}
+	if cfg.RiskUnassignedDays > 0 {
+		thresholds.UnassignedDays = cfg.RiskUnassignedDays
+	}
+	if cfg.RiskStalledDays > 0 {
+		thresholds.StalledDays = cfg.RiskStalledDays
+	}
+	return thresholds
+}
+
+// ComputeRiskSignals flags open issues that are overdue, due soon, high priority
+// but left unassigned, or stalled without updates, and scores them.
+//
+// Parameters:
+//   - issues: Backlog issue objects as returned by the get_issues tool
+//   - thresholds: Day thresholds for each signal
+//   - now: Reference time used for all date comparisons
+//
+// Returns a summary with per-signal counts and the highest scoring issues.
+func ComputeRiskSignals(issues []interface{}, thresholds RiskThresholds, now time.Time) *models.RiskSummary {
+	summary := &models.RiskSummary{TopRisks: make([]*models.RiskIssue, 0)}
+	flagged := make([]*models.RiskIssue, 0)
+
+	for _, item := range issues {
+		issue, ok := item.(map[string]interface{})
+		if !ok || isClosedIssue(issue) {
+			continue
+		}
+
+		var signals []string
+		if isOverdueIssue(issue, now) {
+			signals = append(signals, "overdue")
+			summary.OverdueIssues++
+		} else if dueDate, ok := parseBacklogTime(issue["dueDate"]); ok && dueDate.Before(now.AddDate(0, 0, thresholds.DueSoonDays)) {
+			signals = append(signals, "due_soon")
+			summary.DueSoonIssues++
+		}
+
+		if isHighPriorityIssue(issue) && issue["assignee"] == nil {
+			if created, ok := parseBacklogTime(issue["created"]); ok && now.Sub(created) > days(thresholds.UnassignedDays) {
+				signals = append(signals, "unassigned_high_priority")
+				summary.UnassignedHighPriority++
+			}
+		}
+
+		if updated, ok := parseBacklogTime(issue["updated"]); ok && now.Sub(updated) > days(thresholds.StalledDays) {
+			signals = append(signals, "stalled")
+			summary.StalledIssues++
+		}
+
+		if len(signals) == 0 {
+			continue
+		}
+
+		risk := &models.RiskIssue{Signals: signals}
+		risk.IssueKey, _ = issue["issueKey"].(string)
+		risk.Summary, _ = issue["summary"].(string)
+		for _, signal := range signals {
+			risk.Score += riskSignalWeights[signal]
+		}
+		summary.TotalScore += risk.Score
+		flagged = append(flagged, risk)
+	}
+
+	sort.SliceStable(flagged, func(i, j int) bool {
+		return flagged[i].Score > flagged[j].Score
+	})
+	if len(flagged) > maxTopRisks {
+		flagged = flagged[:maxTopRisks]
+	}
+	summary.TopRisks = flagged
+	return summary
+}
+
+// isHighPriorityIssue reports whether the issue has the built-in high priority
+func isHighPriorityIssue(issue map[string]interface{}) bool {
+	return matchesBacklogField(issue["priority"], backlogPriorityHigh, "高", "high")
+}
+
+// parseBacklogTime parses a Backlog RFC 3339 timestamp field
+func parseBacklogTime(value interface{}) (time.Time, bool) {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// days converts a number of days into a duration
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// ErrSessionNotFound is returned by a SessionStore when no session exists for the requested ID.
+var ErrSessionNotFound = errors.New("slide session not found")
+
+// SessionStore persists slide generation sessions so that generated decks,
+// narrations, and audio metadata survive backend restarts and can be shared
+// between multiple backend instances.
+type SessionStore interface {
+	// Save creates or replaces the stored record for the session. A record
+	// with a lower Version than the stored one is stale and dropped.
+	Save(record *models.SlideSessionRecord) error
+	// Load returns the stored record for the session ID, or ErrSessionNotFound.
+	Load(id string) (*models.SlideSessionRecord, error)
+	// Delete removes the stored record. Deleting an unknown ID is not an error.
+	Delete(id string) error
+	// List returns all stored records in no particular order.
+	List() ([]*models.SlideSessionRecord, error)
+}
+
+// NewSessionStore creates the session store selected by the SESSION_STORE configuration.
+// Supported values are "memory" (default) and "file".
+func NewSessionStore(cfg *config.Config) (SessionStore, error) {
+	switch cfg.SessionStore {
+	case "file":
+		return NewFileSessionStore(cfg.SessionStoreDir)
+	case "memory", "":
+		return NewMemorySessionStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported session store: %s", cfg.SessionStore)
+	}
+}
+
+// MemorySessionStore keeps session records in process memory.
+// Records are lost when the process exits.
+type MemorySessionStore struct {
+	records map[string]*models.SlideSessionRecord
+	mutex   sync.RWMutex
+}
+
+// NewMemorySessionStore creates an empty in-memory session store.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		records: make(map[string]*models.SlideSessionRecord),
+	}
+}
+
+func (s *MemorySessionStore) Save(record *models.SlideSessionRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if stored, exists := s.records[record.ID]; exists && record.Version < stored.Version {
+		return nil
+	}
+	s.records[record.ID] = record
+	return nil
+}
+
+func (s *MemorySessionStore) Load(id string) (*models.SlideSessionRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	record, exists := s.records[id]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+	return record, nil
+}
+
+func (s *MemorySessionStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.records, id)
+	return nil
+}
+
+func (s *MemorySessionStore) List() ([]*models.SlideSessionRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	records := make([]*models.SlideSessionRecord, 0, len(s.records))
+	for _, record := range s.records {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// FileSessionStore persists each session record as a JSON file in a directory.
+// Point several backend instances at a shared volume to share sessions between them.
+type FileSessionStore struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileSessionStore creates a file-backed session store rooted at dir,
+// creating the directory if it does not exist.
+func NewFileSessionStore(dir string) (*FileSessionStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("session store directory not configured")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create session store directory: %w", err)
+	}
+	return &FileSessionStore{dir: dir}, nil
+}
+
+func (s *FileSessionStore) Save(record *models.SlideSessionRecord) error {
+	path, err := s.pathFor(record.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Snapshots may be saved out of order by concurrent writers; keep the
+	// newest. An unreadable stored record is simply replaced.
+	if stored, err := s.Load(record.ID); err == nil && record.Version < stored.Version {
+		return nil
+	}
+
+	// Write to a temporary file first so readers never observe a partial record
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to persist session: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSessionStore) Load(id string) (*models.SlideSessionRecord, error) {
+	path, err := s.pathFor(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var record models.SlideSessionRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	return &record, nil
+}
+
+func (s *FileSessionStore) Delete(id string) error {
+	path, err := s.pathFor(id)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSessionStore) List() ([]*models.SlideSessionRecord, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	records := make([]*models.SlideSessionRecord, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		record, err := s.Load(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			slog.Warn("Skipping unreadable session file", "file", entry.Name(), "error", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// pathFor returns the file path for a session ID, rejecting IDs that could
+// escape the store directory.
+func (s *FileSessionStore) pathFor(id string) (string, error) {
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("invalid session ID: %q", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
@@ -3,11 +3,16 @@
 package services
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"intelligent-presenter-backend/internal/models"
@@ -23,6 +28,12 @@ type SlideService struct {
 	mcpService        *MCPService          // MCP service for Backlog data access
 	bedrockService    *BedrockService      // AWS Bedrock service (custom implementation)
 	bedrockSDKService *BedrockSDKService   // AWS Bedrock service (SDK implementation)
+	geminiService     *GeminiService       // Google Gemini service
+	anthropicService  *AnthropicService    // Anthropic API service (direct, non-Bedrock)
+	audioLimiter      *ConcurrencyLimiter  // Global cap on concurrent audio synthesis
+	slideCache        *slideCache          // Generated slides reused while project data is unchanged
+
+	unknownProviderOnce sync.Once // Warns about an unrecognized AIProvider only once
 }
 
 // NewSlideService creates a new instance of SlideService with the provided configuration.
@@ -35,7 +46,7 @@ func NewSlideService(cfg *config.Config) *SlideService {
 		if sdkService, err := NewBedrockSDKService(cfg); err == nil {
 			bedrockSDKService = sdkService
 		} else {
-			fmt.Printf("Failed to create Bedrock SDK service, falling back to custom implementation: %v\n", err)
+			slog.Warn("Failed to create Bedrock SDK service, falling back to custom implementation", "error", err)
 		}
 	}
 
@@ -44,9 +55,29 @@ func NewSlideService(cfg *config.Config) *SlideService {
 		mcpService:     NewMCPService(cfg),
 		bedrockService: NewBedrockService(cfg),
 		bedrockSDKService: bedrockSDKService,
+		geminiService:     NewGeminiService(cfg),
+		anthropicService:  NewAnthropicService(cfg),
+		audioLimiter:      NewConcurrencyLimiter(cfg.AudioMaxConcurrency),
+		slideCache:        newSlideCache(cfg.SlideCacheTTL),
 	}
 }
 
+// SetCacheClock replaces the time source that decides when cached slides
+// expire, so that expiry can be tested without waiting for the TTL to pass.
+//
+// Parameters:
+//   - now: Function returning the current time
+func (s *SlideService) SetCacheClock(now func() time.Time) {
+	s.slideCache.setClock(now)
+}
+
+// BeginRun marks the start of a generation run for the token so that data that
+// rarely changes, such as the Backlog space, is fetched once per run. The
+// returned function ends the run.
+func (s *SlideService) BeginRun(backlogToken string) (end func()) {
+	return s.mcpService.BeginRun(backlogToken)
+}
+
 // GenerateSlideContent creates a complete slide with both markdown and HTML content
 // for the specified project, theme, and language. This is the main entry point
 // for slide generation and includes data retrieval, AI content generation,
@@ -55,38 +86,127 @@ func NewSlideService(cfg *config.Config) *SlideService {
 // Parameters:
 //   - projectID: The Backlog project identifier
 //   - theme: The slide theme (e.g., project_overview, progress, etc.)
-//   - language: Target language for content generation ("ja" or "en")
+//   - language: Target language for content generation, one of SupportedLanguages
 //   - backlogToken: Authentication token for Backlog API access
 //
 // Returns:
 //   - *models.SlideContent: Complete slide with markdown and HTML content
 //   - error: Any error that occurred during generation
 func (s *SlideService) GenerateSlideContent(projectID string, theme models.SlideTheme, language, backlogToken string) (*models.SlideContent, error) {
-	// Get project data based on theme
-	projectData, err := s.getProjectDataForTheme(projectID, theme, backlogToken)
+	return s.GenerateSlideContentWithOptions(projectID, theme, language, backlogToken, GenerationOptions{})
+}
+
+// GenerateSlideContentWithOptions is GenerateSlideContent with per-generation
+// options. Fragments of the slide markdown are passed to opts.OnDelta while the
+// AI provider generates it; they are only delivered when streaming is enabled
+// and supported by the provider, and the returned slide is always complete and
+// authoritative. A slide previously generated from identical project data is
+// returned from the cache, without streaming, unless opts.BypassCache is set.
+func (s *SlideService) GenerateSlideContentWithOptions(projectID string, theme models.SlideTheme, language, backlogToken string, opts GenerationOptions) (*models.SlideContent, error) {
+	// Get project data based on theme, preferring data prefetched for the deck
+	projectData, prefetched := opts.Prefetched.get(theme)
+	if !prefetched {
+		var err error
+		projectData, err = s.getProjectDataForTheme(projectID, theme, backlogToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project data: %w", err)
+		}
+	}
+	return s.generateSlideFromData(projectID, theme, language, projectData, opts)
+}
+
+// GeneratePortfolioSlideContent creates a portfolio comparison slide that
+// compares the progress of several projects side by side, with a chart of each
+// project's issues appended below the generated markdown.
+//
+// Parameters:
+//   - projectIDs: Backlog project identifiers, in display order; at most MaxPortfolioProjects
+//   - language: Target language for content generation, one of SupportedLanguages
+//   - backlogToken: Authentication token for Backlog API access
+//
+// Returns:
+//   - *models.SlideContent: Complete slide with markdown content
+//   - error: Any error that occurred during generation
+func (s *SlideService) GeneratePortfolioSlideContent(projectIDs []string, language, backlogToken string) (*models.SlideContent, error) {
+	return s.GeneratePortfolioSlideContentWithOptions(projectIDs, language, backlogToken, GenerationOptions{})
+}
+
+// GeneratePortfolioSlideContentWithOptions is GeneratePortfolioSlideContent
+// with per-generation options. Portfolio data is always fetched on demand, as
+// it depends on the projects compared, so opts.Prefetched is ignored.
+func (s *SlideService) GeneratePortfolioSlideContentWithOptions(projectIDs []string, language, backlogToken string, opts GenerationOptions) (*models.SlideContent, error) {
+	projectData, err := s.getPortfolioData(projectIDs, backlogToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio data: %w", err)
+	}
+	return s.generateSlideFromData(strings.Join(projectIDs, ","), models.ThemePortfolioComparison, language, projectData, opts)
+}
+
+// generateSlideFromData generates a slide from fetched project data, reusing a
+// cached slide for identical data. projectID scopes the cache entry.
+func (s *SlideService) generateSlideFromData(projectID string, theme models.SlideTheme, language string, projectData map[string]interface{}, opts GenerationOptions) (*models.SlideContent, error) {
+	cacheKey, err := SlideCacheKey(projectID, opts.cacheTheme(theme), language, opts.temperature(), projectData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project data: %w", err)
+		slog.Warn("Failed to fingerprint project data, skipping slide cache", "theme", theme, "error", err)
+	}
+	if cacheKey != "" && !opts.BypassCache {
+		if cached, hit := s.slideCache.get(cacheKey); hit {
+			slog.Debug("Using cached slide", "theme", theme, "projectID", projectID)
+			// No tokens are spent on a cached slide
+			cached.TokensUsed = 0
+			return cached, nil
+		}
 	}
 
+	// Keep the computed burndown out of the prompt; it is appended as a chart below
+	burndown, _ := projectData["burndown"].(*models.BurndownSeries)
+	delete(projectData, "burndown")
+
 	// Generate markdown content using OpenAI
-	markdown, title, err := s.generateMarkdownContent(projectData, theme, language)
+	markdown, title, tokens, err := s.generateMarkdownContent(projectData, theme, language, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate markdown: %w", err)
 	}
 
+	if burndown != nil {
+		if withChart, err := appendChartConfig(markdown, BurndownChartConfig(burndown, language)); err == nil {
+			markdown = withChart
+		} else {
+			slog.Warn("Failed to add burndown chart", "error", err)
+		}
+	}
+	if projects, ok := projectData["projects"].([]*models.PortfolioProject); ok && len(projects) > 0 {
+		if withChart, err := appendChartConfig(markdown, PortfolioChartConfig(projects, language)); err == nil {
+			markdown = withChart
+		} else {
+			slog.Warn("Failed to add portfolio chart", "error", err)
+		}
+	}
+
 	// // Generate HTML from markdown using LLM
 	// html, err := s.generateHTMLFromMarkdown(markdown, title, language)
 	// if err != nil {
 	// 	return nil, fmt.Errorf("failed to generate HTML: %w", err)
 	// }
 
-	return &models.SlideContent{
+	limitations, _ := projectData["limitations"].([]string)
+
+	slide := &models.SlideContent{
 		Theme:       theme,
 		Title:       title,
 		Markdown:    markdown,
+		PlainText:   StripMarkdown(markdown),
+		ContentHash: ContentHash(title, markdown),
+		Limitations: limitations,
+		DiagramAltTexts: DiagramAltTexts(markdown, language),
 		// HTML:        html,
+		TokensUsed:  tokens,
 		GeneratedAt: time.Now(),
-	}, nil
+	}
+	if cacheKey != "" {
+		s.slideCache.put(cacheKey, slide)
+	}
+	return slide, nil
 }
 
 // GenerateSlideNarration creates spoken narration text for a slide
@@ -95,14 +215,20 @@ func (s *SlideService) GenerateSlideContent(projectID string, theme models.Slide
 //
 // Parameters:
 //   - slide: The slide content to generate narration for
-//   - language: Target language for narration ("ja" or "en")
+//   - language: Target language for narration, one of SupportedLanguages
 //
 // Returns:
 //   - *models.SlideNarration: Generated narration with timing information
 //   - error: Any error that occurred during generation
 func (s *SlideService) GenerateSlideNarration(slide *models.SlideContent, language string) (*models.SlideNarration, error) {
+	return s.GenerateSlideNarrationWithOptions(slide, language, GenerationOptions{})
+}
+
+// GenerateSlideNarrationWithOptions is GenerateSlideNarration with
+// per-generation options. Narration is never streamed, so opts.OnDelta is ignored.
+func (s *SlideService) GenerateSlideNarrationWithOptions(slide *models.SlideContent, language string, opts GenerationOptions) (*models.SlideNarration, error) {
 	// Generate narration text using OpenAI
-	narrationText, err := s.generateNarrationText(slide.Markdown, slide.Title, language)
+	narrationText, tokens, err := s.generateNarrationText(slide.Markdown, slide.Title, language, opts.temperature())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate narration: %w", err)
 	}
@@ -111,12 +237,21 @@ func (s *SlideService) GenerateSlideNarration(slide *models.SlideContent, langua
 		SlideIndex: slide.Index,
 		Text:       narrationText,
 		Language:   language,
+		TokensUsed: tokens,
 	}, nil
 }
 
 func (s *SlideService) GenerateSlideAudio(narration *models.SlideNarration) (*models.SlideAudio, error) {
+	if s.config.DisableAudio {
+		return nil, ErrAudioDisabled
+	}
+
+	// Respect the global audio concurrency cap shared by all sessions
+	s.audioLimiter.Acquire()
+	defer s.audioLimiter.Release()
+
 	// Use MCP Speech service to synthesize audio
-	audioURL, err := s.mcpService.SynthesizeSpeech(narration.Text, narration.Language, "")
+	audioURL, err := s.mcpService.SynthesizeSpeech(narration.Text, narration.Language, "", narration.Speed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to synthesize speech: %w", err)
 	}
@@ -129,55 +264,68 @@ func (s *SlideService) GenerateSlideAudio(narration *models.SlideNarration) (*mo
 	}
 	duration := (wordCount * 60) / 150 // seconds
 
-	return &models.SlideAudio{
+	audio := &models.SlideAudio{
 		SlideIndex: narration.SlideIndex,
 		AudioURL:   audioURL,
 		Duration:   duration,
-	}, nil
+	}
+	if narration.TransitionPause > 0 {
+		audio.AdvanceAfter = duration + narration.TransitionPause
+	}
+	return audio, nil
 }
 
 func (s *SlideService) getProjectDataForTheme(projectID string, theme models.SlideTheme, backlogToken string) (map[string]interface{}, error) {
 	data := make(map[string]interface{})
-	fmt.Printf("Getting project data for theme: %s, projectID: %s\n", theme, projectID)
+	slog.Debug("Getting project data", "theme", theme, "projectID", projectID)
 
 	switch theme {
 	case models.ThemeProjectOverview:
-		fmt.Printf("Fetching project overview...\n")
+		slog.Debug("Fetching project overview", "theme", theme)
 		overview, err := s.mcpService.GetProjectOverview(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project overview: %v\n", err)
+			slog.Error("Failed to get project overview", "theme", theme, "error", err)
 			return nil, err
 		}
 		data["overview"] = overview
-		fmt.Printf("Project overview fetched successfully\n")
+		slog.Debug("Project overview fetched successfully", "theme", theme)
 
 	case models.ThemeProjectProgress:
-		fmt.Printf("Fetching project progress...\n")
+		slog.Debug("Fetching project progress", "theme", theme)
 		progress, err := s.mcpService.GetProjectProgress(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project progress: %v\n", err)
+			slog.Error("Failed to get project progress", "theme", theme, "error", err)
 			return nil, err
 		}
+		// The burndown is rendered as a chart by the server rather than the LLM
+		if progressData, ok := progress.(map[string]interface{}); ok {
+			if burndown, exists := progressData["burndown"]; exists {
+				data["burndown"] = burndown
+				delete(progressData, "burndown")
+			}
+		}
 		data["progress"] = progress
-		fmt.Printf("Project progress fetched successfully\n")
+		slog.Debug("Project progress fetched successfully", "theme", theme)
 
 	case models.ThemeIssueManagement:
-		fmt.Printf("Fetching project issues...\n")
+		slog.Debug("Fetching project issues", "theme", theme)
 		issues, err := s.mcpService.GetProjectIssues(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project issues: %v\n", err)
+			slog.Error("Failed to get project issues", "theme", theme, "error", err)
 			return nil, err
 		}
 		data["issues"] = issues
-		fmt.Printf("Project issues fetched successfully\n")
+		slog.Debug("Project issues fetched successfully", "theme", theme)
 
 	case models.ThemeTeamCollaboration:
-		fmt.Printf("Fetching project team...\n")
-		team, err := s.mcpService.GetProjectTeam(projectID, backlogToken)
+		slog.Debug("Fetching project team", "theme", theme)
+		err := fetchDataSource(data, "team", "Team", func() (interface{}, error) {
+			return s.mcpService.GetProjectTeam(projectID, backlogToken)
+		})
 		if err != nil {
-			fmt.Printf("Failed to get project team: %v\n", err)
+			slog.Warn("Failed to get project team", "theme", theme, "error", err)
 			// For team collaboration, use fallback data when API fails
-			fmt.Printf("Using fallback team data for team collaboration slide\n")
+			slog.Info("Using fallback team data for team collaboration slide")
 			data["team"] = map[string]interface{}{
 				"users": []map[string]interface{}{
 					{"name": "プロジェクトメンバー", "role": "開発者"},
@@ -185,143 +333,196 @@ func (s *SlideService) getProjectDataForTheme(projectID string, theme models.Sli
 				"fallback": true,
 				"error": "API access limited - using sample data",
 			}
-		} else {
-			data["team"] = team
 		}
-		fmt.Printf("Project team data prepared successfully\n")
+		slog.Debug("Project team data prepared successfully", "theme", theme)
 
 	case models.ThemeRiskAnalysis:
-		fmt.Printf("Fetching project risks...\n")
+		slog.Debug("Fetching project risks", "theme", theme)
 		risks, err := s.mcpService.GetProjectRisks(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project risks: %v\n", err)
+			slog.Error("Failed to get project risks", "theme", theme, "error", err)
 			return nil, err
 		}
+		// Hoist the computed risk signals to the top level so they sort ahead
+		// of the raw issue lists and survive prompt data truncation
+		if riskData, ok := risks.(map[string]interface{}); ok {
+			if signals, exists := riskData["riskSignals"]; exists {
+				data["riskSignals"] = signals
+				delete(riskData, "riskSignals")
+			}
+		}
 		data["risks"] = risks
-		fmt.Printf("Project risks fetched successfully\n")
+		slog.Debug("Project risks fetched successfully", "theme", theme)
 
 	case models.ThemeDocumentManagement:
-		fmt.Printf("Fetching project documents...\n")
+		slog.Debug("Fetching project documents", "theme", theme)
 		// Get Wiki and document information
 		overview, err := s.mcpService.GetProjectOverview(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project overview for documents: %v\n", err)
+			slog.Error("Failed to get project overview for documents", "theme", theme, "error", err)
 			return nil, err
 		}
 		data["overview"] = overview
 		data["focus"] = "documents"
-		fmt.Printf("Project documents fetched successfully\n")
+		slog.Debug("Project documents fetched successfully", "theme", theme)
 
 	case models.ThemeCodebaseActivity:
-		fmt.Printf("Fetching project codebase activity...\n")
+		slog.Debug("Fetching project codebase activity", "theme", theme)
 		// Get Git repository and development activity information
 		overview, err := s.mcpService.GetProjectOverview(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project overview for codebase: %v\n", err)
+			slog.Error("Failed to get project overview for codebase", "theme", theme, "error", err)
 			return nil, err
 		}
 		data["overview"] = overview
 		data["focus"] = "codebase"
-		fmt.Printf("Project codebase activity fetched successfully\n")
+
+		// Git data is supplementary; without Git permission the slide notes the limitation
+		err = fetchDataSource(data, "git", "Git", func() (interface{}, error) {
+			return s.mcpService.GetProjectCodebase(projectID, backlogToken)
+		})
+		if err != nil {
+			slog.Warn("Failed to get git data for codebase", "theme", theme, "error", err)
+		}
+		slog.Debug("Project codebase activity fetched successfully", "theme", theme)
 
 	case models.ThemeNotifications:
-		fmt.Printf("Fetching project notifications...\n")
+		slog.Debug("Fetching project notifications", "theme", theme)
 		// Get notification and communication information
 		overview, err := s.mcpService.GetProjectOverview(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project overview for notifications: %v\n", err)
+			slog.Error("Failed to get project overview for notifications", "theme", theme, "error", err)
 			return nil, err
 		}
 		data["overview"] = overview
 		data["focus"] = "notifications"
-		fmt.Printf("Project notifications fetched successfully\n")
+
+		// Watcher counts are supplementary engagement data, so failures are non-critical
+		watchers, err := s.mcpService.GetIssueWatchers(projectID, backlogToken)
+		if err != nil {
+			slog.Warn("Failed to get issue watchers for notifications", "theme", theme, "error", err)
+		} else {
+			data["watchers"] = watchers
+		}
+		slog.Debug("Project notifications fetched successfully", "theme", theme)
 
 	case models.ThemePredictiveAnalysis:
-		fmt.Printf("Fetching project data for predictive analysis...\n")
+		slog.Debug("Fetching project data for predictive analysis", "theme", theme)
 		// Get project progress and issues for predictive analysis
 		progress, err := s.mcpService.GetProjectProgress(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project progress for prediction: %v\n", err)
+			slog.Error("Failed to get project progress for prediction", "theme", theme, "error", err)
 			return nil, err
 		}
 		issues, err2 := s.mcpService.GetProjectIssues(projectID, backlogToken)
 		if err2 != nil {
-			fmt.Printf("Failed to get project issues for prediction: %v\n", err2)
+			slog.Error("Failed to get project issues for prediction", "theme", theme, "error", err2)
 			return nil, err2
 		}
 		data["progress"] = progress
 		data["issues"] = issues
 		data["focus"] = "prediction"
-		fmt.Printf("Project data for predictive analysis fetched successfully\n")
+		slog.Debug("Project data for predictive analysis fetched successfully", "theme", theme)
 
 	case models.ThemeSummaryPlan:
-		fmt.Printf("Fetching comprehensive project data for summary...\n")
+		slog.Debug("Fetching comprehensive project data for summary", "theme", theme)
 		// Get comprehensive data for summary and planning
 		overview, err := s.mcpService.GetProjectOverview(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get project overview for summary: %v\n", err)
+			slog.Error("Failed to get project overview for summary", "theme", theme, "error", err)
 			return nil, err
 		}
 		progress, err2 := s.mcpService.GetProjectProgress(projectID, backlogToken)
 		if err2 != nil {
-			fmt.Printf("Failed to get project progress for summary: %v\n", err2)
+			slog.Warn("Failed to get project progress for summary", "theme", theme, "error", err2)
 			// Non-critical, continue with overview only
 			progress = nil
 		}
 		data["overview"] = overview
 		data["progress"] = progress
 		data["focus"] = "summary"
-		fmt.Printf("Comprehensive project data for summary fetched successfully\n")
+
+		// Recent discussion adds context to the summary but is not required
+		comments, err := s.mcpService.GetRecentComments(projectID, backlogToken)
+		if err != nil {
+			slog.Warn("Failed to get recent comments for summary", "theme", theme, "error", err)
+		} else {
+			data["comments"] = comments
+		}
+		slog.Debug("Comprehensive project data for summary fetched successfully", "theme", theme)
+
+	case models.ThemePeriodComparison:
+		slog.Debug("Fetching period comparison", "theme", theme)
+		comparison, err := s.mcpService.GetPeriodComparison(projectID, backlogToken, time.Now())
+		if err != nil {
+			slog.Error("Failed to get period comparison", "theme", theme, "error", err)
+			return nil, err
+		}
+		data["comparison"] = comparison
+		slog.Debug("Period comparison fetched successfully", "theme", theme)
+
+	case models.ThemeSpaceOverview:
+		slog.Debug("Fetching space overview", "theme", theme)
+		// Space-level data; the project ID does not narrow it
+		overview, err := s.mcpService.GetSpaceOverview(backlogToken)
+		if err != nil {
+			slog.Error("Failed to get space overview", "theme", theme, "error", err)
+			return nil, err
+		}
+		for key, value := range overview {
+			data[key] = value
+		}
+
+		// Disk usage and licence are administrator-only, so without access the
+		// slide notes the limitation instead of failing
+		if err := fetchDataSource(data, "diskUsage", "Disk usage", func() (interface{}, error) {
+			return s.mcpService.GetSpaceDiskUsage(backlogToken)
+		}); err != nil {
+			slog.Warn("Failed to get disk usage for space overview", "theme", theme, "error", err)
+		}
+		if err := fetchDataSource(data, "licence", "Licence", func() (interface{}, error) {
+			return s.mcpService.GetSpaceLicence(backlogToken)
+		}); err != nil {
+			slog.Warn("Failed to get licence for space overview", "theme", theme, "error", err)
+		}
+		slog.Debug("Space overview fetched successfully", "theme", theme)
+
+	case models.ThemePortfolioComparison:
+		// Without further projects the comparison covers the deck's project alone;
+		// GeneratePortfolioSlideContent compares several
+		return s.getPortfolioData([]string{projectID}, backlogToken)
 
 	default:
-		fmt.Printf("Using default theme, fetching project overview...\n")
+		slog.Debug("Using default theme, fetching project overview", "theme", theme)
 		// For other themes, get general project data
 		overview, err := s.mcpService.GetProjectOverview(projectID, backlogToken)
 		if err != nil {
-			fmt.Printf("Failed to get default project overview: %v\n", err)
+			slog.Error("Failed to get default project overview", "theme", theme, "error", err)
 			return nil, err
 		}
 		data["overview"] = overview
-		fmt.Printf("Default project overview fetched successfully\n")
+		slog.Debug("Default project overview fetched successfully", "theme", theme)
 	}
 
-	fmt.Printf("Project data collection completed for theme: %s\n", theme)
+	slog.Debug("Project data collection completed", "theme", theme)
 	return data, nil
 }
 
-func (s *SlideService) generateMarkdownContent(projectData map[string]interface{}, theme models.SlideTheme, language string) (string, string, error) {
-	prompt := s.buildPromptForTheme(projectData, theme, language)
+func (s *SlideService) generateMarkdownContent(projectData map[string]interface{}, theme models.SlideTheme, language string, opts GenerationOptions) (string, string, int, error) {
+	if err := s.CheckPromptDataSize(projectData); err != nil {
+		return "", "", 0, err
+	}
+	prompt := s.BuildPromptForThemePart(projectData, theme, language, opts.Part, opts.Parts)
 
 	// Call AI API based on provider
-	var response string
-	var err error
-	
-	fmt.Printf("Using AI provider: %s\n", s.config.AIProvider)
-	
-	switch s.config.AIProvider {
-	case "bedrock":
-		response, err = s.callBedrock(prompt)
-		// Auto-fallback to OpenAI if Bedrock fails
-		if err != nil {
-			fmt.Printf("Bedrock API failed: %v, falling back to OpenAI\n", err)
-			response, err = s.callOpenAI(prompt)
-			if err != nil {
-				fmt.Printf("OpenAI fallback also failed: %v\n", err)
-				return "", "", err
-			}
-			fmt.Printf("OpenAI fallback successful\n")
-		}
-	case "openai":
-		response, err = s.callOpenAI(prompt)
-	default:
-		// Default to OpenAI if not specified
-		response, err = s.callOpenAI(prompt)
-	}
+	slog.Debug("Generating slide content", "provider", s.config.AIProvider, "theme", theme)
 	
+	response, err := s.callAIProviderStream(prompt, opts.temperature(), opts.OnDelta)
 	if err != nil {
-		fmt.Printf("AI API call failed: %v\n", err)
-		return "", "", err
+		slog.Error("AI API call failed", "provider", s.config.AIProvider, "error", err)
+		return "", "", 0, err
 	}
+	tokens := EstimateTokens(prompt) + EstimateTokens(response)
 
 	// Define theme-specific default titles
 	themeDefaultTitles := map[models.SlideTheme]string{
@@ -335,6 +536,9 @@ func (s *SlideService) generateMarkdownContent(projectData map[string]interface{
 		models.ThemeNotifications:       "通知管理",
 		models.ThemePredictiveAnalysis:  "予測分析",
 		models.ThemeSummaryPlan:         "総括と計画",
+		models.ThemePeriodComparison:    "期間比較",
+		models.ThemeSpaceOverview:       "スペース概要",
+		models.ThemePortfolioComparison: "ポートフォリオ比較",
 	}
 
 	themeDefaultTitlesEN := map[models.SlideTheme]string{
@@ -348,6 +552,9 @@ func (s *SlideService) generateMarkdownContent(projectData map[string]interface{
 		models.ThemeNotifications:       "Notifications",
 		models.ThemePredictiveAnalysis:  "Predictive Analysis",
 		models.ThemeSummaryPlan:         "Summary & Plan",
+		models.ThemePeriodComparison:    "Period Comparison",
+		models.ThemeSpaceOverview:       "Space Overview",
+		models.ThemePortfolioComparison: "Portfolio Comparison",
 	}
 
 	// Extract title and markdown from response
@@ -374,20 +581,114 @@ func (s *SlideService) generateMarkdownContent(projectData map[string]interface{
 	// Look for title in first line if it starts with #
 	if len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
 		extractedTitle := strings.TrimSpace(strings.TrimPrefix(lines[0], "#"))
-		fmt.Printf("AI generated title: '%s' for theme: %s\n", extractedTitle, theme)
+		slog.Debug("AI generated title", "title", extractedTitle, "theme", theme)
 		title = extractedTitle
 	} else {
-		fmt.Printf("No # title found, using default title: '%s' for theme: %s\n", title, theme)
-		fmt.Printf("First line of AI response: '%s'\n", lines[0])
+		slog.Debug("No # title found, using default title", "title", title, "theme", theme, "firstLine", lines[0])
 	}
 
-	return markdown, title, nil
+	return markdown, title, tokens, nil
 }
 
-func (s *SlideService) generateNarrationText(markdown, title, language string) (string, error) {
-	var prompt string
+func (s *SlideService) generateNarrationText(markdown, title, language string, temperature float64) (string, int, error) {
+	prompt := s.BuildNarrationPrompt(markdown, language)
+
+	// Use the same AI provider as for content generation with fallback
+	response, err := s.callAIProvider(prompt, temperature)
+	if err != nil {
+		return "", 0, err
+	}
+	return response, EstimateTokens(prompt) + EstimateTokens(response), nil
+}
+
+// callAIProviderStream streams the response to onDelta when streaming is enabled
+// and the provider supports it, and otherwise calls callAIProvider. A failed
+// stream is retried once without streaming, so onDelta may have received a
+// partial response that the returned text supersedes.
+func (s *SlideService) callAIProviderStream(prompt string, temperature float64, onDelta TextDeltaFunc) (string, error) {
+	if onDelta == nil || !s.config.AIStreaming {
+		return s.callAIProvider(prompt, temperature)
+	}
+
+	var providerName string
+	var stream func(string, float64, TextDeltaFunc) (string, error)
+	switch {
+	case s.config.AIProvider == "openai" || s.config.AIProvider == "":
+		providerName, stream = "OpenAI", s.callOpenAIStream
+	case s.config.AIProvider == "bedrock" && s.bedrockSDKService != nil &&
+		BedrockModelFamily(s.config.BedrockModelID) == BedrockFamilyClaudeMessages:
+		providerName, stream = "Bedrock", s.bedrockSDKService.GenerateTextStream
+	default:
+		return s.callAIProvider(prompt, temperature)
+	}
+
+	response, err := stream(prompt, temperature, onDelta)
+	if err == nil {
+		return response, nil
+	}
+	slog.Warn("AI streaming failed, retrying without streaming", "provider", providerName, "error", err)
+	return s.callAIProvider(prompt, temperature)
+}
+
+// callAIProvider sends the prompt to the configured AI provider. When Bedrock,
+// Gemini, or Anthropic fails and AI fallback is enabled, the prompt is retried with OpenAI.
+func (s *SlideService) callAIProvider(prompt string, temperature float64) (string, error) {
+	var providerName string
+	var callProvider func(string, float64) (string, error)
+
+	switch s.config.AIProvider {
+	case "bedrock":
+		providerName, callProvider = "Bedrock", s.callBedrock
+	case "gemini":
+		providerName, callProvider = "Gemini", s.callGemini
+	case "anthropic":
+		providerName, callProvider = "Anthropic", s.callAnthropic
+	case "openai", "":
+		// Default to OpenAI if not specified
+		return s.callOpenAI(prompt, temperature)
+	default:
+		// Unknown providers are rejected at startup in production mode; elsewhere
+		// warn once so a typo does not silently switch generation to OpenAI
+		s.unknownProviderOnce.Do(func() {
+			slog.Warn("Unknown AI provider, defaulting to OpenAI", "provider", s.config.AIProvider)
+		})
+		return s.callOpenAI(prompt, temperature)
+	}
+
+	response, err := callProvider(prompt, temperature)
+	if err == nil {
+		return response, nil
+	}
+	if !s.config.AIFallbackEnabled {
+		slog.Error("AI API failed (OpenAI fallback disabled)", "provider", providerName, "error", err)
+		return "", err
+	}
+
+	// Auto-fallback to OpenAI if the primary provider fails
+	slog.Warn("AI API failed, falling back to OpenAI", "provider", providerName, "error", err)
+	response, err = s.callOpenAI(prompt, temperature)
+	if err != nil {
+		slog.Error("OpenAI fallback also failed", "error", err)
+		return "", err
+	}
+	slog.Info("OpenAI fallback successful")
+	return response, nil
+}
+
+// BuildNarrationPrompt creates the AI prompt used to generate spoken narration
+// for a slide. The target narration length is taken from the per-language
+// configuration so that reading speed differences between languages are respected.
+//
+// Parameters:
+//   - markdown: The slide markdown content to narrate
+//   - language: Target language for narration, one of SupportedLanguages
+//
+// Returns the complete prompt text for the AI provider.
+func (s *SlideService) BuildNarrationPrompt(markdown, language string) string {
+	target := s.narrationTarget(language)
+
 	if language == "ja" {
-		prompt = fmt.Sprintf(`
+		return fmt.Sprintf(`
 以下のMarkdown形式のスライド内容に基づいて、日本語で自然な口頭発表用のナレーションを生成してください。
 
 スライド内容:
@@ -396,79 +697,96 @@ func (s *SlideService) generateNarrationText(markdown, title, language string) (
 ナレーションの要件:
 1. 聞き手に分かりやすい自然な日本語
 2. プロフェッショナルなプレゼンテーション調
-3. 2-3分程度で読める長さ
+3. %s分程度で読める長さ
 4. スライドの内容を効果的に説明
 
-ナレーション:`, markdown)
-	} else {
-		prompt = fmt.Sprintf(`
-Generate natural narration text in English for the following slide content:
+ナレーション:`, markdown, target)
+	}
+
+	// Other languages use the English template, naming the target language
+	return fmt.Sprintf(`
+Generate natural narration text in %s for the following slide content:
 
 Slide Content:
 %s
 
 Requirements:
 1. Natural, professional presentation style
-2. 2-3 minutes reading time
+2. %s minutes reading time
 3. Clear explanation of slide content
+%s
+Narration:`, languageDisplayName(language), markdown, target, targetLanguageInstruction(language))
+}
 
-Narration:`, markdown)
+// narrationTarget returns the configured narration length in minutes for the
+// given language, falling back to "2-3" when no target is configured.
+func (s *SlideService) narrationTarget(language string) string {
+	if target, exists := s.config.NarrationTargets[language]; exists && target != "" {
+		return target
 	}
+	return "2-3"
+}
 
-	// Use the same AI provider as for content generation with fallback
-	switch s.config.AIProvider {
-	case "bedrock":
-		response, err := s.callBedrock(prompt)
-		// Auto-fallback to OpenAI if Bedrock fails
-		if err != nil {
-			fmt.Printf("Bedrock narration API failed: %v, falling back to OpenAI\n", err)
-			response, err = s.callOpenAI(prompt)
-			if err != nil {
-				fmt.Printf("OpenAI narration fallback also failed: %v\n", err)
-				return "", err
-			}
-			fmt.Printf("OpenAI narration fallback successful\n")
-		}
-		return response, err
-	case "openai":
-		return s.callOpenAI(prompt)
-	default:
-		return s.callOpenAI(prompt)
-	}
+// BuildPromptForTheme creates the AI prompt used to generate slide markdown for a
+// theme from the collected project data. When a prompt audience is configured,
+// an instruction to adapt the wording for that audience is added.
+//
+// Parameters:
+//   - projectData: Project data collected for the theme
+//   - theme: The slide theme to generate
+//   - language: Target language for the slide, one of SupportedLanguages
+//
+// Returns the complete prompt text for the AI provider.
+func (s *SlideService) BuildPromptForTheme(projectData map[string]interface{}, theme models.SlideTheme, language string) string {
+	return s.BuildPromptForThemePart(projectData, theme, language, 1, 1)
 }
 
-func (s *SlideService) buildPromptForTheme(projectData map[string]interface{}, theme models.SlideTheme, language string) string {
+// BuildPromptForThemePart creates the AI prompt for one slide of a theme split
+// across several slides, asking the model to cover only that slide's share of
+// the theme. With a single part it is the same as BuildPromptForTheme.
+//
+// Parameters:
+//   - projectData: Project data collected for the theme
+//   - theme: The slide theme to generate
+//   - language: Target language for the slide, one of SupportedLanguages
+//   - part: 1-based part of the theme this slide covers
+//   - parts: Number of slides the theme is split across
+//
+// Returns the complete prompt text for the AI provider.
+func (s *SlideService) BuildPromptForThemePart(projectData map[string]interface{}, theme models.SlideTheme, language string, part, parts int) string {
 	// Limit the data size to prevent context overflow
-	dataJSON, _ := json.Marshal(projectData)
-	if len(dataJSON) > 8000 { // Limit to ~8KB to keep under token limits
-		dataJSON = dataJSON[:8000]
-		dataJSON = append(dataJSON, []byte("...}")...) // Close JSON properly
-	}
+	dataJSON := s.promptDataJSON(projectData)
 
 	themePrompts := map[models.SlideTheme]string{
 		models.ThemeProjectOverview: `プロジェクトの概要と基本情報のスライドを生成してください。プロジェクト名、目的、期間、チーム構成などを含めてください。`,
-		models.ThemeProjectProgress: `プロジェクトの進捗状況のスライドを生成してください。完了率、マイルストーン、現在の状況などを含めてください。`,
+		models.ThemeProjectProgress: `プロジェクトの進捗状況のスライドを生成してください。完了率、マイルストーン、現在の状況などを含めてください。バーンダウンチャートは自動で追加されるため、作成しないでください。`,
 		models.ThemeIssueManagement: `プロジェクトの課題管理状況のスライドを生成してください。未解決の課題、優先度分布、進行中のタスクなどを含めてください。`,
-		models.ThemeRiskAnalysis: `プロジェクトのリスク分析のスライドを生成してください。潜在的なリスク、遅延要因、対策などを含めてください。`,
+		models.ThemeRiskAnalysis: `プロジェクトのリスク分析のスライドを生成してください。潜在的なリスク、遅延要因、対策などを含めてください。riskSignalsの集計値（期限超過、期限間近、未割り当ての高優先度課題、停滞課題）を根拠として使用してください。`,
 		models.ThemeTeamCollaboration: `チームの協力状況のスライドを生成してください。メンバー構成、役割分担、コミュニケーション状況などを含めてください。`,
 		models.ThemeDocumentManagement: `プロジェクトの文書管理状況のスライドを生成してください。文書数、更新頻度、アクセス状況、知識共有などを含めてください。`,
-		models.ThemeCodebaseActivity: `プロジェクトの開発活動のスライドを生成してください。コミット数、開発者活動量、コード品質指標、リリース頻度などを含めてください。`,
+		models.ThemeCodebaseActivity: `プロジェクトの開発活動のスライドを生成してください。コミット数、開発者活動量、コード品質指標、リリース頻度、git.pullRequestsのリポジトリ別・合計のプルリクエスト数（未対応・マージ済み・クローズ）などを含めてください。`,
 		models.ThemeNotifications: `プロジェクトのコミュニケーション状況のスライドを生成してください。通知数、応答率、情報伝達効率、重要通知の処理状況などを含めてください。`,
 		models.ThemePredictiveAnalysis: `プロジェクトの予測分析のスライドを生成してください。完了予測日、リスク発生確率、必要リソース予測、目標達成可能性などを含めてください。`,
 		models.ThemeSummaryPlan: `プロジェクトの総括・計画のスライドを生成してください。主要成果、KPI達成状況、残課題、次期計画の要点などを含めてください。`,
+		models.ThemePeriodComparison: `今週と先週の比較スライドを生成してください。comparisonのcurrent（今週）とprevious（先週）の課題統計、およびdelta（増減）を使用し、完了数、未対応数、期限超過数、完了率の変化を示してください。`,
+		models.ThemePortfolioComparison: `複数プロジェクトの進捗を比較するポートフォリオスライドを生成してください。projectsの各プロジェクトについてstatsの完了率、完了・処理中・未対応の課題数、期限超過数を表で並べて比較し、進捗の良いプロジェクトと注意が必要なプロジェクトを示してください。プロジェクト別の課題数のグラフは自動で追加されるため、作成しないでください。`,
+		models.ThemeSpaceOverview: `Backlogスペース全体の概要スライドを生成してください。スペース名、ユーザー数とロール構成（usersByRole）、プロジェクト数、ディスク使用量（バイト値をGBなどに換算し、diskUsage.capacityに対する使用率）、ライセンスの上限と契約期間などを含めてください。`,
 	}
 
 	themePromptsEN := map[models.SlideTheme]string{
 		models.ThemeProjectOverview: "Generate a slide for project overview and basic information. Include project name, purpose, duration, team composition, etc.",
-		models.ThemeProjectProgress: "Generate a slide for project progress status. Include completion rate, milestones, current status, etc.",
+		models.ThemeProjectProgress: "Generate a slide for project progress status. Include completion rate, milestones, current status, etc. A burndown chart is appended automatically, so do not create one.",
 		models.ThemeIssueManagement: "Generate a slide for project issue management status. Include unresolved issues, priority distribution, ongoing tasks, etc.",
-		models.ThemeRiskAnalysis: "Generate a slide for project risk analysis. Include potential risks, delay factors, countermeasures, etc.",
+		models.ThemeRiskAnalysis: "Generate a slide for project risk analysis. Include potential risks, delay factors, countermeasures, etc. Ground the analysis in the riskSignals counts (overdue, due soon, unassigned high priority, stalled issues).",
 		models.ThemeTeamCollaboration: "Generate a slide for team collaboration status. Include member composition, role assignments, communication status, etc.",
 		models.ThemeDocumentManagement: "Generate a slide for project document management status. Include document count, update frequency, access status, knowledge sharing, etc.",
-		models.ThemeCodebaseActivity: "Generate a slide for project development activity. Include commit count, developer activity levels, code quality metrics, release frequency, etc.",
+		models.ThemeCodebaseActivity: "Generate a slide for project development activity. Include commit count, developer activity levels, code quality metrics, release frequency, open, merged, and closed pull requests per repository and in total from git.pullRequests, etc.",
 		models.ThemeNotifications: "Generate a slide for project communication status. Include notification count, response rate, information transmission efficiency, important notification processing status, etc.",
 		models.ThemePredictiveAnalysis: "Generate a slide for project predictive analysis. Include predicted completion date, risk occurrence probability, required resource forecast, goal achievement feasibility, etc.",
 		models.ThemeSummaryPlan: "Generate a slide for project summary and planning. Include key achievements, KPI achievement status, remaining issues, key points of next plan, etc.",
+		models.ThemePeriodComparison: "Generate a week-over-week comparison slide. Use the issue statistics in comparison.current (this week) and comparison.previous (last week) and the changes in comparison.delta to show how completed, open, and overdue issues and the completion rate changed.",
+		models.ThemePortfolioComparison: "Generate a portfolio slide comparing the progress of several projects. For each project in projects, compare the completion rate, the completed, in-progress, and open issue counts, and the overdue count from its stats side by side in a table, and point out which projects are on track and which need attention. A chart of each project's issues is appended automatically, so do not create one.",
+		models.ThemeSpaceOverview: "Generate an overview slide for the whole Backlog space. Include the space name, the user count and role mix (usersByRole), the project count, disk usage (convert bytes to GB or similar and show usage against diskUsage.capacity), and licence limits and contract period.",
 	}
 
 	var themePrompt string
@@ -479,6 +797,9 @@ func (s *SlideService) buildPromptForTheme(projectData map[string]interface{}, t
 		if !exists {
 			themePrompt = "プロジェクト関連のスライドを生成してください。"
 		}
+		if parts > 1 {
+			themePrompt += fmt.Sprintf("このテーマは%d枚のスライドに分割されています。これは%d枚目のスライドです。このスライドが担当する部分の要点のみを扱い、他のスライドと内容を重複させないでください。", parts, part)
+		}
 		return fmt.Sprintf(`
 以下のBacklogプロジェクトデータを基に、%s
 
@@ -496,13 +817,17 @@ func (s *SlideService) buildPromptForTheme(projectData map[string]interface{}, t
 6. 数値や結果を強調
 7. Mermaidを使用する場合は ` + "```" + `mermaid で始めること
 8. **重要**: 冗長な説明は避け、核心的な情報のみ記載
-
-スライド内容:`, themePrompt, string(dataJSON))
+%s%s
+スライド内容:`, themePrompt, string(dataJSON), s.audienceInstruction(language), s.limitationInstruction(projectData, language))
 	} else {
+		// Other languages use the English template with a target-language instruction
 		themePrompt, exists = themePromptsEN[theme]
 		if !exists {
 			themePrompt = "Generate a slide about the project."
 		}
+		if parts > 1 {
+			themePrompt += fmt.Sprintf(" This theme is split across %d slides and this is slide %d of %d. Cover only this slide's share of the points and do not repeat the content of the other slides.", parts, part, parts)
+		}
 		return fmt.Sprintf(`
 Generate a slide based on the following Backlog project data for theme: %s
 
@@ -522,61 +847,53 @@ Requirements:
 8. **Important**: Avoid verbose explanations, focus on core information only
 9. **Important**: Only generate one slide
 10. **Important**: Use a compact layout
-
-Slide Content:`, themePrompt, string(dataJSON))
+%s%s%s
+Slide Content:`, themePrompt, string(dataJSON), s.audienceInstruction(language), s.limitationInstruction(projectData, language), targetLanguageInstruction(language))
 	}
 }
 
-func (s *SlideService) callOpenAI(prompt string) (string, error) {
-	if s.config.OpenAIAPIKey == "" {
-		return "", fmt.Errorf("OpenAI API key not configured")
+// limitationInstruction returns the prompt requirement asking the model to note
+// data sources that could not be accessed, or an empty string when all data
+// was available. It is numbered after the optional audience requirement.
+func (s *SlideService) limitationInstruction(projectData map[string]interface{}, language string) string {
+	limitations, _ := projectData["limitations"].([]string)
+	if len(limitations) == 0 {
+		return ""
 	}
 
-	requestBody := map[string]interface{}{
-		"model": "gpt-3.5-turbo",
-		"messages": []map[string]string{
-			{
-				"role":    "user",
-				"content": prompt,
-			},
-		},
-		"max_tokens":  800, // Reduced to prevent context overflow
-		"temperature": 0.7,
+	number := 11
+	if language == "ja" {
+		number = 9
 	}
-
-	jsonData, err := json.Marshal(requestBody)
-	if err != nil {
-		fmt.Printf("OpenAI request marshal error: %v\n", err)
-		return "", err
+	if s.audienceInstruction(language) != "" {
+		number++
 	}
-
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonData))
-	if err != nil {
-		fmt.Printf("OpenAI request creation error: %v\n", err)
-		return "", err
+	if language == "ja" {
+		return fmt.Sprintf("%d. **データの制約**: %s。利用できないデータは推測せず、スライドに制約として簡潔に明記すること\n", number, strings.Join(limitations, "、"))
 	}
+	return fmt.Sprintf("%d. **Data limitations**: %s. Do not guess the missing data; briefly note the limitation on the slide\n", number, strings.Join(limitations, "; "))
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+s.config.OpenAIAPIKey)
+// audienceInstruction returns the prompt requirement describing the configured
+// audience, or an empty string when no audience is configured.
+func (s *SlideService) audienceInstruction(language string) string {
+	audience := strings.TrimSpace(s.config.PromptAudience)
+	if audience == "" {
+		return ""
+	}
+	if language == "ja" {
+		return fmt.Sprintf("9. **対象読者**: %s（読者に合わせた言葉遣いと説明の深さにすること）\n", audience)
+	}
+	return fmt.Sprintf("11. **Audience**: %s (adapt wording and depth of explanation to this audience)\n", audience)
+}
 
-	fmt.Printf("Making OpenAI API call...\n")
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+func (s *SlideService) callOpenAI(prompt string, temperature float64) (string, error) {
+	resp, err := s.postOpenAI(context.Background(), prompt, temperature, false)
 	if err != nil {
-		fmt.Printf("OpenAI API call error: %v\n", err)
 		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		fmt.Printf("OpenAI API error - Status: %d\n", resp.StatusCode)
-		// Read error response
-		var errorBytes bytes.Buffer
-		errorBytes.ReadFrom(resp.Body)
-		fmt.Printf("OpenAI error response: %s\n", errorBytes.String())
-		return "", fmt.Errorf("OpenAI API returned status %d", resp.StatusCode)
-	}
-
 	var response struct {
 		Choices []struct {
 			Message struct {
@@ -590,38 +907,198 @@ func (s *SlideService) callOpenAI(prompt string) (string, error) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		fmt.Printf("OpenAI response decode error: %v\n", err)
+		slog.Error("OpenAI response decode error", "error", err)
 		return "", err
 	}
 
 	if response.Error.Message != "" {
-		fmt.Printf("OpenAI API error: %s (%s)\n", response.Error.Message, response.Error.Type)
+		slog.Error("OpenAI API error", "message", response.Error.Message, "type", response.Error.Type)
 		return "", fmt.Errorf("OpenAI API error: %s", response.Error.Message)
 	}
 
 	if len(response.Choices) == 0 {
-		fmt.Printf("OpenAI returned no choices\n")
+		slog.Error("OpenAI returned no choices")
 		return "", fmt.Errorf("no response from OpenAI")
 	}
 
-	fmt.Printf("OpenAI API call successful\n")
+	slog.Debug("OpenAI API call successful")
 	return response.Choices[0].Message.Content, nil
 }
 
-func (s *SlideService) callBedrock(prompt string) (string, error) {
+// callOpenAIStream requests a streamed chat completion and passes each
+// fragment of the response to onDelta as it arrives. The stream is bounded by
+// AI_STREAM_TIMEOUT rather than the buffered request timeout, and abandoned
+// when it sends no data for AI_STREAM_IDLE_TIMEOUT.
+//
+// Returns the full completion, or an error if the stream fails or ends early.
+func (s *SlideService) callOpenAIStream(prompt string, temperature float64, onDelta TextDeltaFunc) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.aiStreamTimeout())
+	defer cancel()
+	resp, err := s.postOpenAI(ctx, prompt, temperature, true)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	idleTimeout := s.aiStreamIdleTimeout()
+	idle := time.AfterFunc(idleTimeout, cancel)
+	defer idle.Stop()
+
+	var decoder OpenAIStreamDecoder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for !decoder.Done() && scanner.Scan() {
+		idle.Reset(idleTimeout)
+		delta, err := decoder.Decode(scanner.Bytes())
+		if err != nil {
+			return "", err
+		}
+		if delta != "" {
+			onDelta(delta)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return "", fmt.Errorf("OpenAI stream sent no data for %v", idleTimeout)
+		}
+		return "", fmt.Errorf("failed to read OpenAI stream: %w", err)
+	}
+	if !decoder.Done() {
+		return "", fmt.Errorf("OpenAI stream ended before completion")
+	}
+
+	slog.Debug("OpenAI streaming call successful")
+	return decoder.Text(), nil
+}
+
+// aiStreamTimeout returns the deadline of a whole streamed response,
+// defaulting to 5 minutes when no positive value is configured
+func (s *SlideService) aiStreamTimeout() time.Duration {
+	if s.config.AIStreamTimeout > 0 {
+		return s.config.AIStreamTimeout
+	}
+	return 5 * time.Minute
+}
+
+// aiStreamIdleTimeout returns how long a stream may go without sending data,
+// defaulting to 30 seconds when no positive value is configured
+func (s *SlideService) aiStreamIdleTimeout() time.Duration {
+	if s.config.AIStreamIdleTimeout > 0 {
+		return s.config.AIStreamIdleTimeout
+	}
+	return 30 * time.Second
+}
+
+// postOpenAI sends a chat completion request for the prompt, retrying
+// transient failures, and returns the successful response for the caller to
+// read and close. With stream set, the response is a server-sent event stream
+// bounded only by ctx; otherwise the request times out after 30 seconds.
+func (s *SlideService) postOpenAI(ctx context.Context, prompt string, temperature float64, stream bool) (*http.Response, error) {
+	if s.config.OpenAIAPIKey == "" {
+		return nil, fmt.Errorf("OpenAI API key not configured")
+	}
+
+	requestBody := map[string]interface{}{
+		"model": s.openAIModel(),
+		"messages": []map[string]string{
+			{
+				"role":    "user",
+				"content": prompt,
+			},
+		},
+		"max_tokens":  s.openAIMaxTokens(),
+		"temperature": temperature,
+	}
+	if stream {
+		requestBody["stream"] = true
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		slog.Error("OpenAI request marshal error", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("Making OpenAI API call", "model", s.openAIModel(), "stream", stream)
+	client := &http.Client{Timeout: 30 * time.Second}
+	if stream {
+		client = &http.Client{}
+	}
+	resp, err := doWithRetry(client, s.config, "OpenAI", func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", s.openAIBaseURL()+"/chat/completions", bytes.NewBuffer(jsonData))
+		if err != nil {
+			slog.Error("OpenAI request creation error", "error", err)
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+s.config.OpenAIAPIKey)
+		return req, nil
+	})
+	if err != nil {
+		slog.Error("OpenAI API call error", "error", err)
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		defer resp.Body.Close()
+		// The error body can echo the request, so it is only logged at debug level
+		var errorBytes bytes.Buffer
+		errorBytes.ReadFrom(resp.Body)
+		slog.Error("OpenAI API error", "status", resp.StatusCode)
+		slog.Debug("OpenAI error response", "body", errorBytes.String())
+		return nil, fmt.Errorf("OpenAI API returned status %d", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// openAIModel returns the configured OpenAI model, defaulting to gpt-3.5-turbo
+func (s *SlideService) openAIModel() string {
+	if s.config.OpenAIModel != "" {
+		return s.config.OpenAIModel
+	}
+	return "gpt-3.5-turbo"
+}
+
+// openAIMaxTokens returns the configured completion token budget, defaulting to
+// 800 to prevent context overflow when no positive value is configured
+func (s *SlideService) openAIMaxTokens() int {
+	if s.config.OpenAIMaxTokens > 0 {
+		return s.config.OpenAIMaxTokens
+	}
+	return 800
+}
+
+// openAIBaseURL returns the configured OpenAI API base URL without a trailing slash
+func (s *SlideService) openAIBaseURL() string {
+	if s.config.OpenAIBaseURL != "" {
+		return strings.TrimRight(s.config.OpenAIBaseURL, "/")
+	}
+	return "https://api.openai.com/v1"
+}
+
+func (s *SlideService) callBedrock(prompt string, temperature float64) (string, error) {
 	if s.config.AWSAccessKeyID == "" || s.config.AWSSecretAccessKey == "" {
 		return "", fmt.Errorf("AWS credentials not configured")
 	}
 
 	// Prefer AWS SDK service if available
 	if s.bedrockSDKService != nil {
-		fmt.Printf("Using AWS SDK for Bedrock API call\n")
-		return s.bedrockSDKService.GenerateText(prompt)
+		slog.Debug("Using AWS SDK for Bedrock API call")
+		return s.bedrockSDKService.GenerateTextWithTemperature(prompt, temperature)
 	}
 
 	// Fallback to custom implementation
-	fmt.Printf("Using custom implementation for Bedrock API call\n")
-	return s.bedrockService.GenerateText(prompt)
+	slog.Debug("Using custom implementation for Bedrock API call")
+	return s.bedrockService.GenerateTextWithTemperature(prompt, temperature)
+}
+
+func (s *SlideService) callGemini(prompt string, temperature float64) (string, error) {
+	return s.geminiService.GenerateTextWithTemperature(prompt, temperature)
+}
+
+func (s *SlideService) callAnthropic(prompt string, temperature float64) (string, error) {
+	return s.anthropicService.GenerateTextWithTemperature(prompt, temperature)
 }
 
 // generateHTMLFromMarkdown converts markdown content to presentation-ready HTML
@@ -681,23 +1158,5 @@ HTML:`, markdown)
 	}
 
 	// Use the same AI provider as for content generation
-	switch s.config.AIProvider {
-	case "bedrock":
-		response, err := s.callBedrock(prompt)
-		// Auto-fallback to OpenAI if Bedrock fails
-		if err != nil {
-			fmt.Printf("Bedrock HTML generation failed: %v, falling back to OpenAI\n", err)
-			response, err = s.callOpenAI(prompt)
-			if err != nil {
-				fmt.Printf("OpenAI HTML generation fallback also failed: %v\n", err)
-				return "", err
-			}
-			fmt.Printf("OpenAI HTML generation fallback successful\n")
-		}
-		return response, err
-	case "openai":
-		return s.callOpenAI(prompt)
-	default:
-		return s.callOpenAI(prompt)
-	}
-}
\ No newline at end of file
+	return s.callAIProvider(prompt, DefaultTemperature)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// slideCache remembers generated slides so that regenerating a theme for an
+// unchanged project does not repeat the AI call. Entries expire after ttl; a
+// zero ttl disables the cache.
+type slideCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]slideCacheEntry
+}
+
+// slideCacheEntry is a cached slide and when it stops being served
+type slideCacheEntry struct {
+	slide     models.SlideContent
+	expiresAt time.Time
+}
+
+// newSlideCache creates a slide cache whose entries live for ttl
+func newSlideCache(ttl time.Duration) *slideCache {
+	return &slideCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]slideCacheEntry),
+	}
+}
+
+// SlideCacheKey identifies a slide generation by its project, theme, language,
+// sampling temperature, and a fingerprint of the project data sent to the AI
+// provider, so that any change in the data produces a different key.
+//
+// Parameters:
+//   - projectID: The Backlog project identifier
+//   - theme: The slide theme
+//   - language: Target language of the slide
+//   - temperature: Sampling temperature of the generation
+//   - projectData: Project data fetched for the theme
+//
+// Returns the hex-encoded key, or an error if the data cannot be fingerprinted.
+func SlideCacheKey(projectID string, theme models.SlideTheme, language string, temperature float64, projectData map[string]interface{}) (string, error) {
+	// encoding/json sorts map keys, so equal data always encodes identically
+	fingerprint, err := json.Marshal(projectData)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{projectID, string(theme), language, strconv.FormatFloat(temperature, 'g', -1, 64)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	hash.Write(fingerprint)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// setClock replaces the time source used for expiry
+func (c *slideCache) setClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// get returns a copy of the slide cached under key, if it has not expired
+func (c *slideCache) get(key string) (*models.SlideContent, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	if c.now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	slide := entry.slide
+	return &slide, true
+}
+
+// put caches a copy of the slide under key, dropping expired entries
+func (c *slideCache) put(key string, slide *models.SlideContent) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = slideCacheEntry{slide: *slide, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"sync"
+)
+
+// spaceCache holds Backlog space metadata per access token while at least one
+// generation run for that token is active. The space rarely changes, so every
+// slide of a run can share a single get_space call.
+type spaceCache struct {
+	mu      sync.Mutex
+	runs    map[string]int
+	entries map[string]*spaceEntry
+}
+
+// spaceEntry is the cached space of one token. Its mutex makes concurrent
+// callers wait for the first fetch instead of fetching in parallel.
+type spaceEntry struct {
+	mu      sync.Mutex
+	fetched bool
+	space   interface{}
+}
+
+func newSpaceCache() *spaceCache {
+	return &spaceCache{
+		runs:    make(map[string]int),
+		entries: make(map[string]*spaceEntry),
+	}
+}
+
+// BeginRun starts caching space metadata for the token until the returned
+// function is called. Runs for the same token share the cache, which is
+// dropped when the last of them ends.
+func (s *MCPService) BeginRun(backlogToken string) (end func()) {
+	c := s.spaceCache
+	c.mu.Lock()
+	c.runs[backlogToken]++
+	if c.entries[backlogToken] == nil {
+		c.entries[backlogToken] = &spaceEntry{}
+	}
+	c.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.runs[backlogToken]--
+			if c.runs[backlogToken] <= 0 {
+				delete(c.runs, backlogToken)
+				delete(c.entries, backlogToken)
+			}
+		})
+	}
+}
+
+// getSpace returns the space metadata, served from the cache when a run is
+// active for the token. Failed fetches are not cached so later slides retry.
+func (s *MCPService) getSpace(backlogToken string) (interface{}, error) {
+	s.spaceCache.mu.Lock()
+	entry := s.spaceCache.entries[backlogToken]
+	s.spaceCache.mu.Unlock()
+	if entry == nil {
+		return s.callBacklogToolHTTP("get_space", map[string]interface{}{}, backlogToken)
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.fetched {
+		return entry.space, nil
+	}
+	space, err := s.callBacklogToolHTTP("get_space", map[string]interface{}{}, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+	entry.space, entry.fetched = space, true
+	return space, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// backlogRoleNames names Backlog user roleType values
+var backlogRoleNames = map[int]string{
+	1: "administrator",
+	2: "normal",
+	3: "reporter",
+	4: "viewer",
+	5: "guestReporter",
+	6: "guestViewer",
+}
+
+// GetSpaceOverview collects space-wide data for the space overview slide: the
+// space itself, the number of users by role, and the number of projects the
+// user can see. Users are summarized rather than listed so that large spaces
+// fit in the prompt.
+//
+// Parameters:
+//   - backlogToken: OAuth access token for Backlog API
+//
+// Returns the overview data; the space is required, users and projects are best-effort.
+func (s *MCPService) GetSpaceOverview(backlogToken string) (map[string]interface{}, error) {
+	backlogToken = strings.TrimSpace(backlogToken)
+	if backlogToken == "" {
+		return nil, ErrEmptyBacklogToken
+	}
+
+	var (
+		wg                              sync.WaitGroup
+		space, users, projects          interface{}
+		spaceErr, usersErr, projectsErr error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		space, spaceErr = s.getSpace(backlogToken)
+	}()
+	go func() {
+		defer wg.Done()
+		users, usersErr = s.callBacklogToolHTTP("get_users", map[string]interface{}{}, backlogToken)
+	}()
+	go func() {
+		defer wg.Done()
+		projects, projectsErr = s.GetProjects(backlogToken)
+	}()
+	wg.Wait()
+
+	if spaceErr != nil {
+		return nil, fmt.Errorf("failed to get space: %w", spaceErr)
+	}
+	overview := map[string]interface{}{"space": space}
+	if userList, ok := users.([]interface{}); ok && usersErr == nil {
+		overview["userCount"] = len(userList)
+		overview["usersByRole"] = CountUsersByRole(userList)
+	}
+	if projectList, ok := projects.([]interface{}); ok && projectsErr == nil {
+		overview["projectCount"] = len(projectList)
+	}
+	return overview, nil
+}
+
+// GetSpaceDiskUsage returns the storage used by the space in bytes, in total
+// and per project. Backlog only allows administrators to read it.
+func (s *MCPService) GetSpaceDiskUsage(backlogToken string) (interface{}, error) {
+	return s.callBacklogToolHTTP("get_disk_usage", map[string]interface{}{}, backlogToken)
+}
+
+// GetSpaceLicence returns the licence of the space, including its user and
+// storage limits and contract period
+func (s *MCPService) GetSpaceLicence(backlogToken string) (interface{}, error) {
+	return s.callBacklogToolHTTP("get_licence", map[string]interface{}{}, backlogToken)
+}
+
+// CountUsersByRole counts Backlog users per role name. Users with an unknown
+// roleType are counted as "other".
+func CountUsersByRole(users []interface{}) map[string]int {
+	counts := make(map[string]int)
+	for _, item := range users {
+		user, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		roleType, _ := user["roleType"].(float64)
+		name, known := backlogRoleNames[int(roleType)]
+		if !known {
+			name = "other"
+		}
+		counts[name]++
+	}
+	return counts
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"crypto/md5"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -13,6 +15,13 @@ import (
 	"intelligent-presenter-backend/pkg/config"
 )
 
+// ErrAudioDisabled is returned by speech synthesis when DISABLE_AUDIO is set
+var ErrAudioDisabled = errors.New("audio synthesis is disabled")
+
+// ErrSpeechNotConfigured is returned by speech synthesis when no speech server
+// is configured and silent placeholder audio is not allowed
+var ErrSpeechNotConfigured = errors.New("speech server not configured: set MCP_SPEECH_URL")
+
 type SpeechService struct {
 	config    *config.Config
 	cacheDir  string
@@ -22,8 +31,9 @@ type SpeechService struct {
 type SpeechRequest struct {
 	Text      string `json:"text"`
 	Language  string `json:"language"`
-	Voice     string `json:"voice"`
-	Streaming bool   `json:"streaming"`
+	Voice     string  `json:"voice"`
+	Speed     float64 `json:"speed,omitempty"`
+	Streaming bool    `json:"streaming"`
 }
 
 type SpeechResponse struct {
@@ -31,14 +41,19 @@ type SpeechResponse struct {
 	Duration  time.Duration `json:"duration"`
 	Language  string        `json:"language"`
 	Voice     string        `json:"voice"`
+	Engine    string        `json:"engine,omitempty"`
 	CacheHit  bool          `json:"cacheHit"`
 	RequestID string        `json:"requestId"`
 }
 
 func NewSpeechService(cfg *config.Config) *SpeechService {
-	cacheDir := "./cache/audio"
-	os.MkdirAll(cacheDir, 0755)
-	
+	// The directory is created only when placeholder audio is written, so
+	// services that never write audio leave no directory behind
+	cacheDir := cfg.AudioCacheDir
+	if cacheDir == "" {
+		cacheDir = config.DefaultAudioCacheDir
+	}
+
 	return &SpeechService{
 		config:   cfg,
 		cacheDir: cacheDir,
@@ -48,9 +63,18 @@ func NewSpeechService(cfg *config.Config) *SpeechService {
 	}
 }
 
-func (s *SpeechService) SynthesizeSpeech(text, language, voice string) (string, error) {
+func (s *SpeechService) SynthesizeSpeech(text, language, voice string, speed float64) (string, error) {
+	if s.config.DisableAudio {
+		return "", ErrAudioDisabled
+	}
+	// Without a speech server there is no real TTS engine; fail rather than
+	// hand out silence unless placeholder audio was explicitly allowed
+	if s.config.MCPSpeechURL == "" && !s.config.AllowSilentTTS {
+		return "", ErrSpeechNotConfigured
+	}
+
 	// Generate cache key
-	cacheKey := s.generateCacheKey(text, language, voice)
+	cacheKey := s.generateCacheKey(text, language, voice, speed)
 	audioFile := filepath.Join(s.cacheDir, cacheKey+".wav")
 	
 	// Check if audio file already exists in cache
@@ -61,18 +85,19 @@ func (s *SpeechService) SynthesizeSpeech(text, language, voice string) (string,
 	
 	// Check if we have a separate speech server running
 	if s.config.MCPSpeechURL != "" {
-		return s.callSpeechServer(text, language, voice, cacheKey)
+		return s.callSpeechServer(text, language, voice, speed, cacheKey)
 	}
 	
-	// Fall back to simple TTS implementation
+	slog.Warn("MCP_SPEECH_URL is not set; writing silent placeholder audio because ALLOW_SILENT_TTS is enabled")
 	return s.generateSimpleTTS(text, language, voice, audioFile, cacheKey)
 }
 
-func (s *SpeechService) callSpeechServer(text, language, voice, cacheKey string) (string, error) {
+func (s *SpeechService) callSpeechServer(text, language, voice string, speed float64, cacheKey string) (string, error) {
 	request := SpeechRequest{
 		Text:      text,
 		Language:  language,
 		Voice:     voice,
+		Speed:     speed,
 		Streaming: false,
 	}
 	
@@ -103,68 +128,34 @@ func (s *SpeechService) callSpeechServer(text, language, voice, cacheKey string)
 	return speechResponse.AudioURL, nil
 }
 
+// generateSimpleTTS writes a silent placeholder WAV of the estimated narration
+// length. It is a debugging aid used only when ALLOW_SILENT_TTS is enabled.
 func (s *SpeechService) generateSimpleTTS(text, language, voice, audioFile, cacheKey string) (string, error) {
-	// Create a simple placeholder audio file
-	// In production, this would use a real TTS engine
-	
-	duration := s.estimateDuration(text)
-	sampleRate := 16000
-	bitsPerSample := 16
-	channels := 1
-	
-	// Calculate file size
-	audioDataSize := int(duration.Seconds()) * sampleRate * bitsPerSample / 8 * channels
-	fileSize := 36 + audioDataSize
-	
-	// Create WAV header
-	header := make([]byte, 44)
-	
-	// RIFF header
-	copy(header[0:4], "RIFF")
-	header[4] = byte(fileSize & 0xff)
-	header[5] = byte((fileSize >> 8) & 0xff)
-	header[6] = byte((fileSize >> 16) & 0xff)
-	header[7] = byte((fileSize >> 24) & 0xff)
-	copy(header[8:12], "WAVE")
-	
-	// fmt subchunk
-	copy(header[12:16], "fmt ")
-	header[16] = 16 // Subchunk1Size for PCM
-	header[20] = 1  // AudioFormat (PCM)
-	header[22] = byte(channels)
-	header[24] = byte(sampleRate & 0xff)
-	header[25] = byte((sampleRate >> 8) & 0xff)
-	
-	// data subchunk
-	copy(header[36:40], "data")
-	header[40] = byte(audioDataSize & 0xff)
-	header[41] = byte((audioDataSize >> 8) & 0xff)
-	header[42] = byte((audioDataSize >> 16) & 0xff)
-	header[43] = byte((audioDataSize >> 24) & 0xff)
-	
-	// Write to file
-	file, err := os.Create(audioFile)
-	if err != nil {
-		return "", fmt.Errorf("failed to create audio file: %w", err)
+	format := WAVFormat{
+		AudioFormat:   1, // PCM
+		Channels:      1,
+		SampleRate:    16000,
+		ByteRate:      16000 * 2,
+		BlockAlign:    2,
+		BitsPerSample: 16,
 	}
-	defer file.Close()
-	
-	// Write header
-	if _, err := file.Write(header); err != nil {
-		return "", fmt.Errorf("failed to write WAV header: %w", err)
+	wav := EncodeWAV(&WAVAudio{Format: format, Data: silence(format, s.estimateDuration(text))})
+
+	if err := os.MkdirAll(s.cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create audio cache directory: %w", err)
 	}
-	
-	// Write silence (zeros) as placeholder audio data
-	silenceData := make([]byte, audioDataSize)
-	if _, err := file.Write(silenceData); err != nil {
-		return "", fmt.Errorf("failed to write audio data: %w", err)
+	if err := os.WriteFile(audioFile, wav, 0644); err != nil {
+		return "", fmt.Errorf("failed to write audio file: %w", err)
 	}
-	
 	return fmt.Sprintf("/api/v1/speech/audio/%s.wav", cacheKey), nil
 }
 
-func (s *SpeechService) generateCacheKey(text, language, voice string) string {
+func (s *SpeechService) generateCacheKey(text, language, voice string, speed float64) string {
 	content := fmt.Sprintf("%s:%s:%s", text, language, voice)
+	// Keep existing cache keys for the default speed
+	if speed != 0 && speed != 1.0 {
+		content = fmt.Sprintf("%s:%g", content, speed)
+	}
 	hash := md5.Sum([]byte(content))
 	return fmt.Sprintf("%x", hash)
 }
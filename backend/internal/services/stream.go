@@ -0,0 +1,123 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TextDeltaFunc receives fragments of model output as they are generated
+type TextDeltaFunc func(delta string)
+
+// claudeStreamEvent is one event of a Claude Messages API response stream
+type claudeStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ClaudeStreamDecoder assembles the chunks of a streamed Claude Messages API
+// response, as delivered by Bedrock's InvokeModelWithResponseStream, into the
+// full completion.
+type ClaudeStreamDecoder struct {
+	text strings.Builder
+}
+
+// Decode parses one stream chunk and returns the text it adds to the
+// completion, which is empty for events that carry no text.
+//
+// Returns an error if the chunk is malformed or reports a model error.
+func (d *ClaudeStreamDecoder) Decode(chunk []byte) (string, error) {
+	var event claudeStreamEvent
+	if err := json.Unmarshal(chunk, &event); err != nil {
+		return "", fmt.Errorf("failed to decode stream chunk: %w", err)
+	}
+
+	switch event.Type {
+	case "content_block_delta":
+		if event.Delta.Type != "text_delta" {
+			return "", nil
+		}
+		d.text.WriteString(event.Delta.Text)
+		return event.Delta.Text, nil
+	case "error":
+		if event.Error != nil {
+			return "", fmt.Errorf("stream error (%s): %s", event.Error.Type, event.Error.Message)
+		}
+		return "", fmt.Errorf("stream error")
+	default:
+		return "", nil
+	}
+}
+
+// Text returns the completion assembled so far
+func (d *ClaudeStreamDecoder) Text() string {
+	return d.text.String()
+}
+
+// openAIStreamChunk is one chunk of an OpenAI chat completion stream
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// OpenAIStreamDecoder assembles the server-sent events of a streamed OpenAI
+// chat completion (`"stream": true`) into the full completion.
+type OpenAIStreamDecoder struct {
+	text strings.Builder
+	done bool
+}
+
+// Decode parses one line of the event stream and returns the text it adds to
+// the completion. Blank lines, comments, and fields other than data carry no
+// text; the final "data: [DONE]" line marks the stream as done.
+//
+// Returns an error if a data line is malformed or reports an API error.
+func (d *OpenAIStreamDecoder) Decode(line []byte) (string, error) {
+	data, ok := strings.CutPrefix(strings.TrimSpace(string(line)), "data:")
+	if !ok {
+		return "", nil
+	}
+	data = strings.TrimSpace(data)
+	if data == "[DONE]" {
+		d.done = true
+		return "", nil
+	}
+
+	var chunk openAIStreamChunk
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return "", fmt.Errorf("failed to decode stream chunk: %w", err)
+	}
+	if chunk.Error != nil {
+		return "", fmt.Errorf("stream error (%s): %s", chunk.Error.Type, chunk.Error.Message)
+	}
+	if len(chunk.Choices) == 0 {
+		return "", nil
+	}
+	delta := chunk.Choices[0].Delta.Content
+	d.text.WriteString(delta)
+	return delta, nil
+}
+
+// Done reports whether the stream's terminating [DONE] event was decoded
+func (d *OpenAIStreamDecoder) Done() bool {
+	return d.done
+}
+
+// Text returns the completion assembled so far
+func (d *OpenAIStreamDecoder) Text() string {
+	return d.text.String()
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// Subtitle formats supported by BuildSubtitles
+const (
+	SubtitleFormatVTT = "vtt" // WebVTT
+	SubtitleFormatSRT = "srt" // SubRip
+)
+
+// SubtitleCue is a single caption shown between Start and End
+type SubtitleCue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// sentenceTerminators end a sentence in the supported narration languages
+const sentenceTerminators = ".!?。！？"
+
+// BuildSubtitleCues sequences the session's narrations into caption cues. Slides
+// play back to back in slide order, so each slide's cues start where the
+// previous slide's audio ended. A narration is split into one cue per sentence
+// and the slide's audio duration is shared between them by text length. Slides
+// without both narration and audio are skipped.
+//
+// Parameters:
+//   - record: Snapshot of the slide session
+//
+// Returns the cues in playback order.
+func BuildSubtitleCues(record *models.SlideSessionRecord) []SubtitleCue {
+	narrations := make(map[int]string, len(record.Narrations))
+	for _, narration := range record.Narrations {
+		narrations[narration.SlideIndex] = narration.Text
+	}
+
+	audioFiles := make([]*models.SlideAudio, 0, len(record.AudioFiles))
+	for _, audio := range record.AudioFiles {
+		if audio.Duration > 0 {
+			audioFiles = append(audioFiles, audio)
+		}
+	}
+	sort.Slice(audioFiles, func(i, j int) bool {
+		return audioFiles[i].SlideIndex < audioFiles[j].SlideIndex
+	})
+
+	cues := make([]SubtitleCue, 0)
+	var offset time.Duration
+	for _, audio := range audioFiles {
+		slideStart := offset
+		slideEnd := offset + time.Duration(audio.Duration)*time.Second
+		offset = slideEnd
+
+		sentences := splitSentences(narrations[audio.SlideIndex])
+		if len(sentences) == 0 {
+			continue
+		}
+
+		totalLength := 0
+		for _, sentence := range sentences {
+			totalLength += utf8.RuneCountInString(sentence)
+		}
+
+		start, spoken := slideStart, 0
+		for i, sentence := range sentences {
+			spoken += utf8.RuneCountInString(sentence)
+			end := slideStart + (slideEnd-slideStart)*time.Duration(spoken)/time.Duration(totalLength)
+			end = end.Truncate(time.Millisecond)
+			if i == len(sentences)-1 {
+				end = slideEnd
+			}
+			cues = append(cues, SubtitleCue{Start: start, End: end, Text: sentence})
+			start = end
+		}
+	}
+	return cues
+}
+
+// BuildSubtitles renders the session's narrations as a subtitle file.
+//
+// Parameters:
+//   - record: Snapshot of the slide session
+//   - format: SubtitleFormatVTT or SubtitleFormatSRT
+//
+// Returns the subtitle file contents, or an error for unsupported formats.
+func BuildSubtitles(record *models.SlideSessionRecord, format string) (string, error) {
+	cues := BuildSubtitleCues(record)
+
+	var b strings.Builder
+	switch format {
+	case SubtitleFormatVTT:
+		b.WriteString("WEBVTT\n")
+		for i, cue := range cues {
+			fmt.Fprintf(&b, "\n%d\n%s --> %s\n%s\n", i+1,
+				formatCueTime(cue.Start, "."), formatCueTime(cue.End, "."), cue.Text)
+		}
+	case SubtitleFormatSRT:
+		for i, cue := range cues {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n", i+1,
+				formatCueTime(cue.Start, ","), formatCueTime(cue.End, ","), cue.Text)
+		}
+	default:
+		return "", fmt.Errorf("unsupported subtitle format %q", format)
+	}
+	return b.String(), nil
+}
+
+// splitSentences splits narration text after each sentence terminator,
+// dropping blank fragments
+func splitSentences(text string) []string {
+	sentences := make([]string, 0)
+	var current strings.Builder
+	flush := func() {
+		if sentence := strings.Join(strings.Fields(current.String()), " "); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		current.Reset()
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		current.WriteRune(r)
+		// ASCII terminators only end a sentence before whitespace, so that
+		// version numbers and decimals such as "1.5" stay in one cue
+		atBoundary := r >= utf8.RuneSelf || i == len(runes)-1 || unicode.IsSpace(runes[i+1])
+		if r == '\n' || (strings.ContainsRune(sentenceTerminators, r) && atBoundary) {
+			flush()
+		}
+	}
+	flush()
+	return sentences
+}
+
+// formatCueTime formats a cue timestamp as HH:MM:SS followed by the
+// millisecond separator of the subtitle format and milliseconds
+func formatCueTime(d time.Duration, millisSeparator string) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		ms/3600000, ms/60000%60, ms/1000%60, millisSeparator, ms%1000)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// Typical narration speaking rates, in words per second as counted by
+// CountWords. Each Japanese or Chinese character counts as one word.
+const (
+	cjkWordsPerSecond   = 6.0
+	otherWordsPerSecond = 2.5
+)
+
+// EstimateSpeechSeconds estimates how long narration text takes to speak.
+//
+// Parameters:
+//   - text: The narration text
+//   - language: Narration language code
+//   - speed: Speech speed multiplier; 0 means normal speed
+//
+// Returns the estimate in whole seconds, at least 1 for non-empty text.
+func EstimateSpeechSeconds(text, language string, speed float64) int {
+	words := CountWords(text)
+	if words == 0 {
+		return 0
+	}
+
+	rate := otherWordsPerSecond
+	if language == "ja" || language == "zh" {
+		rate = cjkWordsPerSecond
+	}
+	if speed > 0 {
+		rate *= speed
+	}
+	return int(math.Max(1, math.Round(float64(words)/rate)))
+}
+
+// BuildTeleprompter pairs each generated slide with its narration in
+// presentation order. Each slide is timed by its audio duration when audio
+// exists, and by a speaking-rate estimate of the narration otherwise.
+//
+// Parameters:
+//   - record: Snapshot of the slide session
+//
+// Returns the teleprompter view of the deck.
+func BuildTeleprompter(record *models.SlideSessionRecord) *models.Teleprompter {
+	narrations := make(map[int]*models.SlideNarration, len(record.Narrations))
+	for _, narration := range record.Narrations {
+		narrations[narration.SlideIndex] = narration
+	}
+	audioFiles := make(map[int]*models.SlideAudio, len(record.AudioFiles))
+	for _, audio := range record.AudioFiles {
+		audioFiles[audio.SlideIndex] = audio
+	}
+
+	slides := append([]*models.SlideContent(nil), record.Slides...)
+	sort.Slice(slides, func(i, j int) bool {
+		return slides[i].Index < slides[j].Index
+	})
+
+	teleprompter := &models.Teleprompter{
+		SlideID:  record.ID,
+		Language: record.Language,
+		Entries:  make([]*models.TeleprompterEntry, 0, len(slides)),
+	}
+	for _, slide := range slides {
+		entry := &models.TeleprompterEntry{
+			Index:        slide.Index,
+			Title:        slide.Title,
+			Theme:        slide.Theme,
+			Lines:        make([]string, 0),
+			StartSeconds: teleprompter.TotalSeconds,
+			TimingSource: models.TimingSourceEstimate,
+		}
+		if narration, exists := narrations[slide.Index]; exists {
+			entry.Narration = narration.Text
+			entry.Lines = splitSentences(narration.Text)
+			entry.DurationSeconds = EstimateSpeechSeconds(narration.Text, narration.Language, narration.Speed)
+		}
+		if audio, exists := audioFiles[slide.Index]; exists && audio.Duration > 0 {
+			entry.DurationSeconds = audio.Duration
+			entry.TimingSource = models.TimingSourceAudio
+		}
+		if narration, exists := narrations[slide.Index]; exists {
+			entry.DurationSeconds += narration.TransitionPause
+		}
+
+		teleprompter.Entries = append(teleprompter.Entries, entry)
+		teleprompter.TotalSeconds += entry.DurationSeconds
+	}
+	return teleprompter
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// ErrInvalidAICredentials is returned when tenant-supplied AI credentials name
+// an unsupported provider or lack the keys the provider needs
+var ErrInvalidAICredentials = errors.New("invalid AI credentials")
+
+// WithAICredentials returns a slide service for one request that calls the AI
+// provider with the tenant's own credentials instead of the configured ones.
+// Backlog access and the audio limiter are shared with s. The slide cache is
+// not, so that slides paid for with one tenant's credentials are never served
+// to another tenant or to requests on the server's credentials. The OpenAI
+// fallback is disabled so that a tenant's failed call is never retried on the
+// server's shared OpenAI key.
+//
+// Parameters:
+//   - creds: Credentials supplied by the tenant
+//
+// Returns the request-scoped service, or an error wrapping ErrInvalidAICredentials.
+func (s *SlideService) WithAICredentials(creds *models.AICredentials) (*SlideService, error) {
+	cfg := *s.config
+	if creds.Provider != "" {
+		cfg.AIProvider = creds.Provider
+	}
+	cfg.AIFallbackEnabled = false
+
+	switch cfg.AIProvider {
+	case "openai", "":
+		if creds.APIKey == "" {
+			return nil, fmt.Errorf("%w: apiKey is required for openai", ErrInvalidAICredentials)
+		}
+		cfg.OpenAIAPIKey = creds.APIKey
+	case "gemini":
+		if creds.APIKey == "" {
+			return nil, fmt.Errorf("%w: apiKey is required for gemini", ErrInvalidAICredentials)
+		}
+		cfg.GeminiAPIKey = creds.APIKey
+	case "anthropic":
+		if creds.APIKey == "" {
+			return nil, fmt.Errorf("%w: apiKey is required for anthropic", ErrInvalidAICredentials)
+		}
+		cfg.AnthropicAPIKey = creds.APIKey
+	case "bedrock":
+		if creds.AWSAccessKeyID == "" || creds.AWSSecretAccessKey == "" {
+			return nil, fmt.Errorf("%w: awsAccessKeyId and awsSecretAccessKey are required for bedrock", ErrInvalidAICredentials)
+		}
+		cfg.AWSAccessKeyID = creds.AWSAccessKeyID
+		cfg.AWSSecretAccessKey = creds.AWSSecretAccessKey
+		cfg.AWSSessionToken = creds.AWSSessionToken
+		if creds.AWSRegion != "" {
+			cfg.AWSRegion = creds.AWSRegion
+		}
+	default:
+		return nil, fmt.Errorf("%w: provider %q is not supported (use openai, bedrock, gemini, or anthropic)", ErrInvalidAICredentials, cfg.AIProvider)
+	}
+
+	var bedrockSDKService *BedrockSDKService
+	if cfg.AIProvider == "bedrock" {
+		if sdkService, err := NewBedrockSDKService(&cfg); err == nil {
+			bedrockSDKService = sdkService
+		} else {
+			slog.Warn("Failed to create Bedrock SDK service for tenant credentials, falling back to custom implementation", "error", err)
+		}
+	}
+
+	return &SlideService{
+		config:            &cfg,
+		mcpService:        s.mcpService,
+		bedrockService:    NewBedrockService(&cfg),
+		bedrockSDKService: bedrockSDKService,
+		geminiService:     NewGeminiService(&cfg),
+		anthropicService:  NewAnthropicService(&cfg),
+		audioLimiter:      s.audioLimiter,
+		slideCache:        newSlideCache(0),
+	}, nil
+}
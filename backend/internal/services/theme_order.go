@@ -0,0 +1,79 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// OrderThemes reorders requested themes to follow a canonical deck flow.
+// Themes are sorted by their position in order; themes missing from order
+// keep their requested order after the ordered ones.
+//
+// Parameters:
+//   - themes: Requested themes in request order
+//   - order: Canonical theme order, e.g. from THEME_ORDER
+//
+// Returns the reordered themes as a new slice.
+func OrderThemes(themes []models.SlideTheme, order []string) []models.SlideTheme {
+	ordered := make([]models.SlideTheme, len(themes))
+	for i, requested := range themeOrderIndexes(themes, order) {
+		ordered[i] = themes[requested]
+	}
+	return ordered
+}
+
+// OrderSlideSpeeds rekeys per-slide speed overrides, given by the index of the
+// requested theme, so that each follows its theme through OrderThemes.
+// Overrides for indexes beyond the requested themes keep their index.
+//
+// Parameters:
+//   - speeds: Per-slide speed overrides keyed by requested slide index
+//   - themes: Requested themes in request order
+//   - order: Canonical theme order passed to OrderThemes
+//
+// Returns the overrides keyed by reordered slide index, or nil without any.
+func OrderSlideSpeeds(speeds map[int]float64, themes []models.SlideTheme, order []string) map[int]float64 {
+	if len(speeds) == 0 {
+		return speeds
+	}
+	moved := make(map[int]int, len(themes))
+	for i, requested := range themeOrderIndexes(themes, order) {
+		moved[requested] = i
+	}
+	ordered := make(map[int]float64, len(speeds))
+	for index, speed := range speeds {
+		if i, exists := moved[index]; exists {
+			index = i
+		}
+		ordered[index] = speed
+	}
+	return ordered
+}
+
+// themeOrderIndexes returns the indexes of the requested themes in deck order
+func themeOrderIndexes(themes []models.SlideTheme, order []string) []int {
+	rank := make(map[models.SlideTheme]int, len(order))
+	for i, theme := range order {
+		theme = strings.TrimSpace(theme)
+		if _, exists := rank[models.SlideTheme(theme)]; !exists && theme != "" {
+			rank[models.SlideTheme(theme)] = i
+		}
+	}
+	position := func(theme models.SlideTheme) int {
+		if i, exists := rank[theme]; exists {
+			return i
+		}
+		return len(order)
+	}
+
+	indexes := make([]int, len(themes))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return position(themes[indexes[i]]) < position(themes[indexes[j]])
+	})
+	return indexes
+}
@@ -0,0 +1,96 @@
+package services
+
+import "intelligent-presenter-backend/internal/models"
+
+// MaxTargetSlides is the largest slide count a deck template may target
+const MaxTargetSlides = 30
+
+// SplitThemes expands requested themes so that the deck aims for targetSlides
+// slides. Slides beyond one per theme are handed out to the themes in request
+// order, and the slides of a split theme are kept next to each other. Themes
+// are never merged, so a target at or below the number of themes keeps one
+// slide per theme.
+//
+// Parameters:
+//   - themes: Requested themes in deck order
+//   - targetSlides: Slide count the deck template aims for
+//
+// Returns the theme of each slide as a new slice.
+func SplitThemes(themes []models.SlideTheme, targetSlides int) []models.SlideTheme {
+	indexes := splitThemeIndexes(themes, targetSlides)
+	split := make([]models.SlideTheme, len(indexes))
+	for i, requested := range indexes {
+		split[i] = themes[requested]
+	}
+	return split
+}
+
+// SplitSlideSpeeds rekeys per-slide speed overrides, given by the index of the
+// requested theme, so that every slide of a split theme gets its theme's
+// override. Overrides for indexes beyond the requested themes are dropped.
+//
+// Parameters:
+//   - speeds: Per-slide speed overrides keyed by requested slide index
+//   - themes: Requested themes in deck order
+//   - targetSlides: Slide count passed to SplitThemes
+//
+// Returns the overrides keyed by split slide index, or nil without any.
+func SplitSlideSpeeds(speeds map[int]float64, themes []models.SlideTheme, targetSlides int) map[int]float64 {
+	if len(speeds) == 0 {
+		return speeds
+	}
+	split := make(map[int]float64, len(speeds))
+	for i, requested := range splitThemeIndexes(themes, targetSlides) {
+		if speed, exists := speeds[requested]; exists {
+			split[i] = speed
+		}
+	}
+	return split
+}
+
+// ThemePart reports which part of its theme the slide at index is. Adjacent
+// slides of the same theme, as produced by SplitThemes, are parts of one split
+// theme.
+//
+// Parameters:
+//   - themes: Theme of each slide in the deck
+//   - index: Position of the slide (0-based)
+//
+// Returns the 1-based part and the number of parts; parts is 1 for a theme
+// that is not split.
+func ThemePart(themes []models.SlideTheme, index int) (part, parts int) {
+	if index < 0 || index >= len(themes) {
+		return 1, 1
+	}
+	first, last := index, index
+	for first > 0 && themes[first-1] == themes[index] {
+		first--
+	}
+	for last < len(themes)-1 && themes[last+1] == themes[index] {
+		last++
+	}
+	return index - first + 1, last - first + 1
+}
+
+// splitThemeIndexes returns, for each slide of the split deck, the index of the
+// requested theme it belongs to
+func splitThemeIndexes(themes []models.SlideTheme, targetSlides int) []int {
+	if targetSlides > MaxTargetSlides {
+		targetSlides = MaxTargetSlides
+	}
+	counts := make([]int, len(themes))
+	for i := range counts {
+		counts[i] = 1
+	}
+	for extra := 0; len(themes) > 0 && extra < targetSlides-len(themes); extra++ {
+		counts[extra%len(themes)]++
+	}
+
+	var indexes []int
+	for i, count := range counts {
+		for j := 0; j < count; j++ {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
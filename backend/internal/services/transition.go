@@ -0,0 +1,30 @@
+package services
+
+import (
+	"strings"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// TransitionPauseSeconds is how long an auto-advancing player holds a slide
+// after its narration ends before moving to the next one
+const TransitionPauseSeconds = 2
+
+// TransitionCueMarker ends narration that carries a transition cue. Speech
+// engines render the ellipsis as a short pause.
+const TransitionCueMarker = "…"
+
+// AppendTransitionCue ends the narration with a transition marker and records
+// the pause to hold before advancing. Narration that already ends with the
+// marker only has its pause recorded.
+//
+// Parameters:
+//   - narration: Narration to update in place
+func AppendTransitionCue(narration *models.SlideNarration) {
+	text := strings.TrimSpace(narration.Text)
+	if !strings.HasSuffix(text, TransitionCueMarker) {
+		text = strings.TrimSpace(text + " " + TransitionCueMarker)
+	}
+	narration.Text = text
+	narration.TransitionPause = TransitionPauseSeconds
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"math"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EstimateTokens approximates the number of tokens in text. Providers report
+// usage in different ways (or not at all), so generation reports use this
+// estimate: roughly four characters per token for ASCII text and one token per
+// character for other scripts such as Japanese.
+func EstimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// EstimateCost approximates the cost in USD of tokens at a blended price per
+// million tokens, rounded to a hundredth of a cent.
+func EstimateCost(tokens int, costPerMillion float64) float64 {
+	return math.Round(float64(tokens)*costPerMillion/1e6*1e4) / 1e4
+}
+
+// CountWords counts the words in plain text. Whitespace separates words in
+// languages such as English, while each Han, Hiragana, or Katakana character is
+// counted as one word since Japanese text does not use spaces. Full-width
+// punctuation such as "、" and "。" also separates words.
+func CountWords(text string) int {
+	count := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			count++
+			inWord = false
+		case unicode.IsSpace(r) || (r >= utf8.RuneSelf && unicode.IsPunct(r)):
+			inWord = false
+		default:
+			if !inWord {
+				count++
+				inWord = true
+			}
+		}
+	}
+	return count
+}
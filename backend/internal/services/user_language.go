@@ -0,0 +1,60 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// LanguageAuto asks for the deck language to be derived from the user's
+// Backlog language setting
+const LanguageAuto = "auto"
+
+// GetMyself returns the Backlog profile of the user owning the access token.
+func (s *MCPService) GetMyself(backlogToken string) (*models.UserInfo, error) {
+	result, err := s.callBacklogToolHTTP("get_myself", map[string]interface{}{}, backlogToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	// Round-trip through JSON to map the generic tool result onto UserInfo
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode current user: %w", err)
+	}
+	var user models.UserInfo
+	if err := json.Unmarshal(raw, &user); err != nil {
+		return nil, fmt.Errorf("unexpected current user format: %w", err)
+	}
+	return &user, nil
+}
+
+// ResolveLanguage returns the deck language for a request. An explicit
+// language is returned unchanged; "auto" is resolved from the Backlog user's
+// language setting, falling back to the configured default language when the
+// user's setting is unset, unsupported, or cannot be fetched.
+func (s *SlideService) ResolveLanguage(language, backlogToken string) string {
+	if language != LanguageAuto {
+		return language
+	}
+
+	user, err := s.mcpService.GetMyself(backlogToken)
+	if err != nil {
+		return s.defaultLanguage()
+	}
+	// Backlog reports languages such as "ja" or "en", or null when unset
+	if lang := strings.ToLower(user.Lang); len(lang) >= 2 && IsSupportedLanguage(lang[:2]) {
+		return lang[:2]
+	}
+	return s.defaultLanguage()
+}
+
+// defaultLanguage returns the language used when auto-detection fails
+func (s *SlideService) defaultLanguage() string {
+	if s.config.DefaultLanguage != "" {
+		return s.config.DefaultLanguage
+	}
+	return "ja"
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// watcherScopeNote explains the fallback used when the space users cannot be listed
+const watcherScopeNote = "Only the authenticated user's watch list could be read; counts reflect observed watchers only"
+
+// maxWatcherUsers bounds how many users' watch lists are read for one project
+const maxWatcherUsers = 100
+
+// watchingPageSize is the largest page the Backlog watchings API returns
+const watchingPageSize = 100
+
+// maxWatchingsPerUser bounds how many items are read from one user's watch list
+const maxWatchingsPerUser = 1000
+
+// GetIssueWatchers counts the watchers of each of the project's issues.
+// Backlog has no per-issue watcher endpoint, so the watch list of every space
+// user is read, up to maxWatcherUsers users, and the watchings of the
+// project's issues are counted. When the users cannot be listed, only the
+// authenticated user's watch list is counted and the result is labelled
+// accordingly; users whose watch lists cannot be read are noted.
+func (s *MCPService) GetIssueWatchers(projectID, backlogToken string) (*models.WatcherStats, error) {
+	projectID, backlogToken, err := ValidateProjectInput(projectID, backlogToken)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.callBacklogToolHTTP("get_users", map[string]interface{}{}, backlogToken)
+	userIDs := watcherUserIDs(users)
+	if err != nil || len(userIDs) == 0 {
+		slog.Warn("Failed to list users for watcher counts, counting the current user's watch list only", "error", err)
+		watchings, err := s.getWatchings(0, backlogToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get watching list: %w", err)
+		}
+		stats := AggregateWatcherCounts(watchings, projectID)
+		stats.Scope = "current_user"
+		stats.Note = watcherScopeNote
+		return stats, nil
+	}
+
+	var notes []string
+	if len(userIDs) > maxWatcherUsers {
+		notes = append(notes, fmt.Sprintf("Watch lists of the first %d of %d users were counted", maxWatcherUsers, len(userIDs)))
+		userIDs = userIDs[:maxWatcherUsers]
+	}
+
+	lists := make([][]interface{}, len(userIDs))
+	errs := make([]error, len(userIDs))
+	limiter := NewConcurrencyLimiter(defaultPrefetchConcurrency)
+	var wg sync.WaitGroup
+	for i, userID := range userIDs {
+		wg.Add(1)
+		go func(i, userID int) {
+			defer wg.Done()
+			limiter.Acquire()
+			defer limiter.Release()
+			lists[i], errs[i] = s.getWatchings(userID, backlogToken)
+		}(i, userID)
+	}
+	wg.Wait()
+
+	var watchings []interface{}
+	failed := 0
+	for i, list := range lists {
+		if errs[i] != nil {
+			slog.Warn("Failed to get watch list for watcher counts", "userID", userIDs[i], "error", errs[i])
+			failed++
+			continue
+		}
+		watchings = append(watchings, list...)
+	}
+	if failed == len(userIDs) {
+		return nil, fmt.Errorf("failed to get any watching list: %w", errs[0])
+	}
+	if failed > 0 {
+		notes = append(notes, fmt.Sprintf("Watch lists of %d users could not be read and are not counted", failed))
+	}
+
+	stats := AggregateWatcherCounts(watchings, projectID)
+	stats.Scope = "space_users"
+	stats.Note = strings.Join(notes, "; ")
+	return stats, nil
+}
+
+// getWatchings pages through a user's watch list until a short page is
+// returned or maxWatchingsPerUser items have been collected. A userID of 0
+// reads the authenticated user's watch list.
+func (s *MCPService) getWatchings(userID int, backlogToken string) ([]interface{}, error) {
+	watchings := make([]interface{}, 0)
+
+	for offset := 0; offset < maxWatchingsPerUser; offset += watchingPageSize {
+		if offset > 0 {
+			s.waitBetweenPages()
+		}
+
+		arguments := map[string]interface{}{
+			"count":  watchingPageSize,
+			"offset": offset,
+			"order":  "desc",
+		}
+		if userID != 0 {
+			arguments["userId"] = userID
+		}
+		result, err := s.callBacklogPage("get_watching_list_items", arguments, backlogToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get watchings at offset %d: %w", offset, err)
+		}
+		page, ok := result.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected watching list format at offset %d: %T", offset, result)
+		}
+
+		watchings = append(watchings, page...)
+		// A short page means there are no more watchings
+		if len(page) < watchingPageSize {
+			break
+		}
+	}
+
+	return watchings, nil
+}
+
+// watcherUserIDs returns the numeric IDs of the users returned by get_users
+func watcherUserIDs(users interface{}) []int {
+	list, _ := users.([]interface{})
+	ids := make([]int, 0, len(list))
+	for _, item := range list {
+		user, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := user["id"].(float64); ok && id > 0 {
+			ids = append(ids, int(id))
+		}
+	}
+	return ids
+}
+
+// AggregateWatcherCounts counts watchers per issue key from one or more
+// Backlog watch lists, keeping only issues that belong to the given project.
+// The project may be given either as a numeric ID or as a project key.
+//
+// Parameters:
+//   - watchings: Watch list items as returned by the get_watching_list_items tool
+//   - projectID: Backlog project ID or key used to filter issues
+//
+// Returns the per-issue counts; Scope and Note are left for the caller to set.
+func AggregateWatcherCounts(watchings []interface{}, projectID string) *models.WatcherStats {
+	stats := &models.WatcherStats{Counts: make(map[string]int)}
+
+	for _, item := range watchings {
+		watching, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		issue, ok := watching["issue"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		issueKey, _ := issue["issueKey"].(string)
+		if issueKey == "" || !issueInProject(issue, issueKey, projectID) {
+			continue
+		}
+		stats.Counts[issueKey]++
+	}
+
+	stats.WatchedIssues = len(stats.Counts)
+	return stats
+}
+
+// issueInProject reports whether the issue belongs to the project given by ID or key
+func issueInProject(issue map[string]interface{}, issueKey, projectID string) bool {
+	if projectID == "" {
+		return true
+	}
+	if id, ok := issue["projectId"].(float64); ok && fmt.Sprintf("%.0f", id) == projectID {
+		return true
+	}
+	return strings.HasPrefix(issueKey, projectID+"-")
+}
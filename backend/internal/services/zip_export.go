@@ -0,0 +1,119 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// WriteDeckZip writes the deck as a ZIP bundle for offline use: one markdown
+// file per slide under slides/, an HTML file for slides that have one, the
+// slide audio under audio/, and a manifest.json describing them. Audio that
+// cannot be fetched is left out and the slide's audioFile is empty in the
+// manifest.
+//
+// Parameters:
+//   - w: Destination of the ZIP stream
+//   - record: Snapshot of the slide session
+//
+// Returns an error if the bundle could not be written.
+func (s *SlideService) WriteDeckZip(w io.Writer, record *models.SlideSessionRecord) error {
+	narrations := make(map[int]*models.SlideNarration, len(record.Narrations))
+	for _, narration := range record.Narrations {
+		narrations[narration.SlideIndex] = narration
+	}
+	audioFiles := make(map[int]*models.SlideAudio, len(record.AudioFiles))
+	for _, audio := range record.AudioFiles {
+		audioFiles[audio.SlideIndex] = audio
+	}
+
+	slides := append([]*models.SlideContent(nil), record.Slides...)
+	sort.Slice(slides, func(i, j int) bool {
+		return slides[i].Index < slides[j].Index
+	})
+
+	manifest := &models.DeckManifest{
+		SlideID:    record.ID,
+		ProjectID:  record.ProjectID,
+		Language:   record.Language,
+		CreatedAt:  record.CreatedAt,
+		ExportedAt: time.Now(),
+		Slides:     make([]*models.DeckManifestSlide, 0, len(slides)),
+	}
+
+	archive := zip.NewWriter(w)
+	for _, slide := range slides {
+		base := fmt.Sprintf("slide-%02d", slide.Index+1)
+		entry := &models.DeckManifestSlide{
+			Index:        slide.Index,
+			Title:        slide.Title,
+			Theme:        slide.Theme,
+			MarkdownFile: "slides/" + base + ".md",
+		}
+		if err := writeZipFile(archive, entry.MarkdownFile, []byte(slide.Markdown)); err != nil {
+			return err
+		}
+		if slide.HTML != "" {
+			entry.HTMLFile = "slides/" + base + ".html"
+			if err := writeZipFile(archive, entry.HTMLFile, []byte(slide.HTML)); err != nil {
+				return err
+			}
+		}
+		if narration, exists := narrations[slide.Index]; exists {
+			entry.Narration = narration.Text
+		}
+		if audio, exists := audioFiles[slide.Index]; exists {
+			data, err := s.mcpService.FetchAudio(audio.AudioURL)
+			if err != nil {
+				slog.Warn("Leaving slide audio out of deck bundle", "slideIndex", slide.Index, "audioURL", audio.AudioURL, "error", err)
+			} else {
+				entry.AudioFile = "audio/" + base + audioExtension(audio.AudioURL)
+				entry.AudioDuration = audio.Duration
+				if err := writeZipFile(archive, entry.AudioFile, data); err != nil {
+					return err
+				}
+			}
+		}
+		manifest.Slides = append(manifest.Slides, entry)
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := writeZipFile(archive, "manifest.json", manifestJSON); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// writeZipFile adds a file with the given contents to the archive
+func writeZipFile(archive *zip.Writer, name string, contents []byte) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := file.Write(contents); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// audioExtension returns the file extension of an audio URL, defaulting to .wav
+func audioExtension(audioURL string) string {
+	if i := strings.IndexAny(audioURL, "?#"); i >= 0 {
+		audioURL = audioURL[:i]
+	}
+	if ext := path.Ext(audioURL); ext != "" {
+		return ext
+	}
+	return ".wav"
+}
@@ -4,8 +4,30 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+)
+
+// DefaultAudioCacheDir is where placeholder audio is written when
+// AUDIO_CACHE_DIR is unset
+const DefaultAudioCacheDir = "./cache/audio"
+
+// defaultJWTSecret is the development signing key used when JWT_SECRET is unset.
+// It is publicly known and must never be used in production.
+const defaultJWTSecret = "intelligent-presenter-secret-key"
+
+// Values for OnOversizeData
+const (
+	OversizeDataTruncate = "truncate" // Cut oversized project data to fit the prompt
+	OversizeDataError    = "error"    // Fail generation so the user can narrow the scope
 )
 
 // Config holds all configuration values for the intelligent presenter backend.
@@ -16,29 +38,145 @@ type Config struct {
 	Port string
 	// Environment indicates the deployment environment (debug, release, production)
 	Environment string
+	// LogLevel is the minimum level of structured logs (debug, info, warn, error)
+	LogLevel string
 	
 	// Backlog OAuth configuration for integrating with Backlog project management
 	BacklogDomain       string // Backlog space domain (e.g., "yourspace.backlog.jp")
 	BacklogClientID     string // OAuth2 client ID for Backlog API
 	BacklogClientSecret string // OAuth2 client secret for Backlog API
 	OAuthRedirectURL    string // OAuth2 callback URL for authentication flow
+	BacklogTokenURL     string // Optional OAuth2 token endpoint override (defaults to https://{domain}/api/v2/oauth2/token)
 	
 	// AI Provider configuration for slide content generation
-	AIProvider   string // AI service to use: "openai" or "bedrock"
-	OpenAIAPIKey string // API key for OpenAI services
+	AIProvider      string // AI service to use: "openai", "bedrock", "gemini", or "anthropic"
+	OpenAIAPIKey    string // API key for OpenAI services
+	OpenAIModel     string // OpenAI chat model used for generation (e.g., "gpt-4o")
+	OpenAIMaxTokens int    // Maximum tokens per OpenAI completion
+	OpenAIBaseURL   string // Base URL of the OpenAI-compatible API
+
+	// Google Gemini configuration for AI content generation
+	GeminiAPIKey  string // API key for the Gemini API
+	GeminiModel   string // Gemini model used for generation (e.g., "gemini-1.5-flash")
+	GeminiBaseURL string // Base URL of the Gemini API
+
+	// Anthropic API configuration for calling Claude without AWS
+	AnthropicAPIKey  string // API key for the Anthropic Messages API
+	AnthropicModel   string // Claude model used for generation (e.g., "claude-3-haiku-20240307")
+	AnthropicBaseURL string // Base URL of the Anthropic API
+
+	// AIFallbackEnabled retries failed Bedrock, Gemini, or Anthropic calls with OpenAI
+	AIFallbackEnabled bool
+
+	// AIStreaming streams slide markdown to WebSocket clients while the model
+	// generates it. OpenAI and Claude models on Bedrock (via the SDK) support
+	// streaming; other models and providers keep the buffered response.
+	AIStreaming bool
+
+	// Deadlines of a streamed OpenAI response, which may outlast the timeout of
+	// a buffered request
+	AIStreamTimeout     time.Duration // Longest a whole streamed response may take
+	AIStreamIdleTimeout time.Duration // Longest a stream may go without sending data
+
+	// AITokenCostPerMillion is the blended price in USD of one million AI
+	// tokens, used to estimate the cost of a generation in its report
+	AITokenCostPerMillion float64
+
+	// Retry configuration for transient AI provider failures (429/5xx)
+	AIMaxAttempts    int           // Maximum number of attempts per AI request, including the first
+	AIRetryBaseDelay time.Duration // Initial backoff delay, doubled after every failed attempt
+
+	// PromptAudience describes the intended audience or reading level injected
+	// into slide prompts (e.g., "non-technical executives")
+	PromptAudience string
+
+	// DefaultLanguage is used when a request asks for "auto" language and the
+	// user's Backlog language cannot be determined
+	DefaultLanguage string
+
+	// Prompt data size guard. Project data larger than PromptDataMaxBytes is
+	// truncated or rejected depending on OnOversizeData ("truncate" or "error")
+	PromptDataMaxBytes int
+	OnOversizeData     string
+
+	// Narration configuration for spoken slide commentary
+	NarrationTargets map[string]string // Target narration length in minutes per language (e.g., "ja" -> "1-2")
+
+	// Disable all audio synthesis for deployments without TTS infrastructure
+	DisableAudio bool // Produce text-and-narration-only decks without calling the speech server
+	// Debug only: write silent placeholder audio when MCP_SPEECH_URL is unset
+	AllowSilentTTS bool
+	// Directory holding placeholder audio written by the backend itself
+	AudioCacheDir string
+
+	// Audio synthesis concurrency limits to avoid saturating the TTS engine
+	AudioMaxConcurrency        int // Maximum concurrent audio syntheses across all sessions (0 = unlimited)
+	AudioSessionMaxConcurrency int // Maximum concurrent audio syntheses within one session (0 = unlimited)
+	SlideMaxConcurrency        int // Maximum slides generated concurrently within one session
+	PrefetchThemeData          bool // Fetch every theme's Backlog data concurrently before generating slides
+	PrefetchMaxConcurrency     int  // Maximum concurrent theme data fetches during prefetch
+
+	// How long a generated slide is reused for unchanged project data (0 disables the cache)
+	SlideCacheTTL time.Duration
+
+	// Accept themes outside the built-in set, generating a generic slide for them
+	AllowUnknownThemes bool
+
+	// Accept per-request AI provider credentials in the X-AI-Credentials header,
+	// so that each tenant of a shared deployment uses its own AI account
+	AllowTenantAICredentials bool
+
+	// Canonical deck flow applied to requests with autoOrder set
+	ThemeOrder []string
+
+	// Risk signal thresholds for risk analysis, in days
+	RiskDueSoonDays    int // Open issues due within this many days are flagged as due soon
+	RiskUnassignedDays int // High-priority issues unassigned for longer than this are flagged
+	RiskStalledDays    int // Open issues without updates for longer than this are flagged as stalled
+
+	// Issue statistics configuration for progress analysis
+	ExcludeDuplicateIssues bool // Exclude issues resolved as "Duplicate" from progress statistics
+
+	// Comment aggregation for summaries
+	MaxSummaryComments int // Maximum total comments fetched for LLM summaries
+
+	// Pacing of paginated Backlog fetches to stay under the API rate limit
+	BacklogPageDelay time.Duration // Pause between page requests (0 disables)
 	
 	// AWS Bedrock configuration for AI content generation
 	AWSRegion          string // AWS region for Bedrock service
 	AWSAccessKeyID     string // AWS access key for authentication
 	AWSSecretAccessKey string // AWS secret key for authentication
-	BedrockModelID     string // Bedrock model identifier for content generation
+	AWSSessionToken    string // Optional session token for temporary (STS, assumed-role, IRSA) credentials
+	BedrockModelID     string // Bedrock model identifier (Claude, Titan, Llama, or Mistral) for content generation
+	BedrockEndpoint    string // Optional Bedrock runtime endpoint override (defaults to the regional endpoint)
 	
 	// MCP Server URLs for Model Context Protocol integration
 	MCPBacklogURL string // URL of the Backlog MCP server
 	MCPSpeechURL  string // URL of the Speech MCP server
+
+	// backlog-server command (binary and arguments) run as a stdio subprocess
+	// when MCP_BACKLOG_URL is unset, for local development without Docker
+	BacklogMCPCommand string
 	
+	// Per-user rate limiting for slide generation and speech synthesis
+	RateLimitRPS   float64 // Sustained requests per second allowed per user (0 disables the limit)
+	RateLimitBurst int     // Requests a user may make at once before being throttled
+
+	// Slide session persistence configuration
+	SessionStore    string        // Session store backend: "memory" or "file"
+	SessionStoreDir string        // Directory used by the file session store
+	SessionTTL      time.Duration // How long completed sessions are kept before cleanup (0 disables cleanup)
+
+	// WebSocket heartbeat configuration
+	WSPingInterval time.Duration // How often slide WebSocket clients are pinged
+	WSPongTimeout  time.Duration // How long a client may go without answering a ping before it is dropped
+
 	// JWT configuration for session management
-	JWTSecret string // Secret key for JWT token signing and verification
+	JWTSecret       string        // Secret key for JWT token signing and verification
+	JWTTokenTTL     time.Duration // Lifetime of application JWTs issued after login or refresh
+	JWTRefreshGrace time.Duration // How long after expiry an application JWT may still be refreshed
+	OAuthStateTTL   time.Duration // Lifetime of the signed OAuth state parameter
 
     // Frontend base URL for OAuth redirects and CORS
     FrontendBaseURL string // Base URL of the frontend application
@@ -54,25 +192,185 @@ type Config struct {
 // Returns a fully configured Config struct with all fields populated
 // from environment variables or their default values.
 func Load() *Config {
-	return &Config{
+	cfg := &Config{
 		Port:                getEnv("PORT", "8080"),
 		Environment:         getEnv("GIN_MODE", "debug"),
+		LogLevel:            getEnv("LOG_LEVEL", "info"),
 		BacklogDomain:       getEnv("BACKLOG_DOMAIN", ""),
 		BacklogClientID:     getEnv("BACKLOG_CLIENT_ID", ""),
 		BacklogClientSecret: getEnv("BACKLOG_CLIENT_SECRET", ""),
         OAuthRedirectURL:    getEnv("OAUTH_REDIRECT_URL", "http://localhost:8081/api/v1/auth/callback"),
+		BacklogTokenURL:     getEnv("BACKLOG_TOKEN_URL", ""),
 		AIProvider:          getEnv("AI_PROVIDER", "openai"),
 		OpenAIAPIKey:        getEnv("OPENAI_API_KEY", ""),
+		OpenAIModel:         getEnv("OPENAI_MODEL", "gpt-3.5-turbo"),
+		OpenAIMaxTokens:     getEnvAsPositiveInt("OPENAI_MAX_TOKENS", 800),
+		OpenAIBaseURL:       getEnv("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+		GeminiAPIKey:        getEnv("GEMINI_API_KEY", ""),
+		GeminiModel:         getEnv("GEMINI_MODEL", "gemini-1.5-flash"),
+		GeminiBaseURL:       getEnv("GEMINI_BASE_URL", "https://generativelanguage.googleapis.com/v1beta"),
+		AnthropicAPIKey:     getEnv("ANTHROPIC_API_KEY", ""),
+		AnthropicModel:      getEnv("ANTHROPIC_MODEL", "claude-3-haiku-20240307"),
+		AnthropicBaseURL:    getEnv("ANTHROPIC_BASE_URL", "https://api.anthropic.com/v1"),
+		AIFallbackEnabled:   getEnvAsBool("AI_FALLBACK_ENABLED", true),
+		AIStreaming:         getEnvAsBool("AI_STREAMING", false),
+		AIStreamTimeout:     getEnvAsPositiveDuration("AI_STREAM_TIMEOUT", 5*time.Minute),
+		AIStreamIdleTimeout: getEnvAsPositiveDuration("AI_STREAM_IDLE_TIMEOUT", 30*time.Second),
+		AITokenCostPerMillion: getEnvAsNonNegativeFloat("AI_TOKEN_COST_PER_MILLION", 1),
+		AIMaxAttempts:       getEnvAsPositiveInt("AI_MAX_ATTEMPTS", 3),
+		AIRetryBaseDelay:    getEnvAsDuration("AI_RETRY_BASE_DELAY", time.Second),
+		PromptAudience:      getEnv("PROMPT_AUDIENCE", ""),
+		DefaultLanguage:     getEnv("DEFAULT_LANGUAGE", "ja"),
+		PromptDataMaxBytes:  getEnvAsPositiveInt("PROMPT_DATA_MAX_BYTES", 8000),
+		OnOversizeData:      getEnv("ON_OVERSIZE_DATA", OversizeDataTruncate),
+		NarrationTargets:    getEnvAsMap("NARRATION_TARGETS", map[string]string{"ja": "2-3", "en": "2-3"}),
+		DisableAudio:        getEnvAsBool("DISABLE_AUDIO", false),
+		AllowSilentTTS:      getEnvAsBool("ALLOW_SILENT_TTS", false),
+		AudioCacheDir:       getEnv("AUDIO_CACHE_DIR", DefaultAudioCacheDir),
+		AudioMaxConcurrency: getEnvAsPositiveInt("AUDIO_MAX_CONCURRENCY", 4),
+		AudioSessionMaxConcurrency: getEnvAsPositiveInt("AUDIO_SESSION_MAX_CONCURRENCY", 2),
+		SlideMaxConcurrency: getEnvAsPositiveInt("SLIDE_MAX_CONCURRENCY", 3),
+		PrefetchThemeData:   getEnvAsBool("PREFETCH_THEME_DATA", true),
+		PrefetchMaxConcurrency: getEnvAsPositiveInt("PREFETCH_MAX_CONCURRENCY", 4),
+		SlideCacheTTL:       getEnvAsDuration("SLIDE_CACHE_TTL", time.Hour),
+		AllowUnknownThemes:  getEnvAsBool("ALLOW_UNKNOWN_THEMES", false),
+		AllowTenantAICredentials: getEnvAsBool("ALLOW_TENANT_AI_CREDENTIALS", false),
+		ThemeOrder:          getEnvAsSlice("THEME_ORDER", DefaultThemeOrder),
+		RiskDueSoonDays:     getEnvAsPositiveInt("RISK_DUE_SOON_DAYS", 3),
+		RiskUnassignedDays:  getEnvAsPositiveInt("RISK_UNASSIGNED_DAYS", 3),
+		RiskStalledDays:     getEnvAsPositiveInt("RISK_STALLED_DAYS", 14),
+		ExcludeDuplicateIssues: getEnvAsBool("EXCLUDE_DUPLICATE_ISSUES", false),
+		MaxSummaryComments:  getEnvAsPositiveInt("MAX_SUMMARY_COMMENTS", 200),
+		BacklogPageDelay:    getEnvAsDuration("BACKLOG_PAGE_DELAY", 200*time.Millisecond),
 		AWSRegion:           getEnv("AWS_REGION", "ap-northeast-1"),
 		AWSAccessKeyID:      getEnv("AWS_ACCESS_KEY_ID", ""),
 		AWSSecretAccessKey:  getEnv("AWS_SECRET_ACCESS_KEY", ""),
+		AWSSessionToken:     getEnv("AWS_SESSION_TOKEN", ""),
 		BedrockModelID:      getEnv("BEDROCK_MODEL_ID", "anthropic.claude-3-haiku-20240307-v1:0"),
-        MCPBacklogURL:       getEnv("MCP_BACKLOG_URL", "http://localhost:3001"),
+		BedrockEndpoint:     getEnv("BEDROCK_ENDPOINT", ""),
+        MCPBacklogURL:       getEnv("MCP_BACKLOG_URL", ""),
+		BacklogMCPCommand:   getEnv("BACKLOG_MCP_COMMAND", ""),
 		MCPSpeechURL:        getEnv("MCP_SPEECH_URL", "http://localhost:3002"),
-		JWTSecret:           getEnv("JWT_SECRET", "intelligent-presenter-secret-key"),
+		RateLimitRPS:        getEnvAsNonNegativeFloat("RATE_LIMIT_RPS", 1),
+		RateLimitBurst:      getEnvAsPositiveInt("RATE_LIMIT_BURST", 5),
+		SessionStore:        getEnv("SESSION_STORE", "memory"),
+		SessionStoreDir:     getEnv("SESSION_STORE_DIR", "./data/sessions"),
+		SessionTTL:          getEnvAsDuration("SESSION_TTL", 24*time.Hour),
+		WSPingInterval:      getEnvAsDuration("WS_PING_INTERVAL", 30*time.Second),
+		WSPongTimeout:       getEnvAsDuration("WS_PONG_TIMEOUT", 60*time.Second),
+		JWTSecret:           getEnv("JWT_SECRET", defaultJWTSecret),
+		JWTTokenTTL:         getEnvAsPositiveDuration("JWT_TOKEN_TTL", 7*24*time.Hour),
+		JWTRefreshGrace:     getEnvAsDuration("JWT_REFRESH_GRACE", 7*24*time.Hour),
+		OAuthStateTTL:       getEnvAsPositiveDuration("OAUTH_STATE_TTL", 10*time.Minute),
         FrontendBaseURL:     getEnv("FRONTEND_BASE_URL", "http://localhost:3003"),
 		CORSOrigins:         getEnvAsSlice("CORS_ORIGINS", []string{"http://localhost:3003"}),
 	}
+
+	// Without a local backlog-server command, default to the bridge container
+	if cfg.MCPBacklogURL == "" && cfg.BacklogMCPCommand == "" {
+		cfg.MCPBacklogURL = "http://localhost:3001"
+	}
+	return cfg
+}
+
+// UsesLocalBacklogMCP reports whether Backlog tools are called on a
+// backlog-server subprocess over stdio instead of the HTTP bridge.
+func (c *Config) UsesLocalBacklogMCP() bool {
+	return c.MCPBacklogURL == "" && c.BacklogMCPCommand != ""
+}
+
+// IsProduction reports whether the server runs in production or release mode.
+func (c *Config) IsProduction() bool {
+	return c.Environment == "production" || c.Environment == "release"
+}
+
+// Validate checks that the configuration is usable before the server starts.
+// Outside production mode it accepts the development defaults. In production
+// mode it requires a non-default JWT secret, a supported AI provider with its
+// credentials present, and valid MCP server URLs, and it refuses the local
+// backlog-server subprocess.
+//
+// Returns an error listing every problem found, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	if !c.IsProduction() {
+		return nil
+	}
+
+	var errs []error
+	if c.JWTSecret == "" || c.JWTSecret == defaultJWTSecret {
+		errs = append(errs, errors.New("JWT_SECRET must be set to a non-default value"))
+	}
+
+	switch c.AIProvider {
+	case "openai":
+		if c.OpenAIAPIKey == "" {
+			errs = append(errs, errors.New("OPENAI_API_KEY is required when AI_PROVIDER is openai"))
+		}
+	case "bedrock":
+		if c.AWSAccessKeyID == "" || c.AWSSecretAccessKey == "" {
+			errs = append(errs, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required when AI_PROVIDER is bedrock"))
+		}
+		if c.AWSRegion == "" {
+			errs = append(errs, errors.New("AWS_REGION is required when AI_PROVIDER is bedrock"))
+		}
+	case "gemini":
+		if c.GeminiAPIKey == "" {
+			errs = append(errs, errors.New("GEMINI_API_KEY is required when AI_PROVIDER is gemini"))
+		}
+	case "anthropic":
+		if c.AnthropicAPIKey == "" {
+			errs = append(errs, errors.New("ANTHROPIC_API_KEY is required when AI_PROVIDER is anthropic"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("AI_PROVIDER %q is not supported (use openai, bedrock, gemini, or anthropic)", c.AIProvider))
+	}
+
+	switch c.OnOversizeData {
+	case "", OversizeDataTruncate, OversizeDataError:
+	default:
+		errs = append(errs, fmt.Errorf("ON_OVERSIZE_DATA %q is not supported (use %s or %s)", c.OnOversizeData, OversizeDataTruncate, OversizeDataError))
+	}
+
+	if c.UsesLocalBacklogMCP() {
+		// The subprocess calls Backlog with the server's own credentials rather
+		// than each user's token, so it would expose one account's data to everyone
+		errs = append(errs, errors.New("BACKLOG_MCP_COMMAND is for local development only; set MCP_BACKLOG_URL in production"))
+	} else if err := validateURL("MCP_BACKLOG_URL", c.MCPBacklogURL); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateURL("MCP_SPEECH_URL", c.MCPSpeechURL); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateURL checks that the named setting holds an absolute http(s) URL.
+func validateURL(name, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an absolute http(s) URL, got %q", name, value)
+	}
+	return nil
+}
+
+// DefaultThemeOrder is the deck flow used for autoOrder requests when
+// THEME_ORDER is not set: space, portfolio, and project overview, progress,
+// issues, risks, then summary
+var DefaultThemeOrder = []string{
+	string(models.ThemeSpaceOverview),
+	string(models.ThemePortfolioComparison),
+	string(models.ThemeProjectOverview),
+	string(models.ThemeProjectProgress),
+	string(models.ThemeIssueManagement),
+	string(models.ThemePeriodComparison),
+	string(models.ThemeRiskAnalysis),
+	string(models.ThemeTeamCollaboration),
+	string(models.ThemeCodebaseActivity),
+	string(models.ThemeDocumentManagement),
+	string(models.ThemeNotifications),
+	string(models.ThemePredictiveAnalysis),
+	string(models.ThemeSummaryPlan),
 }
 
 // getEnvAsSlice converts a comma-separated environment variable into a string slice.
@@ -91,6 +389,140 @@ func getEnvAsSlice(name string, defaultVal []string) []string {
     return strings.Split(valStr, ",")
 }
 
+// getEnvAsMap converts a comma-separated list of key=value pairs into a map.
+// Entries without an "=" separator are ignored. If the environment variable
+// is empty or not set, it returns the provided default map.
+//
+// Parameters:
+//   - name: the environment variable name to read
+//   - defaultVal: the default map to return if the environment variable is not set
+//
+// Returns a map of trimmed keys to trimmed values, or the default value if not found.
+func getEnvAsMap(name string, defaultVal map[string]string) map[string]string {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+
+	result := make(map[string]string)
+	for _, pair := range strings.Split(valStr, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return result
+}
+
+// getEnvAsDuration parses an environment variable as a Go duration string (e.g., "30m", "24h").
+// If the environment variable is not set or cannot be parsed, it returns the provided default.
+//
+// Parameters:
+//   - name: the environment variable name to read
+//   - defaultVal: the default duration to return if the variable is missing or invalid
+//
+// Returns the parsed duration, or the default value if not found or invalid.
+func getEnvAsDuration(name string, defaultVal time.Duration) time.Duration {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+	duration, err := time.ParseDuration(valStr)
+	if err != nil {
+		return defaultVal
+	}
+	return duration
+}
+
+// getEnvAsPositiveDuration parses an environment variable as a positive Go
+// duration string (e.g., "168h"). If the environment variable is not set, cannot
+// be parsed, or is not greater than zero, it logs a warning where applicable and
+// returns the provided default.
+//
+// Parameters:
+//   - name: the environment variable name to read
+//   - defaultVal: the default duration to return if the variable is missing or invalid
+//
+// Returns the parsed duration, or the default value if not found or invalid.
+func getEnvAsPositiveDuration(name string, defaultVal time.Duration) time.Duration {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+	duration, err := time.ParseDuration(valStr)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid value %q for %s, must be a positive duration; using default %v", valStr, name, defaultVal)
+		return defaultVal
+	}
+	return duration
+}
+
+// getEnvAsNonNegativeFloat parses an environment variable as a floating-point
+// number of zero or more. If the environment variable is not set, is not a
+// number, or is negative, it logs a warning where applicable and returns the
+// provided default.
+//
+// Parameters:
+//   - name: the environment variable name to read
+//   - defaultVal: the default value to return if the variable is missing or invalid
+//
+// Returns the parsed number, or the default value if not found or invalid.
+func getEnvAsNonNegativeFloat(name string, defaultVal float64) float64 {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil || val < 0 {
+		log.Printf("Invalid value %q for %s, must be a non-negative number; using default %v", valStr, name, defaultVal)
+		return defaultVal
+	}
+	return val
+}
+
+// getEnvAsPositiveInt parses an environment variable as a positive integer.
+// If the environment variable is not set, is not a number, or is not greater
+// than zero, it logs a warning where applicable and returns the provided default.
+//
+// Parameters:
+//   - name: the environment variable name to read
+//   - defaultVal: the default value to return if the variable is missing or invalid
+//
+// Returns the parsed integer, or the default value if not found or invalid.
+func getEnvAsPositiveInt(name string, defaultVal int) int {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+	val, err := strconv.Atoi(valStr)
+	if err != nil || val <= 0 {
+		log.Printf("Invalid value %q for %s, must be a positive integer; using default %d", valStr, name, defaultVal)
+		return defaultVal
+	}
+	return val
+}
+
+// getEnvAsBool parses an environment variable as a boolean (e.g., "true", "1", "false").
+// If the environment variable is not set or cannot be parsed, it returns the provided default.
+//
+// Parameters:
+//   - name: the environment variable name to read
+//   - defaultVal: the default value to return if the variable is missing or invalid
+//
+// Returns the parsed boolean, or the default value if not found or invalid.
+func getEnvAsBool(name string, defaultVal bool) bool {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return defaultVal
+	}
+	val, err := strconv.ParseBool(valStr)
+	if err != nil {
+		return defaultVal
+	}
+	return val
+}
+
 // getEnv retrieves an environment variable value with a fallback default.
 // This is a utility function used throughout the configuration loading process.
 //
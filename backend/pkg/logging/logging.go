@@ -0,0 +1,93 @@
+// Package logging configures structured, leveled logging for the intelligent
+// presenter backend. Log records are written with log/slog and attributes that
+// carry credentials, such as access tokens and API keys, are redacted.
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// redacted replaces the value of sensitive attributes
+const redacted = "[REDACTED]"
+
+// sensitiveKeyParts marks attribute keys whose values must never be logged.
+// Keys are compared case-insensitively with separators removed, so "apiKey",
+// "api_key", and "API-KEY" all match.
+var sensitiveKeyParts = []string{"token", "apikey", "secret", "password", "authorization", "credential"}
+
+// Setup installs a redacting logger at the given level as the slog default.
+//
+// Parameters:
+//   - level: Minimum level to log ("debug", "info", "warn", or "error")
+func Setup(level string) {
+	slog.SetDefault(slog.New(NewHandler(os.Stdout, ParseLevel(level))))
+}
+
+// NewHandler creates a text handler that writes records at or above level to w
+// and redacts sensitive attributes.
+func NewHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	return slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	})
+}
+
+// ParseLevel converts a LOG_LEVEL value into a slog level. Unknown values
+// default to info.
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// IsSensitiveKey reports whether an attribute or field name holds a credential.
+func IsSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactMap returns a copy of fields with the values of sensitive keys
+// replaced, descending into nested maps. It is used before logging payloads.
+func RedactMap(fields map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if IsSensitiveKey(key) {
+			result[key] = redacted
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = RedactMap(nested)
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// redactAttr replaces sensitive attribute values before they are written.
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() == slog.KindGroup {
+		return attr
+	}
+	if IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+	if fields, ok := attr.Value.Any().(map[string]interface{}); ok {
+		return slog.Any(attr.Key, RedactMap(fields))
+	}
+	return attr
+}
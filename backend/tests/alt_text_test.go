@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// TestSlideService_ChartSlideIncludesAltText tests that a generated slide with a
+// chart and a Mermaid diagram carries a non-empty description of each
+func TestSlideService_ChartSlideIncludesAltText(t *testing.T) {
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result": {"content": [{"type": "text", "text": "{\"name\": \"Alpha\"}"}]}}`))
+	}))
+	defer bridge.Close()
+
+	markdown := "# Status\n\n```mermaid\npie title Issues by status\n    \"Open\" : 12\n    \"Closed\" : 30\n```\n\n" +
+		"```json\n{\"type\": \"bar\", \"data\": {\"labels\": [\"Alice\", \"Bob\"], \"datasets\": [{\"label\": \"Assigned issues\", \"data\": [5, 3]}]}}\n```\n"
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]interface{}{"content": markdown}}},
+		})
+	}))
+	defer openAI.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+	})
+	slide, err := service.GenerateSlideContent("TEST", models.ThemeProjectOverview, "en", "token")
+	if err != nil {
+		t.Fatalf("GenerateSlideContent failed: %v", err)
+	}
+
+	if len(slide.DiagramAltTexts) != 2 {
+		t.Fatalf("Expected alt text for 2 diagrams, got %+v", slide.DiagramAltTexts)
+	}
+	pie, chart := slide.DiagramAltTexts[0], slide.DiagramAltTexts[1]
+	if pie.Kind != "mermaid" || pie.Index != 0 || !strings.Contains(pie.AltText, "Open 12") || !strings.Contains(pie.AltText, "Issues by status") {
+		t.Errorf("Expected the pie chart slices and title in the alt text, got %+v", pie)
+	}
+	if chart.Kind != "chart" || chart.Index != 1 || !strings.Contains(chart.AltText, "Bar chart") || !strings.Contains(chart.AltText, "Alice 5, Bob 3") {
+		t.Errorf("Expected the chart data in the alt text, got %+v", chart)
+	}
+}
+
+// TestDiagramAltTexts_SummarizesLongSeries tests that a long chart series such
+// as a burndown is summarized rather than listed, and that non-chart code
+// blocks get no alt text
+func TestDiagramAltTexts_SummarizesLongSeries(t *testing.T) {
+	series := &models.BurndownSeries{}
+	for day, remaining := range []int{9, 8, 8, 7, 6, 6, 5, 4, 4, 2} {
+		series.Labels = append(series.Labels, fmt.Sprintf("2026-10-%02d", day+1))
+		series.Remaining = append(series.Remaining, remaining)
+	}
+	configJSON, _ := json.Marshal(services.BurndownChartConfig(series, "ja"))
+	markdown := "```go\nfmt.Println(\"not a diagram\")\n```\n\n```json\n" + string(configJSON) + "\n```\n"
+
+	altTexts := services.DiagramAltTexts(markdown, "ja")
+	if len(altTexts) != 1 {
+		t.Fatalf("Expected alt text for only the chart, got %+v", altTexts)
+	}
+	want := "折れ線グラフ「課題バーンダウン」。残課題数: 10点、2026-10-01時点 9 から 2026-10-10時点 2（最小 2、最大 9）。"
+	if altTexts[0].AltText != want {
+		t.Errorf("Expected %q, got %q", want, altTexts[0].AltText)
+	}
+}
+
+// TestDiagramAltTexts_DescribesPointData tests that charts with {x, y} points
+// are described by each point, and that datasets with gaps or other data are
+// still described by their size rather than dropping the chart
+func TestDiagramAltTexts_DescribesPointData(t *testing.T) {
+	markdown := "```json\n" + `{
+		"type": "line",
+		"data": {"datasets": [
+			{"label": "Velocity", "data": [{"x": "Sprint 1", "y": 12}, {"x": "Sprint 2", "y": 15.5}]},
+			{"label": "Scope", "data": [20, null, 24]}
+		]}
+	}` + "\n```\n"
+
+	altTexts := services.DiagramAltTexts(markdown, "en")
+	if len(altTexts) != 1 {
+		t.Fatalf("Expected alt text for the chart, got %+v", altTexts)
+	}
+	want := "Line chart. Velocity: Sprint 1 12, Sprint 2 15.5. Scope: 3 data points."
+	if altTexts[0].AltText != want {
+		t.Errorf("Expected %q, got %q", want, altTexts[0].AltText)
+	}
+
+	altTexts = services.DiagramAltTexts(markdown, "ja")
+	want = "折れ線グラフ。Velocity: Sprint 1 12、Sprint 2 15.5。Scope: 3点のデータ。"
+	if len(altTexts) != 1 || altTexts[0].AltText != want {
+		t.Errorf("Expected %q, got %+v", want, altTexts)
+	}
+}
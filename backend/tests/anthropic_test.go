@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// TestAnthropicService_GenerateText tests the Messages API payload and content response parsing
+func TestAnthropicService_GenerateText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" {
+			t.Errorf("Unexpected request path: %s", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "test-anthropic-key" {
+			t.Errorf("Expected API key header, got %q", r.Header.Get("x-api-key"))
+		}
+		if r.Header.Get("anthropic-version") == "" {
+			t.Error("Expected anthropic-version header to be set")
+		}
+
+		var raw map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if raw["model"] != "claude-test" {
+			t.Errorf("Expected model claude-test, got %v", raw["model"])
+		}
+		if _, exists := raw["anthropic_version"]; exists {
+			t.Error("anthropic_version must not be sent in the body to the direct API")
+		}
+		messages, ok := raw["messages"].([]interface{})
+		if !ok || len(messages) != 1 {
+			t.Fatalf("Expected a single message, got %v", raw["messages"])
+		}
+		message := messages[0].(map[string]interface{})
+		if message["role"] != "user" || message["content"] != "Generate a slide" {
+			t.Errorf("Unexpected message payload: %v", message)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "msg_test",
+			"type": "message",
+			"role": "assistant",
+			"model": "claude-test",
+			"content": [{"type": "text", "text": "# Project Overview"}],
+			"usage": {"input_tokens": 10, "output_tokens": 5}
+		}`))
+	}))
+	defer server.Close()
+
+	service := services.NewAnthropicService(&config.Config{
+		AnthropicAPIKey:  "test-anthropic-key",
+		AnthropicModel:   "claude-test",
+		AnthropicBaseURL: server.URL,
+	})
+
+	text, err := service.GenerateText("Generate a slide")
+	if err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+	if text != "# Project Overview" {
+		t.Errorf("Unexpected generated text: %q", text)
+	}
+}
+
+// TestAnthropicService_ErrorResponse tests that API error messages are surfaced
+func TestAnthropicService_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"type": "error", "error": {"type": "authentication_error", "message": "invalid x-api-key"}}`))
+	}))
+	defer server.Close()
+
+	service := services.NewAnthropicService(&config.Config{
+		AnthropicAPIKey:  "bad-key",
+		AnthropicBaseURL: server.URL,
+	})
+
+	_, err := service.GenerateText("Generate a slide")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid x-api-key") {
+		t.Errorf("Expected API error message in error, got %v", err)
+	}
+}
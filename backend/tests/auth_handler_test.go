@@ -0,0 +1,263 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/api/handlers"
+	"intelligent-presenter-backend/internal/auth"
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testJWTSecret = "test-secret"
+
+// newMockTokenEndpoint creates a Backlog OAuth token endpoint that accepts only
+// the given refresh token and rotates it on success
+func newMockTokenEndpoint(t *testing.T, validRefreshToken string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != validRefreshToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors": [{"message": "The refresh token is invalid or revoked."}]}`))
+			return
+		}
+		w.Write([]byte(`{"access_token": "new-access", "refresh_token": "new-refresh", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// signTestClaims signs JWT claims with the test secret, optionally already
+// expired, sealing the refresh token as GenerateToken does
+func signTestClaims(t *testing.T, refreshToken string, expiresAt time.Time) string {
+	t.Helper()
+	if refreshToken != "" {
+		sealed, err := auth.SealRefreshToken(refreshToken, testJWTSecret)
+		if err != nil {
+			t.Fatalf("Failed to seal refresh token: %v", err)
+		}
+		refreshToken = sealed
+	}
+	claims := &models.JWTClaims{
+		UserID:              42,
+		BacklogToken:        "old-access",
+		BacklogRefreshToken: refreshToken,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(expiresAt.Add(-time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("Failed to sign test token: %v", err)
+	}
+	return token
+}
+
+// TestAuthHandler_RefreshToken tests exchanging the stored Backlog refresh token
+// for a new application JWT, including rejection of revoked refresh tokens
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenEndpoint := newMockTokenEndpoint(t, "valid-refresh")
+
+	handler := handlers.NewAuthHandler(&config.Config{
+		BacklogClientID:     "client-id",
+		BacklogClientSecret: "client-secret",
+		BacklogTokenURL:     tokenEndpoint.URL,
+		JWTSecret:           testJWTSecret,
+		JWTRefreshGrace:     24 * time.Hour,
+	})
+	router := gin.New()
+	router.POST("/auth/refresh", handler.RefreshToken)
+
+	refresh := func(token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("expired JWT with valid refresh token", func(t *testing.T) {
+		w := refresh(signTestClaims(t, "valid-refresh", time.Now().Add(-time.Minute)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			Token string `json:"token"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		claims, err := auth.ParseRefreshableToken(response.Token, testJWTSecret, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to parse refreshed token: %v", err)
+		}
+		if claims.UserID != 42 {
+			t.Errorf("Expected user ID 42 to be kept, got %d", claims.UserID)
+		}
+		if claims.BacklogToken != "new-access" || claims.BacklogRefreshToken != "new-refresh" {
+			t.Errorf("Expected rotated Backlog tokens, got %q and %q", claims.BacklogToken, claims.BacklogRefreshToken)
+		}
+		if !claims.ExpiresAt.After(time.Now()) {
+			t.Errorf("Expected the refreshed JWT to expire in the future, got %v", claims.ExpiresAt)
+		}
+
+		payload, err := jwt.NewParser().DecodeSegment(strings.Split(response.Token, ".")[1])
+		if err != nil {
+			t.Fatalf("Failed to decode the token payload: %v", err)
+		}
+		if strings.Contains(string(payload), "new-refresh") {
+			t.Errorf("Expected the refresh token to be sealed in the JWT, got payload %s", payload)
+		}
+	})
+
+	t.Run("JWT expired beyond the refresh grace period", func(t *testing.T) {
+		w := refresh(signTestClaims(t, "valid-refresh", time.Now().Add(-25*time.Hour)))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("JWT without expiry", func(t *testing.T) {
+		sealed, _ := auth.SealRefreshToken("valid-refresh", testJWTSecret)
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &models.JWTClaims{
+			UserID:              42,
+			BacklogRefreshToken: sealed,
+		}).SignedString([]byte(testJWTSecret))
+		if w := refresh(token); w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("unsealed refresh token", func(t *testing.T) {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &models.JWTClaims{
+			UserID:              42,
+			BacklogRefreshToken: "valid-refresh",
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}).SignedString([]byte(testJWTSecret))
+		if w := refresh(token); w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("revoked refresh token", func(t *testing.T) {
+		w := refresh(signTestClaims(t, "revoked-refresh", time.Now().Add(time.Hour)))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("token without refresh token", func(t *testing.T) {
+		w := refresh(signTestClaims(t, "", time.Now().Add(time.Hour)))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("token signed with another secret", func(t *testing.T) {
+		forged, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &models.JWTClaims{
+			UserID:              42,
+			BacklogRefreshToken: "valid-refresh",
+		}).SignedString([]byte("other-secret"))
+		w := refresh(forged)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		if w := refresh(""); w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d", w.Code)
+		}
+	})
+}
+
+// TestAuthTokenTTL_ReflectsConfiguration tests that application JWTs and OAuth
+// state tokens expire after the configured lifetimes
+func TestAuthTokenTTL_ReflectsConfiguration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assertExpiresIn := func(t *testing.T, expiresAt time.Time, ttl time.Duration) {
+		t.Helper()
+		expected := time.Now().Add(ttl)
+		if diff := expiresAt.Sub(expected); diff < -5*time.Second || diff > 5*time.Second {
+			t.Errorf("Expected expiry around %v, got %v", expected, expiresAt)
+		}
+	}
+
+	t.Run("application token", func(t *testing.T) {
+		token, err := auth.GenerateToken(42, "access", "refresh", testJWTSecret, 30*time.Minute)
+		if err != nil {
+			t.Fatalf("GenerateToken failed: %v", err)
+		}
+		claims, err := auth.ParseRefreshableToken(token, testJWTSecret, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to parse token: %v", err)
+		}
+		assertExpiresIn(t, claims.ExpiresAt.Time, 30*time.Minute)
+
+		token, _ = auth.GenerateToken(42, "access", "refresh", testJWTSecret, 0)
+		claims, _ = auth.ParseRefreshableToken(token, testJWTSecret, time.Hour)
+		assertExpiresIn(t, claims.ExpiresAt.Time, 7*24*time.Hour)
+	})
+
+	t.Run("OAuth state", func(t *testing.T) {
+		handler := handlers.NewAuthHandler(&config.Config{
+			BacklogDomain: "example.backlog.jp",
+			JWTSecret:     testJWTSecret,
+			OAuthStateTTL: 2 * time.Minute,
+		})
+		router := gin.New()
+		router.GET("/auth/login", handler.InitiateOAuth)
+
+		w := performRequest(router, http.MethodGet, "/auth/login")
+		var response struct {
+			State string `json:"state"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode login response: %v", err)
+		}
+
+		claims := jwt.MapClaims{}
+		if _, err := jwt.ParseWithClaims(response.State, claims, func(*jwt.Token) (interface{}, error) {
+			return []byte(testJWTSecret), nil
+		}); err != nil {
+			t.Fatalf("Failed to parse state token: %v", err)
+		}
+		expiresAt, err := claims.GetExpirationTime()
+		if err != nil || expiresAt == nil {
+			t.Fatalf("Expected an expiration claim, got %v", err)
+		}
+		assertExpiresIn(t, expiresAt.Time, 2*time.Minute)
+	})
+
+	t.Run("environment parsing", func(t *testing.T) {
+		t.Setenv("JWT_TOKEN_TTL", "168h")
+		t.Setenv("OAUTH_STATE_TTL", "-5m")
+		cfg := config.Load()
+		if cfg.JWTTokenTTL != 168*time.Hour {
+			t.Errorf("Expected JWT_TOKEN_TTL of 168h, got %v", cfg.JWTTokenTTL)
+		}
+		if cfg.OAuthStateTTL != 10*time.Minute {
+			t.Errorf("Expected a non-positive OAUTH_STATE_TTL to fall back to 10m, got %v", cfg.OAuthStateTTL)
+		}
+	})
+}
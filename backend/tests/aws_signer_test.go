@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// signedHeadersOf extracts the SignedHeaders list from a SigV4 Authorization header
+func signedHeadersOf(authorization string) []string {
+	for _, part := range strings.Split(authorization, ", ") {
+		if list, ok := strings.CutPrefix(part, "SignedHeaders="); ok {
+			return strings.Split(list, ";")
+		}
+	}
+	return nil
+}
+
+// TestAWSV4Signer_SignsSessionToken tests that temporary credentials send the
+// security token header and include it in the signed headers
+func TestAWSV4Signer_SignsSessionToken(t *testing.T) {
+	sign := func(sessionToken string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "https://bedrock-runtime.us-east-1.amazonaws.com/model/m/invoke", strings.NewReader("{}"))
+		signer := &services.AWSV4Signer{
+			AccessKey:    "AKIDEXAMPLE",
+			SecretKey:    "secret",
+			SessionToken: sessionToken,
+			Region:       "us-east-1",
+			Service:      "bedrock",
+		}
+		if err := signer.SignRequest(req, []byte("{}")); err != nil {
+			t.Fatalf("SignRequest failed: %v", err)
+		}
+		return req
+	}
+
+	req := sign("session-token")
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "session-token" {
+		t.Errorf("Expected the X-Amz-Security-Token header, got %q", got)
+	}
+	signed := signedHeadersOf(req.Header.Get("Authorization"))
+	if strings.Join(signed, ";") != "host;x-amz-date;x-amz-security-token" {
+		t.Errorf("Expected the security token to be signed, got SignedHeaders=%v", signed)
+	}
+
+	req = sign("")
+	if req.Header.Get("X-Amz-Security-Token") != "" {
+		t.Error("Expected no security token header for long-term credentials")
+	}
+	if signed := signedHeadersOf(req.Header.Get("Authorization")); strings.Join(signed, ";") != "host;x-amz-date" {
+		t.Errorf("Expected only host and date to be signed, got %v", signed)
+	}
+}
+
+// TestBedrockService_SendsSessionToken tests that the configured session token
+// reaches Bedrock with the request
+func TestBedrockService_SendsSessionToken(t *testing.T) {
+	var token, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Amz-Security-Token")
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"content": [{"type": "text", "text": "# Slide"}]}`))
+	}))
+	defer server.Close()
+
+	service := services.NewBedrockService(&config.Config{
+		AWSRegion:          "us-east-1",
+		AWSAccessKeyID:     "ASIAEXAMPLE",
+		AWSSecretAccessKey: "secret",
+		AWSSessionToken:    "assumed-role-token",
+		BedrockModelID:     "anthropic.claude-3-haiku-20240307-v1:0",
+		BedrockEndpoint:    server.URL,
+	})
+	if _, err := service.GenerateText("Summarize"); err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+
+	if token != "assumed-role-token" {
+		t.Errorf("Expected the session token header, got %q", token)
+	}
+	if !strings.Contains(authorization, "x-amz-security-token") {
+		t.Errorf("Expected the session token in SignedHeaders, got %q", authorization)
+	}
+}
@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestBacklogMCPHelperProcess is not a real test. It is run as a subprocess by
+// the wrapper tests and acts as a minimal stdio backlog-server that echoes each
+// tool call back together with its own process ID. Tool calls are answered
+// concurrently after a short delay, so responses arrive out of order.
+func TestBacklogMCPHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_BACKLOG_MCP_HELPER") != "1" {
+		return
+	}
+
+	var writeMu sync.Mutex
+	respond := func(id int64, result interface{}) {
+		response, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": id, "result": result})
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		fmt.Fprintf(os.Stdout, "%s\n", response)
+	}
+
+	var wg sync.WaitGroup
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var request struct {
+			ID     *int64 `json:"id"`
+			Method string `json:"method"`
+			Params struct {
+				Name string `json:"name"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil || request.ID == nil {
+			continue // Notifications get no response
+		}
+
+		if request.Method != "tools/call" {
+			respond(*request.ID, map[string]interface{}{"protocolVersion": "2024-11-05"})
+			continue
+		}
+
+		wg.Add(1)
+		go func(id int64, name string) {
+			defer wg.Done()
+			time.Sleep(time.Duration(id%5) * time.Millisecond)
+			text := fmt.Sprintf(`{"tool": %q, "pid": %d}`, name, os.Getpid())
+			respond(id, map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": text}},
+			})
+		}(*request.ID, request.Params.Name)
+	}
+	wg.Wait()
+	os.Exit(0)
+}
+
+// newLocalBacklogMCPConfig returns a configuration that runs the helper process
+// as the local backlog-server
+func newLocalBacklogMCPConfig(t *testing.T) *config.Config {
+	t.Helper()
+	t.Setenv("GO_WANT_BACKLOG_MCP_HELPER", "1")
+	return &config.Config{
+		BacklogMCPCommand: os.Args[0] + " -test.run=^TestBacklogMCPHelperProcess$",
+	}
+}
+
+// TestBacklogMCPWrapper_LocalMode tests that without MCP_BACKLOG_URL, Backlog
+// tool calls are sent over stdio to a single backlog-server subprocess
+func TestBacklogMCPWrapper_LocalMode(t *testing.T) {
+	cfg := newLocalBacklogMCPConfig(t)
+	if !cfg.UsesLocalBacklogMCP() {
+		t.Fatal("Expected local mode without MCP_BACKLOG_URL")
+	}
+
+	service := services.NewMCPService(cfg)
+	t.Cleanup(func() { service.Stop() })
+
+	var pids []float64
+	for i := 0; i < 2; i++ {
+		result, err := service.GetProjects("token")
+		if err != nil {
+			t.Fatalf("GetProjects failed: %v", err)
+		}
+		data, ok := result.(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected the decoded tool result, got %T", result)
+		}
+		if data["tool"] != "get_project_list" {
+			t.Errorf("Expected the get_project_list tool to be called, got %v", data["tool"])
+		}
+		pid, _ := data["pid"].(float64)
+		pids = append(pids, pid)
+	}
+	if pids[0] == 0 || pids[0] != pids[1] {
+		t.Errorf("Expected both calls to reuse one subprocess, got pids %v", pids)
+	}
+	if pids[0] == float64(os.Getpid()) {
+		t.Error("Expected the tool calls to run in a subprocess")
+	}
+}
+
+// TestBacklogMCPWrapper_LocalModeRefusedInProduction tests that in production
+// Backlog tool calls are not sent to the local backlog-server, which would run
+// them with its own credentials instead of the user's token
+func TestBacklogMCPWrapper_LocalModeRefusedInProduction(t *testing.T) {
+	cfg := newLocalBacklogMCPConfig(t)
+	cfg.Environment = "production"
+
+	service := services.NewMCPService(cfg)
+	t.Cleanup(func() { service.Stop() })
+
+	if _, err := service.GetProjects("token"); !errors.Is(err, services.ErrLocalBacklogMCPInProduction) {
+		t.Errorf("Expected ErrLocalBacklogMCPInProduction, got %v", err)
+	}
+}
+
+// TestBacklogMCPWrapper_RoutesConcurrentSessions tests that overlapping requests
+// from several HTTP sessions each receive their own response, even when the
+// subprocess answers out of order. Run with -race to exercise response routing.
+func TestBacklogMCPWrapper_RoutesConcurrentSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	wrapper := services.NewBacklogMCPWrapper(newLocalBacklogMCPConfig(t))
+	if err := wrapper.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(func() { wrapper.Stop() })
+
+	router := gin.New()
+	router.POST("/mcp", wrapper.HandleHTTP)
+
+	const sessions, requestsPerSession = 5, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, sessions*requestsPerSession)
+	for s := 0; s < sessions; s++ {
+		for i := 0; i < requestsPerSession; i++ {
+			wg.Add(1)
+			go func(sessionID string, clientID int) {
+				defer wg.Done()
+				tool := fmt.Sprintf("%s-tool-%d", sessionID, clientID)
+				body, _ := json.Marshal(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      clientID, // Clients reuse the same IDs across sessions
+					"method":  "tools/call",
+					"params":  map[string]interface{}{"name": tool},
+				})
+				req := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader(body))
+				req.Header.Set("Mcp-Session-Id", sessionID)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				var response struct {
+					ID     int `json:"id"`
+					Result struct {
+						Content []struct {
+							Text string `json:"text"`
+						} `json:"content"`
+					} `json:"result"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || len(response.Result.Content) == 0 {
+					errs <- fmt.Errorf("%s: unexpected response %d %s", tool, w.Code, w.Body.String())
+					return
+				}
+				var echoed struct {
+					Tool string `json:"tool"`
+				}
+				json.Unmarshal([]byte(response.Result.Content[0].Text), &echoed)
+				if echoed.Tool != tool || response.ID != clientID {
+					errs <- fmt.Errorf("%s (id %d) received the response for %s (id %d)", tool, clientID, echoed.Tool, response.ID)
+				}
+			}(fmt.Sprintf("session-%d", s), i)
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// TestBedrockModelFamily tests model family detection from Bedrock model IDs
+func TestBedrockModelFamily(t *testing.T) {
+	testCases := map[string]string{
+		"anthropic.claude-3-haiku-20240307-v1:0":       services.BedrockFamilyClaudeMessages,
+		"us.anthropic.claude-3-5-sonnet-20240620-v1:0": services.BedrockFamilyClaudeMessages,
+		"anthropic.claude-v2:1":                        services.BedrockFamilyClaudeCompletion,
+		"anthropic.claude-instant-v1":                  services.BedrockFamilyClaudeCompletion,
+		"amazon.titan-text-express-v1":                 services.BedrockFamilyTitan,
+		"meta.llama3-8b-instruct-v1:0":                 services.BedrockFamilyLlama,
+		"meta.llama2-13b-chat-v1":                      services.BedrockFamilyLlama,
+		"mistral.mistral-7b-instruct-v0:2":             services.BedrockFamilyMistral,
+		"mistral.mixtral-8x7b-instruct-v0:1":           services.BedrockFamilyMistral,
+		"cohere.command-text-v14":                      "",
+	}
+	for modelID, expected := range testCases {
+		if family := services.BedrockModelFamily(modelID); family != expected {
+			t.Errorf("BedrockModelFamily(%q) = %q, expected %q", modelID, family, expected)
+		}
+	}
+}
+
+// TestBuildBedrockRequest tests request construction for each model family
+func TestBuildBedrockRequest(t *testing.T) {
+	t.Run("titan", func(t *testing.T) {
+		body, err := services.BuildBedrockRequest("amazon.titan-text-express-v1", "Summarize", services.DefaultTemperature)
+		if err != nil {
+			t.Fatalf("BuildBedrockRequest failed: %v", err)
+		}
+		var request struct {
+			InputText            string `json:"inputText"`
+			TextGenerationConfig struct {
+				MaxTokenCount int     `json:"maxTokenCount"`
+				Temperature   float64 `json:"temperature"`
+			} `json:"textGenerationConfig"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Fatalf("Failed to decode Titan request: %v", err)
+		}
+		if request.InputText != "Summarize" || request.TextGenerationConfig.MaxTokenCount == 0 {
+			t.Errorf("Unexpected Titan request: %s", body)
+		}
+	})
+
+	t.Run("llama", func(t *testing.T) {
+		body, err := services.BuildBedrockRequest("meta.llama3-8b-instruct-v1:0", "Summarize", services.DefaultTemperature)
+		if err != nil {
+			t.Fatalf("BuildBedrockRequest failed: %v", err)
+		}
+		var request struct {
+			Prompt    string `json:"prompt"`
+			MaxGenLen int    `json:"max_gen_len"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Fatalf("Failed to decode Llama request: %v", err)
+		}
+		if !strings.Contains(request.Prompt, "<|start_header_id|>user<|end_header_id|>\n\nSummarize<|eot_id|>") {
+			t.Errorf("Expected the Llama 3 chat template, got %q", request.Prompt)
+		}
+		if request.MaxGenLen == 0 {
+			t.Errorf("Expected max_gen_len to be set, got %s", body)
+		}
+
+		body, _ = services.BuildBedrockRequest("meta.llama2-13b-chat-v1", "Summarize", services.DefaultTemperature)
+		if !strings.Contains(string(body), `[INST] Summarize [/INST]`) {
+			t.Errorf("Expected the Llama 2 instruction template, got %s", body)
+		}
+	})
+
+	t.Run("mistral", func(t *testing.T) {
+		body, err := services.BuildBedrockRequest("mistral.mistral-7b-instruct-v0:2", "Summarize", services.DefaultTemperature)
+		if err != nil {
+			t.Fatalf("BuildBedrockRequest failed: %v", err)
+		}
+		var request struct {
+			Prompt    string `json:"prompt"`
+			MaxTokens int    `json:"max_tokens"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Fatalf("Failed to decode Mistral request: %v", err)
+		}
+		if request.Prompt != "<s>[INST] Summarize [/INST]" || request.MaxTokens == 0 {
+			t.Errorf("Unexpected Mistral request: %s", body)
+		}
+	})
+
+	t.Run("claude messages omit the model field", func(t *testing.T) {
+		body, err := services.BuildBedrockRequest("anthropic.claude-3-haiku-20240307-v1:0", "Summarize", services.DefaultTemperature)
+		if err != nil {
+			t.Fatalf("BuildBedrockRequest failed: %v", err)
+		}
+		if strings.Contains(string(body), `"model"`) || !strings.Contains(string(body), `"anthropic_version"`) {
+			t.Errorf("Unexpected Claude request: %s", body)
+		}
+	})
+
+	t.Run("unsupported model", func(t *testing.T) {
+		if _, err := services.BuildBedrockRequest("cohere.command-text-v14", "Summarize", services.DefaultTemperature); err == nil {
+			t.Error("Expected an error for an unsupported model")
+		}
+	})
+}
+
+// TestParseBedrockResponse tests response parsing for each model family
+func TestParseBedrockResponse(t *testing.T) {
+	testCases := []struct {
+		modelID string
+		body    string
+	}{
+		{"amazon.titan-text-express-v1", `{"inputTextTokenCount": 3, "results": [{"tokenCount": 4, "outputText": "# Slide", "completionReason": "FINISH"}]}`},
+		{"meta.llama3-8b-instruct-v1:0", `{"generation": "# Slide", "prompt_token_count": 3, "generation_token_count": 4, "stop_reason": "stop"}`},
+		{"mistral.mistral-7b-instruct-v0:2", `{"outputs": [{"text": " # Slide", "stop_reason": "stop"}]}`},
+		{"anthropic.claude-3-haiku-20240307-v1:0", `{"content": [{"type": "text", "text": "# Slide"}]}`},
+		{"anthropic.claude-v2:1", `{"completion": "# Slide", "stop_reason": "stop_sequence"}`},
+	}
+	for _, tc := range testCases {
+		text, err := services.ParseBedrockResponse(tc.modelID, []byte(tc.body))
+		if err != nil {
+			t.Errorf("ParseBedrockResponse(%s) failed: %v", tc.modelID, err)
+			continue
+		}
+		if text != "# Slide" {
+			t.Errorf("ParseBedrockResponse(%s) = %q, expected %q", tc.modelID, text, "# Slide")
+		}
+	}
+
+	if _, err := services.ParseBedrockResponse("amazon.titan-text-express-v1", []byte(`{"results": []}`)); err == nil {
+		t.Error("Expected an error for a Titan response without results")
+	}
+}
+
+// TestBedrockService_GenerateTextWithLlama tests a full InvokeModel round trip
+// using the Llama request and response schema
+func TestBedrockService_GenerateTextWithLlama(t *testing.T) {
+	var requestBody string
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestBody = string(body)
+		requestPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"generation": "# Llama Slide", "stop_reason": "stop"}`))
+	}))
+	defer server.Close()
+
+	service := services.NewBedrockService(&config.Config{
+		AWSRegion:          "us-east-1",
+		AWSAccessKeyID:     "AKIDEXAMPLE",
+		AWSSecretAccessKey: "secret",
+		BedrockModelID:     "meta.llama3-8b-instruct-v1:0",
+		BedrockEndpoint:    server.URL,
+	})
+
+	text, err := service.GenerateText("Summarize")
+	if err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+	if text != "# Llama Slide" {
+		t.Errorf("Expected the Llama generation, got %q", text)
+	}
+	if requestPath != "/model/meta.llama3-8b-instruct-v1:0/invoke" {
+		t.Errorf("Unexpected request path %q", requestPath)
+	}
+	if !strings.Contains(requestBody, `"max_gen_len"`) {
+		t.Errorf("Expected a Llama request body, got %s", requestBody)
+	}
+}
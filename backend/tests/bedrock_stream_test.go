@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/internal/services"
+)
+
+// TestClaudeStreamDecoder_AssemblesCompletion tests that streamed Claude chunks
+// are assembled into the full completion, with each text delta reported in order
+func TestClaudeStreamDecoder_AssemblesCompletion(t *testing.T) {
+	chunks := []string{
+		`{"type": "message_start", "message": {"id": "msg_1", "role": "assistant", "content": []}}`,
+		`{"type": "content_block_start", "index": 0, "content_block": {"type": "text", "text": ""}}`,
+		`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "# Project "}}`,
+		`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Overview\n"}}`,
+		`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "- 完了率 80%"}}`,
+		`{"type": "content_block_stop", "index": 0}`,
+		`{"type": "message_delta", "delta": {"stop_reason": "end_turn"}, "usage": {"output_tokens": 12}}`,
+		`{"type": "message_stop"}`,
+	}
+
+	var decoder services.ClaudeStreamDecoder
+	var deltas []string
+	for _, chunk := range chunks {
+		delta, err := decoder.Decode([]byte(chunk))
+		if err != nil {
+			t.Fatalf("Decode(%s) failed: %v", chunk, err)
+		}
+		if delta != "" {
+			deltas = append(deltas, delta)
+		}
+	}
+
+	expected := "# Project Overview\n- 完了率 80%"
+	if decoder.Text() != expected {
+		t.Errorf("Expected completion %q, got %q", expected, decoder.Text())
+	}
+	if len(deltas) != 3 || strings.Join(deltas, "") != expected {
+		t.Errorf("Expected three deltas forming the completion, got %q", deltas)
+	}
+}
+
+// TestClaudeStreamDecoder_ReportsErrors tests that malformed chunks and error
+// events fail decoding
+func TestClaudeStreamDecoder_ReportsErrors(t *testing.T) {
+	var decoder services.ClaudeStreamDecoder
+	if _, err := decoder.Decode([]byte(`{"type": "content_block_delta"`)); err == nil {
+		t.Error("Expected an error for a malformed chunk")
+	}
+	_, err := decoder.Decode([]byte(`{"type": "error", "error": {"type": "overloaded_error", "message": "Overloaded"}}`))
+	if err == nil || !strings.Contains(err.Error(), "Overloaded") {
+		t.Errorf("Expected the stream error message, got %v", err)
+	}
+}
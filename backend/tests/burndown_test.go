@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/services"
+)
+
+// TestComputeBurndown_SeriesShape tests that the burndown covers consecutive days,
+// only decreases while issues are being closed, steps up when a new issue is
+// created, and ends at the number of unfinished issues
+func TestComputeBurndown_SeriesShape(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	var issues []interface{}
+	err := json.Unmarshal([]byte(`[
+		{"issueKey": "TEST-1", "status": {"id": 4}, "created": "2024-03-01T09:00:00Z", "updated": "2024-03-03T10:00:00Z"},
+		{"issueKey": "TEST-2", "status": {"id": 4}, "created": "2024-03-01T09:00:00Z", "updated": "2024-03-05T10:00:00Z"},
+		{"issueKey": "TEST-3", "status": {"id": 4}, "created": "2024-03-01T09:00:00Z", "updated": "2024-03-05T18:00:00Z"},
+		{"issueKey": "TEST-4", "status": {"id": 2}, "created": "2024-03-01T09:00:00Z", "updated": "2024-03-09T10:00:00Z"},
+		{"issueKey": "TEST-5", "status": {"id": 1}, "created": "2024-03-08T09:00:00Z", "updated": "2024-03-08T09:00:00Z"},
+		{"issueKey": "TEST-6", "status": {"id": 4}, "created": "2024-03-08T09:00:00Z", "updated": "2024-03-09T12:00:00Z"}
+	]`), &issues)
+	if err != nil {
+		t.Fatalf("Failed to parse test issues: %v", err)
+	}
+
+	series := services.ComputeBurndown(issues, 30, now)
+	if series == nil {
+		t.Fatal("Expected a burndown series")
+	}
+	if len(series.Labels) != 10 || len(series.Remaining) != len(series.Labels) {
+		t.Fatalf("Expected 10 daily points from 2024-03-01 to 2024-03-10, got %d labels and %d values", len(series.Labels), len(series.Remaining))
+	}
+	if series.Labels[0] != "2024-03-01" || series.Labels[len(series.Labels)-1] != "2024-03-10" {
+		t.Errorf("Unexpected series range: %s to %s", series.Labels[0], series.Labels[len(series.Labels)-1])
+	}
+	for i := 1; i < len(series.Labels); i++ {
+		previous, _ := time.Parse("2006-01-02", series.Labels[i-1])
+		current, _ := time.Parse("2006-01-02", series.Labels[i])
+		if current.Sub(previous) != 24*time.Hour {
+			t.Errorf("Expected consecutive days, got %s after %s", series.Labels[i], series.Labels[i-1])
+		}
+	}
+
+	// Before new issues arrive on 2024-03-08 the series never increases
+	for i := 1; i < 7; i++ {
+		if series.Remaining[i] > series.Remaining[i-1] {
+			t.Errorf("Expected remaining issues not to increase on %s, got %v", series.Labels[i], series.Remaining)
+		}
+	}
+
+	expected := []int{4, 4, 3, 3, 1, 1, 1, 3, 2, 2}
+	for i, value := range expected {
+		if series.Remaining[i] != value {
+			t.Errorf("Expected %d remaining on %s, got %d", value, series.Labels[i], series.Remaining[i])
+		}
+	}
+
+	// The window is capped to the most recent days
+	capped := services.ComputeBurndown(issues, 5, now)
+	if len(capped.Labels) != 5 || capped.Labels[0] != "2024-03-06" {
+		t.Errorf("Expected a 5-day window starting 2024-03-06, got %v", capped.Labels)
+	}
+
+	if services.ComputeBurndown(nil, 30, now) != nil {
+		t.Error("Expected no series without issues")
+	}
+
+	chart := services.BurndownChartConfig(series, "en")
+	configJSON, _ := json.Marshal(chart)
+	if chart["type"] != "line" || !strings.Contains(string(configJSON), `"data":[4,4,3,3,1,1,1,3,2,2]`) {
+		t.Errorf("Unexpected chart config: %s", configJSON)
+	}
+}
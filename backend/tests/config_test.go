@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// validProductionConfig returns a production configuration that passes validation
+func validProductionConfig() *config.Config {
+	return &config.Config{
+		Environment:   "production",
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "sk-test",
+		MCPBacklogURL: "http://backlog-mcp:3001",
+		MCPSpeechURL:  "http://speech-mcp:3002",
+		JWTSecret:     "a-strong-secret",
+	}
+}
+
+// TestConfigValidate tests that production configurations with missing or
+// invalid settings are rejected with every problem reported
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*config.Config)
+		expected []string
+	}{
+		{
+			name:   "valid production config",
+			modify: func(c *config.Config) {},
+		},
+		{
+			name: "development mode accepts defaults",
+			modify: func(c *config.Config) {
+				c.Environment = "debug"
+				c.OpenAIAPIKey = ""
+				c.JWTSecret = "intelligent-presenter-secret-key"
+			},
+		},
+		{
+			name:     "default JWT secret",
+			modify:   func(c *config.Config) { c.JWTSecret = "intelligent-presenter-secret-key" },
+			expected: []string{"JWT_SECRET"},
+		},
+		{
+			name:     "missing OpenAI key",
+			modify:   func(c *config.Config) { c.OpenAIAPIKey = "" },
+			expected: []string{"OPENAI_API_KEY"},
+		},
+		{
+			name: "bedrock without AWS credentials",
+			modify: func(c *config.Config) {
+				c.AIProvider = "bedrock"
+				c.AWSRegion = "ap-northeast-1"
+				c.AWSAccessKeyID = "AKIA"
+			},
+			expected: []string{"AWS_SECRET_ACCESS_KEY"},
+		},
+		{
+			name:     "gemini without API key",
+			modify:   func(c *config.Config) { c.AIProvider = "gemini" },
+			expected: []string{"GEMINI_API_KEY"},
+		},
+		{
+			name:     "anthropic without API key",
+			modify:   func(c *config.Config) { c.AIProvider = "anthropic" },
+			expected: []string{"ANTHROPIC_API_KEY"},
+		},
+		{
+			name:     "unknown provider",
+			modify:   func(c *config.Config) { c.AIProvider = "llama" },
+			expected: []string{"AI_PROVIDER"},
+		},
+		{
+			name: "invalid MCP URLs",
+			modify: func(c *config.Config) {
+				c.MCPBacklogURL = "backlog-mcp:3001"
+				c.MCPSpeechURL = ""
+			},
+			expected: []string{"MCP_BACKLOG_URL", "MCP_SPEECH_URL"},
+		},
+		{
+			name: "local backlog-server in production",
+			modify: func(c *config.Config) {
+				c.MCPBacklogURL = ""
+				c.BacklogMCPCommand = "./backlog-server/backlog-mcp-server"
+			},
+			expected: []string{"BACKLOG_MCP_COMMAND"},
+		},
+		{
+			name: "unknown oversize data mode",
+			modify: func(c *config.Config) {
+				c.OnOversizeData = "drop"
+			},
+			expected: []string{"ON_OVERSIZE_DATA"},
+		},
+		{
+			name: "multiple problems are aggregated",
+			modify: func(c *config.Config) {
+				c.Environment = "release"
+				c.JWTSecret = ""
+				c.OpenAIAPIKey = ""
+				c.MCPSpeechURL = "ftp://speech"
+			},
+			expected: []string{"JWT_SECRET", "OPENAI_API_KEY", "MCP_SPEECH_URL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validProductionConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+
+			if len(tt.expected) == 0 {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error mentioning %v, got nil", tt.expected)
+			}
+			for _, name := range tt.expected {
+				if !strings.Contains(err.Error(), name) {
+					t.Errorf("Expected error to mention %s, got %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+// TestDefaultThemeOrder_CoversEveryTheme tests that the default deck flow
+// places every built-in theme exactly once
+func TestDefaultThemeOrder_CoversEveryTheme(t *testing.T) {
+	seen := make(map[models.SlideTheme]int)
+	for _, theme := range config.DefaultThemeOrder {
+		seen[models.SlideTheme(theme)]++
+	}
+	for _, theme := range models.AllSlideThemes {
+		if seen[theme] != 1 {
+			t.Errorf("Expected %s once in the default theme order, got %d", theme, seen[theme])
+		}
+	}
+	if len(config.DefaultThemeOrder) != len(models.AllSlideThemes) {
+		t.Errorf("Expected %d themes in the default order, got %v", len(models.AllSlideThemes), config.DefaultThemeOrder)
+	}
+}
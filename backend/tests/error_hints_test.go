@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/internal/services"
+)
+
+// TestRemediationHint tests that common generation errors map to user-facing
+// hints, including when wrapped, and that unknown errors get no hint
+func TestRemediationHint(t *testing.T) {
+	credentialsErr := fmt.Errorf("failed to generate markdown: %w", errors.New("AWS credentials not configured"))
+	if hint := services.RemediationHint(credentialsErr); !strings.Contains(hint, "Ask your administrator to configure an AI provider") {
+		t.Errorf("Expected the credentials error to suggest configuring an AI provider, got %q", hint)
+	}
+
+	tests := map[string]struct {
+		err      error
+		contains string
+	}{
+		"data too large": {fmt.Errorf("slide 1: %w", services.ErrDataTooLarge), "fewer themes"},
+		"rate limited":   {&services.BacklogToolError{Tool: "get_issues", StatusCode: http.StatusTooManyRequests}, "rate limit"},
+		"forbidden":      {&services.BacklogToolError{Tool: "get_issues", StatusCode: http.StatusForbidden}, "space administrator"},
+		"speech server":  {errors.New("failed to call speech server: connection refused"), "speech server"},
+		"no speech URL":  {services.ErrSpeechNotConfigured, "MCP_SPEECH_URL"},
+	}
+	for name, tt := range tests {
+		if hint := services.RemediationHint(tt.err); !strings.Contains(hint, tt.contains) {
+			t.Errorf("%s: expected a hint containing %q, got %q", name, tt.contains, hint)
+		}
+	}
+
+	if hint := services.RemediationHint(errors.New("unexpected end of input")); hint != "" {
+		t.Errorf("Expected no hint for an unknown error, got %q", hint)
+	}
+	if hint := services.RemediationHint(nil); hint != "" {
+		t.Errorf("Expected no hint without an error, got %q", hint)
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// TestGeminiService_GenerateText tests Gemini request construction and response parsing
+func TestGeminiService_GenerateText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/gemini-test:generateContent" {
+			t.Errorf("Unexpected request path: %s", r.URL.Path)
+		}
+		if r.Header.Get("x-goog-api-key") != "test-gemini-key" {
+			t.Errorf("Expected API key header, got %q", r.Header.Get("x-goog-api-key"))
+		}
+
+		var req services.GeminiRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode Gemini request: %v", err)
+		}
+		if len(req.Contents) != 1 || len(req.Contents[0].Parts) != 1 || req.Contents[0].Parts[0].Text != "Generate a slide" {
+			t.Errorf("Unexpected request contents: %+v", req.Contents)
+		}
+		if req.Contents[0].Role != "user" {
+			t.Errorf("Expected user role, got %q", req.Contents[0].Role)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"candidates": [{
+				"content": {"role": "model", "parts": [{"text": "# Project Overview\n"}, {"text": "- Status: on track"}]},
+				"finishReason": "STOP"
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	service := services.NewGeminiService(&config.Config{
+		GeminiAPIKey:  "test-gemini-key",
+		GeminiModel:   "gemini-test",
+		GeminiBaseURL: server.URL,
+	})
+
+	text, err := service.GenerateText("Generate a slide")
+	if err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+	if text != "# Project Overview\n- Status: on track" {
+		t.Errorf("Unexpected generated text: %q", text)
+	}
+}
+
+// TestGeminiService_ErrorResponses tests that API errors and blocked prompts are reported
+func TestGeminiService_ErrorResponses(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{
+			name:   "API error",
+			status: http.StatusBadRequest,
+			body:   `{"error": {"code": 400, "message": "API key not valid", "status": "INVALID_ARGUMENT"}}`,
+		},
+		{
+			name:   "Blocked prompt",
+			status: http.StatusOK,
+			body:   `{"promptFeedback": {"blockReason": "SAFETY"}}`,
+		},
+		{
+			name:   "No candidates",
+			status: http.StatusOK,
+			body:   `{"candidates": []}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			service := services.NewGeminiService(&config.Config{
+				GeminiAPIKey:     "test-gemini-key",
+				GeminiBaseURL:    server.URL,
+				AIRetryBaseDelay: time.Millisecond,
+			})
+
+			if _, err := service.GenerateText("Generate a slide"); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+
+	if _, err := services.NewGeminiService(&config.Config{}).GenerateText("prompt"); err == nil {
+		t.Error("Expected error when Gemini API key is not configured")
+	}
+}
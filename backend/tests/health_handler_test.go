@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"intelligent-presenter-backend/internal/api/handlers"
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newHealthServer creates a downstream server whose /health answers with status
+func newHealthServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			t.Errorf("Unexpected health check path: %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestHealthHandler_Ready tests that the readiness probe reports each
+// dependency and fails with 503 when any of them is down
+func TestHealthHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	up := newHealthServer(t, http.StatusOK)
+	failing := newHealthServer(t, http.StatusInternalServerError)
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	tests := []struct {
+		name         string
+		config       config.Config
+		expectedCode int
+		expected     map[string]string
+	}{
+		{
+			name:         "all dependencies up",
+			config:       config.Config{MCPBacklogURL: up.URL, MCPSpeechURL: up.URL, AIProvider: "openai", OpenAIAPIKey: "key"},
+			expectedCode: http.StatusOK,
+			expected:     map[string]string{"backlogMCP": "up", "speech": "up", "aiProvider": "up"},
+		},
+		{
+			name:         "speech server unreachable",
+			config:       config.Config{MCPBacklogURL: up.URL, MCPSpeechURL: unreachable.URL, AIProvider: "openai", OpenAIAPIKey: "key"},
+			expectedCode: http.StatusServiceUnavailable,
+			expected:     map[string]string{"backlogMCP": "up", "speech": "down", "aiProvider": "up"},
+		},
+		{
+			name:         "Backlog MCP server unhealthy",
+			config:       config.Config{MCPBacklogURL: failing.URL, MCPSpeechURL: up.URL, AIProvider: "openai", OpenAIAPIKey: "key"},
+			expectedCode: http.StatusServiceUnavailable,
+			expected:     map[string]string{"backlogMCP": "down", "speech": "up", "aiProvider": "up"},
+		},
+		{
+			name:         "AI provider without credentials",
+			config:       config.Config{MCPBacklogURL: up.URL, MCPSpeechURL: up.URL, AIProvider: "gemini"},
+			expectedCode: http.StatusServiceUnavailable,
+			expected:     map[string]string{"backlogMCP": "up", "speech": "up", "aiProvider": "down"},
+		},
+		{
+			name:         "speech not required when audio is disabled",
+			config:       config.Config{MCPBacklogURL: up.URL, MCPSpeechURL: unreachable.URL, AIProvider: "openai", OpenAIAPIKey: "key", DisableAudio: true},
+			expectedCode: http.StatusOK,
+			expected:     map[string]string{"backlogMCP": "up", "speech": "disabled", "aiProvider": "up"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.config
+			router := gin.New()
+			router.GET("/health/ready", handlers.NewHealthHandler(&cfg).Ready)
+
+			w := performRequest(router, http.MethodGet, "/health/ready")
+			if w.Code != tt.expectedCode {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedCode, w.Code, w.Body.String())
+			}
+
+			var response struct {
+				Dependencies map[string]handlers.DependencyStatus `json:"dependencies"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			for name, status := range tt.expected {
+				if got := response.Dependencies[name]; got.Status != status {
+					t.Errorf("Expected %s to be %s, got %+v", name, status, got)
+				}
+				if status == "down" && response.Dependencies[name].Error == "" {
+					t.Errorf("Expected an error message for %s", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/internal/services"
+)
+
+// TestBuildIssueHierarchy_ReportsCycles tests that a cyclic parent chain is
+// reported and broken instead of looping, while valid trees are kept intact
+func TestBuildIssueHierarchy_ReportsCycles(t *testing.T) {
+	var issues []interface{}
+	err := json.Unmarshal([]byte(`[
+		{"id": 1, "issueKey": "TEST-1", "summary": "Epic", "parentIssueId": null},
+		{"id": 2, "issueKey": "TEST-2", "summary": "Story", "parentIssueId": 1},
+		{"id": 3, "issueKey": "TEST-3", "summary": "Loop A", "parentIssueId": 5},
+		{"id": 4, "issueKey": "TEST-4", "summary": "Loop B", "parentIssueId": 3},
+		{"id": 5, "issueKey": "TEST-5", "summary": "Loop C", "parentIssueId": 4},
+		{"id": 6, "issueKey": "TEST-6", "summary": "Hangs off the loop", "parentIssueId": 4},
+		{"id": 7, "issueKey": "TEST-7", "summary": "Self parent", "parentIssueId": 7},
+		{"id": 8, "issueKey": "TEST-8", "summary": "Orphan", "parentIssueId": 99}
+	]`), &issues)
+	if err != nil {
+		t.Fatalf("Failed to parse test issues: %v", err)
+	}
+
+	hierarchy := services.BuildIssueHierarchy(issues)
+
+	expectedCycles := [][]string{{"TEST-3", "TEST-5", "TEST-4"}, {"TEST-7"}}
+	if !reflect.DeepEqual(hierarchy.Cycles, expectedCycles) {
+		t.Errorf("Expected cycles %v, got %v", expectedCycles, hierarchy.Cycles)
+	}
+
+	var rootKeys []string
+	for _, root := range hierarchy.Roots {
+		rootKeys = append(rootKeys, root.IssueKey)
+	}
+	if expected := []string{"TEST-1", "TEST-3", "TEST-7", "TEST-8"}; !reflect.DeepEqual(rootKeys, expected) {
+		t.Errorf("Expected roots %v, got %v", expected, rootKeys)
+	}
+
+	// Every issue appears exactly once when walking the trees
+	seen := make(map[string]int)
+	var walk func(nodes []*models.IssueNode)
+	walk = func(nodes []*models.IssueNode) {
+		for _, node := range nodes {
+			seen[node.IssueKey]++
+			if seen[node.IssueKey] > 1 {
+				t.Fatalf("Issue %s reached twice", node.IssueKey)
+			}
+			walk(node.Children)
+		}
+	}
+	walk(hierarchy.Roots)
+	if len(seen) != len(issues) {
+		t.Errorf("Expected all %d issues in the trees, got %d", len(issues), len(seen))
+	}
+	if children := hierarchy.Roots[0].Children; len(children) != 1 || children[0].IssueKey != "TEST-2" {
+		t.Errorf("Expected TEST-2 under TEST-1, got %+v", children)
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/internal/services"
+)
+
+// TestComputeIssueStats_ExcludesDuplicates tests that duplicate-resolution issues
+// are excluded from the completion calculation when configured
+func TestComputeIssueStats_ExcludesDuplicates(t *testing.T) {
+	var issues []interface{}
+	err := json.Unmarshal([]byte(`[
+		{"issueKey": "TEST-1", "status": {"id": 4, "name": "完了"}, "resolution": {"id": 0, "name": "対応済み"}},
+		{"issueKey": "TEST-2", "status": {"id": 2, "name": "処理中"}, "resolution": null},
+		{"issueKey": "TEST-3", "status": {"id": 4, "name": "完了"}, "resolution": {"id": 3, "name": "重複"}},
+		{"issueKey": "TEST-4", "status": {"id": 4, "name": "Closed"}, "resolution": {"id": 3, "name": "Duplicate"}}
+	]`), &issues)
+	if err != nil {
+		t.Fatalf("Failed to parse test issues: %v", err)
+	}
+
+	stats, counted := services.ComputeIssueStats(issues, true, time.Now())
+	if stats.TotalIssues != 2 || stats.CompletedIssues != 1 || stats.ExcludedDuplicates != 2 {
+		t.Errorf("Unexpected stats with duplicates excluded: %+v", stats)
+	}
+	if stats.CompletionRate != 50 {
+		t.Errorf("Expected completion rate 50, got %v", stats.CompletionRate)
+	}
+	if len(counted) != 2 {
+		t.Errorf("Expected 2 counted issues, got %d", len(counted))
+	}
+
+	stats, counted = services.ComputeIssueStats(issues, false, time.Now())
+	if stats.TotalIssues != 4 || stats.CompletedIssues != 3 || stats.ExcludedDuplicates != 0 {
+		t.Errorf("Unexpected stats with duplicates included: %+v", stats)
+	}
+	if stats.CompletionRate != 75 {
+		t.Errorf("Expected completion rate 75, got %v", stats.CompletionRate)
+	}
+	if len(counted) != 4 {
+		t.Errorf("Expected 4 counted issues, got %d", len(counted))
+	}
+}
+
+// TestComputeIssueStats_StatusCategoriesAndOverdue tests tallying issues by status
+// category, the completion percentage, and overdue detection based on due dates
+func TestComputeIssueStats_StatusCategoriesAndOverdue(t *testing.T) {
+	var issues []interface{}
+	err := json.Unmarshal([]byte(`[
+		{"issueKey": "TEST-1", "status": {"id": 1, "name": "未対応"}, "dueDate": "2024-05-01T00:00:00Z"},
+		{"issueKey": "TEST-2", "status": {"id": 1, "name": "未対応"}, "dueDate": null},
+		{"issueKey": "TEST-3", "status": {"id": 2, "name": "処理中"}, "dueDate": "2024-06-30T00:00:00Z"},
+		{"issueKey": "TEST-4", "status": {"id": 3, "name": "処理済み"}, "dueDate": "2024-05-15T00:00:00Z"},
+		{"issueKey": "TEST-5", "status": {"id": 4, "name": "完了"}, "dueDate": "2024-04-01T00:00:00Z"},
+		{"issueKey": "TEST-6", "status": {"id": 4, "name": "完了"}},
+		{"issueKey": "TEST-7", "status": {"id": 12345, "name": "レビュー中"}, "dueDate": "2024-06-01T00:00:00Z"},
+		{"issueKey": "TEST-8", "status": {"id": 2, "name": "処理中"}, "dueDate": "2024-06-01T00:00:00Z"}
+	]`), &issues)
+	if err != nil {
+		t.Fatalf("Failed to parse test issues: %v", err)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stats, _ := services.ComputeIssueStats(issues, false, now)
+
+	if stats.TotalIssues != 8 {
+		t.Errorf("Expected 8 issues, got %d", stats.TotalIssues)
+	}
+	if stats.OpenIssues != 2 || stats.InProgressIssues != 4 || stats.CompletedIssues != 2 {
+		t.Errorf("Unexpected status categories: open=%d inProgress=%d closed=%d",
+			stats.OpenIssues, stats.InProgressIssues, stats.CompletedIssues)
+	}
+	if stats.CompletionRate != 25 {
+		t.Errorf("Expected completion rate 25, got %v", stats.CompletionRate)
+	}
+	// TEST-1 and TEST-4 are past due; closed TEST-5 and TEST-7/TEST-8 due today are not overdue
+	if stats.OverdueIssues != 2 {
+		t.Errorf("Expected 2 overdue issues, got %d", stats.OverdueIssues)
+	}
+
+	empty, _ := services.ComputeIssueStats(nil, false, now)
+	if empty.TotalIssues != 0 || empty.CompletionRate != 0 {
+		t.Errorf("Expected zero stats for no issues, got %+v", empty)
+	}
+}
+
+// TestComparePeriodStats tests that the delta between two periods is the
+// current period's statistics minus the previous period's
+func TestComparePeriodStats(t *testing.T) {
+	previous := &models.IssueStats{
+		TotalIssues:      10,
+		OpenIssues:       4,
+		InProgressIssues: 3,
+		CompletedIssues:  3,
+		OverdueIssues:    2,
+		CompletionRate:   30,
+	}
+	current := &models.IssueStats{
+		TotalIssues:      8,
+		OpenIssues:       1,
+		InProgressIssues: 3,
+		CompletedIssues:  4,
+		OverdueIssues:    3,
+		CompletionRate:   50,
+	}
+
+	delta := services.ComparePeriodStats(previous, current)
+	expected := models.IssueStatsDelta{
+		TotalIssues:      -2,
+		OpenIssues:       -3,
+		InProgressIssues: 0,
+		CompletedIssues:  1,
+		OverdueIssues:    1,
+		CompletionRate:   20,
+	}
+	if delta != expected {
+		t.Errorf("Expected delta %+v, got %+v", expected, delta)
+	}
+
+	if reversed := services.ComparePeriodStats(current, previous); reversed.CompletedIssues != -1 || reversed.CompletionRate != -20 {
+		t.Errorf("Expected the reversed comparison to negate the delta, got %+v", reversed)
+	}
+}
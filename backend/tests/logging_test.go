@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"intelligent-presenter-backend/pkg/logging"
+)
+
+// TestLogging_RedactsSensitiveFields tests that tokens and API keys never reach
+// the log output, including inside logged payloads
+func TestLogging_RedactsSensitiveFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(logging.NewHandler(&buf, slog.LevelDebug))
+
+	logger.Info("Calling tool",
+		"accessToken", "secret-access",
+		"api_key", "secret-key",
+		"args", map[string]interface{}{
+			"projectIdOrKey": "TEST",
+			"auth":           map[string]interface{}{"refreshToken": "secret-refresh"},
+		},
+	)
+
+	output := buf.String()
+	for _, secret := range []string{"secret-access", "secret-key", "secret-refresh"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, output)
+		}
+	}
+	if !strings.Contains(output, "TEST") {
+		t.Errorf("Expected non-sensitive fields to be kept, got %s", output)
+	}
+
+	redacted := logging.RedactMap(map[string]interface{}{"Authorization": "Bearer x", "count": 20})
+	if redacted["Authorization"] == "Bearer x" || redacted["count"] != 20 {
+		t.Errorf("Unexpected RedactMap result: %v", redacted)
+	}
+}
+
+// TestLogging_ParseLevel tests LOG_LEVEL parsing and level filtering
+func TestLogging_ParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"INFO":    slog.LevelInfo,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+		"":        slog.LevelInfo,
+		"verbose": slog.LevelInfo,
+	}
+	for value, expected := range tests {
+		if level := logging.ParseLevel(value); level != expected {
+			t.Errorf("ParseLevel(%q) = %v, expected %v", value, level, expected)
+		}
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(logging.NewHandler(&buf, logging.ParseLevel("warn")))
+	logger.Info("hidden")
+	logger.Warn("shown")
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "shown") {
+		t.Errorf("Expected only warn and above to be logged, got %s", buf.String())
+	}
+}
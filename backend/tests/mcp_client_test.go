@@ -0,0 +1,196 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/mcp"
+)
+
+// TestMCPClient_ConcurrentCallTool tests that concurrent tool calls receive
+// distinct request IDs and share the session ID without data races.
+// Run with -race to exercise the session ID guard.
+func TestMCPClient_ConcurrentCallTool(t *testing.T) {
+	var mu sync.Mutex
+	seenIDs := make(map[string]bool)
+	duplicates := 0
+	missingSession := 0
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request mcp.MCPRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id := fmt.Sprint(request.ID)
+
+		mu.Lock()
+		requests++
+		if seenIDs[id] {
+			duplicates++
+		}
+		seenIDs[id] = true
+		if requests > 1 && r.Header.Get("Mcp-Session-Id") != "session-1" {
+			missingSession++
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Mcp-Session-Id", "session-1")
+		json.NewEncoder(w).Encode(mcp.MCPResponse{
+			JSONRPC: "2.0",
+			ID:      request.ID,
+			Result:  json.RawMessage(`{"content": []}`),
+		})
+	}))
+	defer server.Close()
+
+	client := mcp.NewMCPClient(server.URL)
+	ctx := context.Background()
+
+	// Establish the session first so that every later call should carry it
+	if _, err := client.CallTool(ctx, "get_space", nil); err != nil {
+		t.Fatalf("Initial CallTool failed: %v", err)
+	}
+
+	const callers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.CallTool(ctx, "get_projects", map[string]interface{}{}); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("CallTool failed: %v", err)
+	}
+	if requests != callers+1 {
+		t.Errorf("Expected %d requests, got %d", callers+1, requests)
+	}
+	if duplicates != 0 {
+		t.Errorf("Expected unique request IDs, got %d duplicates", duplicates)
+	}
+	if missingSession != 0 {
+		t.Errorf("Expected every follow-up request to carry the session ID, %d did not", missingSession)
+	}
+}
+
+// TestMCPClient_ReconnectsAfterLostSession tests that a request answered with
+// 404 for an unknown session re-runs the handshake and is resent on the new session
+func TestMCPClient_ReconnectsAfterLostSession(t *testing.T) {
+	var mu sync.Mutex
+	initializations := 0
+	activeSession := ""
+	droppedFirstSession := false
+	var toolSessions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request mcp.MCPRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch request.Method {
+		case "initialize":
+			initializations++
+			activeSession = fmt.Sprintf("session-%d", initializations)
+			w.Header().Set("Mcp-Session-Id", activeSession)
+		case "notifications/initialized":
+		default:
+			// Simulate a server restart by forgetting the first session once
+			if !droppedFirstSession {
+				droppedFirstSession = true
+				activeSession = ""
+			}
+			if r.Header.Get("Mcp-Session-Id") != activeSession || activeSession == "" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": "session not found"}`))
+				return
+			}
+			toolSessions = append(toolSessions, activeSession)
+		}
+		json.NewEncoder(w).Encode(mcp.MCPResponse{JSONRPC: "2.0", ID: request.ID, Result: json.RawMessage(`{}`)})
+	}))
+	defer server.Close()
+
+	client := mcp.NewMCPClient(server.URL)
+	ctx := context.Background()
+	if err := client.Initialize(ctx, map[string]interface{}{"name": "test"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	if _, err := client.CallTool(ctx, "get_space", nil); err != nil {
+		t.Fatalf("Expected CallTool to recover from the lost session, got %v", err)
+	}
+	if initializations != 2 {
+		t.Errorf("Expected the handshake to run again after the session was lost, got %d initializations", initializations)
+	}
+	if len(toolSessions) != 1 || toolSessions[0] != "session-2" {
+		t.Errorf("Expected the tool call to be resent on session-2, got %v", toolSessions)
+	}
+}
+
+// TestMCPClient_RetriesServerErrors tests that 5xx responses are retried up to
+// the configured number of attempts
+func TestMCPClient_RetriesServerErrors(t *testing.T) {
+	newFlakyServer := func(failures int) (*httptest.Server, *int) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request mcp.MCPRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			attempts++
+			if attempts <= failures {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mcp.MCPResponse{JSONRPC: "2.0", ID: request.ID, Result: json.RawMessage(`{}`)})
+		}))
+		t.Cleanup(server.Close)
+		return server, &attempts
+	}
+
+	t.Run("recovers within the attempt budget", func(t *testing.T) {
+		server, attempts := newFlakyServer(2)
+		client := mcp.NewMCPClient(server.URL)
+		client.SetRetryPolicy(3, time.Millisecond)
+
+		if _, err := client.CallTool(context.Background(), "get_space", nil); err != nil {
+			t.Fatalf("Expected CallTool to succeed after retries, got %v", err)
+		}
+		if *attempts != 3 {
+			t.Errorf("Expected 3 attempts, got %d", *attempts)
+		}
+	})
+
+	t.Run("gives up after the attempt budget", func(t *testing.T) {
+		server, attempts := newFlakyServer(5)
+		client := mcp.NewMCPClient(server.URL)
+		client.SetRetryPolicy(2, time.Millisecond)
+
+		if _, err := client.CallTool(context.Background(), "get_space", nil); err == nil {
+			t.Fatal("Expected CallTool to fail once retries are exhausted")
+		}
+		if *attempts != 2 {
+			t.Errorf("Expected 2 attempts, got %d", *attempts)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/api/handlers"
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestMCPHandler_GetAudioFileForwardsRange tests that a ranged audio request is
+// forwarded to the speech server and answered with 206 Partial Content
+func TestMCPHandler_GetAudioFileForwardsRange(t *testing.T) {
+	audio := bytes.Repeat([]byte("0123456789"), 100)
+	speechServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cache/test.wav" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, "test.wav", time.Time{}, bytes.NewReader(audio))
+	}))
+	defer speechServer.Close()
+
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewMCPHandler(&config.Config{MCPSpeechURL: speechServer.URL})
+	router := gin.New()
+	router.GET("/cache/:filename", handler.GetAudioFile)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/cache/test.wav", nil)
+	req.Header.Set("Range", "bytes=100-199")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPartialContent {
+		t.Fatalf("Expected 206, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentRange := w.Header().Get("Content-Range"); contentRange != "bytes 100-199/1000" {
+		t.Errorf("Expected Content-Range bytes 100-199/1000, got %q", contentRange)
+	}
+	if acceptRanges := w.Header().Get("Accept-Ranges"); acceptRanges != "bytes" {
+		t.Errorf("Expected Accept-Ranges bytes, got %q", acceptRanges)
+	}
+	if !bytes.Equal(w.Body.Bytes(), audio[100:200]) {
+		t.Errorf("Expected the requested byte range, got %d bytes", w.Body.Len())
+	}
+
+	w = performRequest(router, http.MethodGet, "/cache/test.wav")
+	if w.Code != http.StatusOK || w.Body.Len() != len(audio) {
+		t.Errorf("Expected the whole file without a Range header, got %d with %d bytes", w.Code, w.Body.Len())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/cache/test.wav", nil)
+	req.Header.Set("Range", "bytes=5000-")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestedRangeNotSatisfiable || w.Header().Get("Content-Range") != "bytes */1000" {
+		t.Errorf("Expected 416 with the file size, got %d %q", w.Code, w.Header().Get("Content-Range"))
+	}
+}
+
+// TestMCPHandler_GetProjectIssuesPaginates tests that offset and count are
+// forwarded to get_issues and that the total from count_issues is included
+func TestMCPHandler_GetProjectIssuesPaginates(t *testing.T) {
+	var issueArgs map[string]interface{}
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string                 `json:"tool"`
+			Args map[string]interface{} `json:"args"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		text := `{"count": 137}`
+		if payload.Tool == "get_issues" {
+			issueArgs = payload.Args
+			text = `[{"issueKey": "TEST-41"}, {"issueKey": "TEST-40"}]`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": text}},
+			},
+		})
+	}))
+	defer bridge.Close()
+
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewMCPHandler(&config.Config{MCPBacklogURL: bridge.URL})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.GET("/projects/:projectId/issues", handler.GetProjectIssues)
+
+	w := performRequest(router, http.MethodGet, "/projects/TEST/issues?offset=40&count=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if issueArgs["offset"] != float64(40) || issueArgs["count"] != float64(2) {
+		t.Errorf("Expected offset 40 and count 2 to be forwarded, got %v", issueArgs)
+	}
+
+	var page struct {
+		Issues []map[string]interface{} `json:"issues"`
+		Offset int                      `json:"offset"`
+		Count  int                      `json:"count"`
+		Total  int                      `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("Failed to decode page: %v", err)
+	}
+	if len(page.Issues) != 2 || page.Offset != 40 || page.Count != 2 || page.Total != 137 {
+		t.Errorf("Unexpected page: %+v", page)
+	}
+
+	for _, query := range []string{"offset=-1", "count=0", "count=101", "count=ten"} {
+		if w := performRequest(router, http.MethodGet, "/projects/TEST/issues?"+query); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", query, w.Code)
+		}
+	}
+}
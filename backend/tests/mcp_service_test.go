@@ -1,8 +1,19 @@
 package tests
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"intelligent-presenter-backend/internal/models"
 	"intelligent-presenter-backend/internal/services"
 	"intelligent-presenter-backend/pkg/config"
 )
@@ -62,43 +73,55 @@ func TestMCPService_ProjectDataValidation(t *testing.T) {
 			name:      "Whitespace project ID",
 			projectID: "   ",
 			token:     "valid-token",
-			valid:     true, // Current implementation doesn't trim whitespace
+			valid:     false,
 		},
 		{
 			name:      "Whitespace token",
 			projectID: "123",
 			token:     "   ",
-			valid:     true, // Current implementation doesn't trim whitespace
+			valid:     false,
+		},
+		{
+			name:      "Surrounding whitespace",
+			projectID: " TEST_PROJECT ",
+			token:     "\tvalid-token\n",
+			valid:     true,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Enhanced validation logic
-			projectIDValid := len(tc.projectID) > 0 && len(tc.projectID) == len(tc.projectID)
-			tokenValid := len(tc.token) > 0 && len(tc.token) == len(tc.token)
-			
-			// Trim whitespace for validation
-			projectIDTrimmed := tc.projectID
-			tokenTrimmed := tc.token
-			if len(projectIDTrimmed) > 0 {
-				projectIDTrimmed = tc.projectID // In real implementation, would use strings.TrimSpace
-			}
-			if len(tokenTrimmed) > 0 {
-				tokenTrimmed = tc.token // In real implementation, would use strings.TrimSpace
+			projectID, token, err := services.ValidateProjectInput(tc.projectID, tc.token)
+
+			if isValid := err == nil; isValid != tc.valid {
+				t.Errorf("Expected validity %v, got %v (%v) for projectID='%s', token='%s'",
+					tc.valid, isValid, err, tc.projectID, tc.token)
 			}
-			
-			isValid := len(projectIDTrimmed) > 0 && len(tokenTrimmed) > 0 && 
-				projectIDValid && tokenValid
-			
-			if isValid != tc.valid {
-				t.Errorf("Expected validity %v, got %v for projectID='%s', token='%s'", 
-					tc.valid, isValid, tc.projectID, tc.token)
+			if err == nil && (projectID != strings.TrimSpace(tc.projectID) || token != strings.TrimSpace(tc.token)) {
+				t.Errorf("Expected trimmed values, got projectID='%s', token='%s'", projectID, token)
 			}
 		})
 	}
 }
 
+// TestMCPService_RejectsBlankInputWithoutCalling tests that MCPService methods
+// reject whitespace-only project IDs and tokens without calling Backlog
+func TestMCPService_RejectsBlankInputWithoutCalling(t *testing.T) {
+	bridge, calls := newMockBridge(t, 0)
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: bridge.URL})
+
+	if _, err := service.GetProjectOverview("   ", "token"); !errors.Is(err, services.ErrEmptyProjectID) {
+		t.Errorf("Expected ErrEmptyProjectID, got %v", err)
+	}
+	if _, err := service.GetProjectProgress("TEST", " "); !errors.Is(err, services.ErrEmptyBacklogToken) {
+		t.Errorf("Expected ErrEmptyBacklogToken, got %v", err)
+	}
+	calls.Range(func(tool, _ interface{}) bool {
+		t.Errorf("Expected no Backlog calls, got %v", tool)
+		return true
+	})
+}
+
 // TestMCPService_BacklogToolNames tests that MCP tools are properly named
 func TestMCPService_BacklogToolNames(t *testing.T) {
 	expectedTools := []string{
@@ -315,3 +338,458 @@ func TestMCPService_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+// newMockBridge starts a Backlog MCP HTTP bridge that answers each tool with a
+// small JSON object, and get_issues with an empty page, failing the tools
+// listed in failing with a bridge error
+func newMockBridge(t *testing.T, delay time.Duration, failing ...string) (*httptest.Server, *sync.Map) {
+	t.Helper()
+	called := &sync.Map{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string `json:"tool"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		called.Store(payload.Tool, true)
+		time.Sleep(delay)
+
+		w.Header().Set("Content-Type", "application/json")
+		for _, tool := range failing {
+			if tool == payload.Tool {
+				json.NewEncoder(w).Encode(map[string]interface{}{"error": "tool failed"})
+				return
+			}
+		}
+		text := fmt.Sprintf(`{"tool": %q}`, payload.Tool)
+		if payload.Tool == "get_issues" {
+			text = "[]"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": text}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, called
+}
+
+// TestMCPService_GetProjectOverviewFetchesConcurrently tests that the project,
+// space, and users tools are all called and that their calls overlap
+func TestMCPService_GetProjectOverviewFetchesConcurrently(t *testing.T) {
+	delay := 100 * time.Millisecond
+	server, called := newMockBridge(t, delay)
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL})
+
+	start := time.Now()
+	overview, err := service.GetProjectOverview("TEST", "token")
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("GetProjectOverview failed: %v", err)
+	}
+
+	for _, tool := range []string{"get_project", "get_space", "get_users"} {
+		if _, ok := called.Load(tool); !ok {
+			t.Errorf("Expected %s to be called", tool)
+		}
+	}
+	data := overview.(map[string]interface{})
+	for _, key := range []string{"project", "space", "users"} {
+		if _, exists := data[key]; !exists {
+			t.Errorf("Expected %q in overview data", key)
+		}
+	}
+	if elapsed >= 3*delay {
+		t.Errorf("Expected concurrent fetches to take less than %v, took %v", 3*delay, elapsed)
+	}
+}
+
+// TestMCPService_GetProjectOverviewToleratesPartialFailures tests that space and
+// users failures are tolerated while a project failure is returned as an error
+func TestMCPService_GetProjectOverviewToleratesPartialFailures(t *testing.T) {
+	server, _ := newMockBridge(t, 0, "get_space", "get_users")
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL})
+
+	overview, err := service.GetProjectOverview("TEST", "token")
+	if err != nil {
+		t.Fatalf("Expected space and users failures to be tolerated, got %v", err)
+	}
+	data := overview.(map[string]interface{})
+	if _, exists := data["project"]; !exists {
+		t.Error("Expected project data in overview")
+	}
+	if _, exists := data["space"]; exists {
+		t.Error("Expected failed space data to be omitted")
+	}
+	if _, exists := data["users"]; exists {
+		t.Error("Expected failed users data to be omitted")
+	}
+
+	server, _ = newMockBridge(t, 0, "get_project")
+	service = services.NewMCPService(&config.Config{MCPBacklogURL: server.URL})
+	if _, err := service.GetProjectOverview("TEST", "token"); err == nil {
+		t.Error("Expected an error when the project cannot be fetched")
+	}
+}
+
+// TestMCPService_GetIssueCommentsStopsAtCap tests that comment pagination halts
+// once the configured cap is reached, even when more comments are available
+func TestMCPService_GetIssueCommentsStopsAtCap(t *testing.T) {
+	var mu sync.Mutex
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string                 `json:"tool"`
+			Args map[string]interface{} `json:"args"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		requests = append(requests, payload.Args)
+		mu.Unlock()
+
+		// Every issue has an effectively unlimited comment history
+		maxID := 100000
+		if value, ok := payload.Args["maxId"].(float64); ok {
+			maxID = int(value)
+		}
+		count := int(payload.Args["count"].(float64))
+		page := make([]map[string]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			page = append(page, map[string]interface{}{"id": maxID - i, "content": "comment"})
+		}
+
+		text, _ := json.Marshal(page)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": string(text)}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL, MaxSummaryComments: 250})
+	comments, err := service.GetIssueComments([]string{"TEST-1", "TEST-2"}, "token")
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+
+	if len(comments) != 250 {
+		t.Errorf("Expected 250 comments, got %d", len(comments))
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected pagination to stop after 3 requests, got %d", len(requests))
+	}
+	if count := requests[2]["count"].(float64); count != 50 {
+		t.Errorf("Expected the last page to request only the remaining 50 comments, got %v", count)
+	}
+	if maxID := requests[1]["maxId"].(float64); maxID != 100000-100 {
+		t.Errorf("Expected the second page to continue below the oldest comment, got maxId %v", maxID)
+	}
+	for _, args := range requests {
+		if args["issueIdOrKey"] != "TEST-1" {
+			t.Errorf("Expected no comments to be fetched for TEST-2 once the cap was reached")
+		}
+	}
+}
+
+// TestMCPService_GetAllIssuesWaitsAfterRateLimit tests that a 429 between pages
+// delays the next page request by the Retry-After interval and that pagination
+// then resumes at the same offset
+func TestMCPService_GetAllIssuesWaitsAfterRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var offsets []int
+	var rateLimitedAt, retriedAt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Args map[string]interface{} `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		offset := int(payload.Args["offset"].(float64))
+		count := int(payload.Args["count"].(float64))
+
+		mu.Lock()
+		offsets = append(offsets, offset)
+		if offset == 100 {
+			if rateLimitedAt.IsZero() {
+				rateLimitedAt = time.Now()
+				mu.Unlock()
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(map[string]interface{}{"error": "Too many requests", "status": 429})
+				return
+			}
+			retriedAt = time.Now()
+		}
+		mu.Unlock()
+
+		// The project has 130 issues
+		page := make([]map[string]interface{}, 0, count)
+		for i := offset; i < offset+count && i < 130; i++ {
+			page = append(page, map[string]interface{}{"id": i})
+		}
+		text, _ := json.Marshal(page)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": string(text)}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL, BacklogPageDelay: 10 * time.Millisecond})
+	issues, err := service.GetAllIssues("TEST", "token", 1000)
+	if err != nil {
+		t.Fatalf("GetAllIssues failed: %v", err)
+	}
+
+	if len(issues) != 130 {
+		t.Errorf("Expected 130 issues, got %d", len(issues))
+	}
+	if fmt.Sprint(offsets) != "[0 100 100]" {
+		t.Errorf("Expected the rate-limited page to be requested again, got offsets %v", offsets)
+	}
+	if wait := retriedAt.Sub(rateLimitedAt); wait < time.Second {
+		t.Errorf("Expected the next page request to wait for Retry-After, waited %v", wait)
+	}
+}
+
+// TestMCPService_GetProjectProgressPagesThroughIssues tests that progress
+// statistics cover every page of issues while the raw issue list passed on
+// with them stays one page long
+func TestMCPService_GetProjectProgressPagesThroughIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string                 `json:"tool"`
+			Args map[string]interface{} `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		text := `{"count": 250}`
+		if payload.Tool == "get_issues" {
+			offset := int(payload.Args["offset"].(float64))
+			count := int(payload.Args["count"].(float64))
+			// The project has 250 issues, the last 50 of them closed
+			page := make([]map[string]interface{}, 0, count)
+			for i := offset; i < offset+count && i < 250; i++ {
+				status := map[string]interface{}{"id": 1, "name": "Open"}
+				if i >= 200 {
+					status = map[string]interface{}{"id": 4, "name": "Closed"}
+				}
+				page = append(page, map[string]interface{}{"id": i, "status": status})
+			}
+			encoded, _ := json.Marshal(page)
+			text = string(encoded)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": text}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL})
+	progress, err := service.GetProjectProgress("TEST", "token")
+	if err != nil {
+		t.Fatalf("GetProjectProgress failed: %v", err)
+	}
+	data := progress.(map[string]interface{})
+	stats, ok := data["stats"].(*models.IssueStats)
+	if !ok {
+		t.Fatalf("Expected issue statistics, got %T", data["stats"])
+	}
+	if stats.TotalIssues != 250 || stats.CompletedIssues != 50 {
+		t.Errorf("Expected statistics over all 250 issues with 50 completed, got %+v", stats)
+	}
+	if issues, _ := data["issues"].([]interface{}); len(issues) != 100 {
+		t.Errorf("Expected a one-page issue sample, got %d issues", len(issues))
+	}
+}
+
+// TestMCPService_GetPeriodComparisonPagesThroughIssues tests that each period's
+// statistics cover every page of the issues updated within it
+func TestMCPService_GetPeriodComparisonPagesThroughIssues(t *testing.T) {
+	// Issues updated in each period, keyed by updatedSince
+	totals := map[string]int{"2026-03-02": 20, "2026-03-09": 150}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Args map[string]interface{} `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		since, _ := payload.Args["updatedSince"].(string)
+		total, ok := totals[since]
+		if !ok {
+			t.Errorf("Unexpected updatedSince %q", since)
+		}
+		offset := int(payload.Args["offset"].(float64))
+		count := int(payload.Args["count"].(float64))
+		page := make([]map[string]interface{}, 0, count)
+		for i := offset; i < offset+count && i < total; i++ {
+			page = append(page, map[string]interface{}{"id": i, "status": map[string]interface{}{"id": 1, "name": "Open"}})
+		}
+		encoded, _ := json.Marshal(page)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": string(encoded)}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL})
+	comparison, err := service.GetPeriodComparison("TEST", "token", time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetPeriodComparison failed: %v", err)
+	}
+	if comparison.Previous.Stats.TotalIssues != 20 || comparison.Current.Stats.TotalIssues != 150 {
+		t.Errorf("Expected 20 and 150 issues, got %d and %d",
+			comparison.Previous.Stats.TotalIssues, comparison.Current.Stats.TotalIssues)
+	}
+	if comparison.Delta.TotalIssues != 130 {
+		t.Errorf("Expected a delta of 130 issues, got %d", comparison.Delta.TotalIssues)
+	}
+}
+
+// TestMCPService_GetPortfolioProjectPagesThroughIssues tests that a portfolio
+// project's statistics cover every page of its issues
+func TestMCPService_GetPortfolioProjectPagesThroughIssues(t *testing.T) {
+	var offsets []int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string                 `json:"tool"`
+			Args map[string]interface{} `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		text := `{"id": 1, "projectKey": "ALPHA", "name": "Alpha"}`
+		if payload.Tool == "get_issues" {
+			offset := int(payload.Args["offset"].(float64))
+			count := int(payload.Args["count"].(float64))
+			mu.Lock()
+			offsets = append(offsets, offset)
+			mu.Unlock()
+			// The project has 180 issues, 120 of them closed
+			page := make([]map[string]interface{}, 0, count)
+			for i := offset; i < offset+count && i < 180; i++ {
+				status := map[string]interface{}{"id": 4, "name": "Closed"}
+				if i >= 120 {
+					status = map[string]interface{}{"id": 1, "name": "Open"}
+				}
+				page = append(page, map[string]interface{}{"id": i, "status": status})
+			}
+			encoded, _ := json.Marshal(page)
+			text = string(encoded)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": text}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL})
+	project, err := service.GetPortfolioProject("ALPHA", "token", time.Now())
+	if err != nil {
+		t.Fatalf("GetPortfolioProject failed: %v", err)
+	}
+	if project.Stats.TotalIssues != 180 || project.Stats.CompletedIssues != 120 {
+		t.Errorf("Expected statistics over all 180 issues with 120 completed, got %+v", project.Stats)
+	}
+	if fmt.Sprint(offsets) != "[0 100]" {
+		t.Errorf("Expected two issue pages, got offsets %v", offsets)
+	}
+}
+
+// TestMCPService_CachesSpaceDuringRun tests that space metadata is fetched once
+// across several overview calls within a run and fetched again after it ends
+func TestMCPService_CachesSpaceDuringRun(t *testing.T) {
+	var spaceCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string `json:"tool"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload.Tool == "get_space" {
+			atomic.AddInt32(&spaceCalls, 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": `{"spaceKey": "TEST"}`}},
+			},
+		})
+	}))
+	defer server.Close()
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: server.URL})
+
+	endRun := service.BeginRun("token")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			overview, err := service.GetProjectOverview("TEST", "token")
+			if err != nil {
+				t.Errorf("GetProjectOverview failed: %v", err)
+				return
+			}
+			if _, exists := overview.(map[string]interface{})["space"]; !exists {
+				t.Error("Expected cached space data in overview")
+			}
+		}()
+	}
+	wg.Wait()
+	if calls := atomic.LoadInt32(&spaceCalls); calls != 1 {
+		t.Errorf("Expected get_space to be called once during the run, got %d", calls)
+	}
+
+	// Another token is not served from this run's cache
+	service.GetProjectOverview("TEST", "other-token")
+	if calls := atomic.LoadInt32(&spaceCalls); calls != 2 {
+		t.Errorf("Expected a separate fetch for another token, got %d calls", calls)
+	}
+
+	endRun()
+	service.GetProjectOverview("TEST", "token")
+	if calls := atomic.LoadInt32(&spaceCalls); calls != 3 {
+		t.Errorf("Expected space to be fetched again after the run ended, got %d calls", calls)
+	}
+}
+
+// TestSpeechService_RequiresSpeechServer tests that synthesis without a speech
+// server fails with a configuration error unless silent placeholder audio is
+// explicitly allowed
+func TestSpeechService_RequiresSpeechServer(t *testing.T) {
+	dir := t.TempDir()
+	service := services.NewSpeechService(&config.Config{MCPSpeechURL: "", AudioCacheDir: dir})
+	if _, err := service.SynthesizeSpeech("こんにちは", "ja", "", 1.0); !errors.Is(err, services.ErrSpeechNotConfigured) {
+		t.Errorf("Expected ErrSpeechNotConfigured, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no placeholder audio without ALLOW_SILENT_TTS, found %d files", len(entries))
+	}
+
+	service = services.NewSpeechService(&config.Config{MCPSpeechURL: "", AllowSilentTTS: true, AudioCacheDir: dir})
+	audioURL, err := service.SynthesizeSpeech("こんにちは", "ja", "", 1.0)
+	if err != nil {
+		t.Fatalf("Expected silent placeholder audio with ALLOW_SILENT_TTS, got %v", err)
+	}
+	data, err := service.FetchAudio(audioURL)
+	if err != nil {
+		t.Fatalf("Failed to read placeholder audio: %v", err)
+	}
+	if _, err := services.ParseWAV(data); err != nil {
+		t.Errorf("Expected a valid WAV placeholder, got %v", err)
+	}
+}
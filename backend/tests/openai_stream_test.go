@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// TestOpenAIStreamDecoder_AssemblesCompletion tests that the lines of an OpenAI
+// event stream are assembled into the full completion, ignoring lines without
+// text and stopping at [DONE]
+func TestOpenAIStreamDecoder_AssemblesCompletion(t *testing.T) {
+	lines := []string{
+		`: keep-alive`,
+		`data: {"choices": [{"index": 0, "delta": {"role": "assistant", "content": ""}}]}`,
+		``,
+		`data: {"choices": [{"index": 0, "delta": {"content": "# Project "}}]}`,
+		`data: {"choices": [{"index": 0, "delta": {"content": "Overview\n"}}]}`,
+		`data: {"choices": [{"index": 0, "delta": {"content": "- 完了率 80%"}}]}`,
+		`data: {"choices": [{"index": 0, "delta": {}, "finish_reason": "stop"}]}`,
+		`data: [DONE]`,
+	}
+
+	var decoder services.OpenAIStreamDecoder
+	var deltas []string
+	for _, line := range lines {
+		delta, err := decoder.Decode([]byte(line))
+		if err != nil {
+			t.Fatalf("Decode(%s) failed: %v", line, err)
+		}
+		if delta != "" {
+			deltas = append(deltas, delta)
+		}
+	}
+
+	expected := "# Project Overview\n- 完了率 80%"
+	if decoder.Text() != expected {
+		t.Errorf("Expected completion %q, got %q", expected, decoder.Text())
+	}
+	if len(deltas) != 3 || strings.Join(deltas, "") != expected {
+		t.Errorf("Expected three deltas forming the completion, got %q", deltas)
+	}
+	if !decoder.Done() {
+		t.Error("Expected the stream to be done after [DONE]")
+	}
+}
+
+// TestOpenAIStreamDecoder_ReportsErrors tests that malformed data lines and
+// error events fail decoding
+func TestOpenAIStreamDecoder_ReportsErrors(t *testing.T) {
+	var decoder services.OpenAIStreamDecoder
+	if _, err := decoder.Decode([]byte(`data: {"choices": [`)); err == nil {
+		t.Error("Expected an error for a malformed data line")
+	}
+	_, err := decoder.Decode([]byte(`data: {"error": {"type": "server_error", "message": "Overloaded"}}`))
+	if err == nil || !strings.Contains(err.Error(), "Overloaded") {
+		t.Errorf("Expected the stream error message, got %v", err)
+	}
+}
+
+// TestSlideService_StreamsOpenAISlideMarkdown tests that with AI_STREAMING
+// enabled, OpenAI slide markdown is requested as a stream, each fragment is
+// passed to OnDelta, and the slide holds the assembled markdown. Without
+// OnDelta the buffered response is used.
+func TestSlideService_StreamsOpenAISlideMarkdown(t *testing.T) {
+	bridge, _ := newMockBridge(t, 0)
+	fragments := []string{"# Stream", "ed Slide\n", "- first", " point"}
+
+	var streamed []bool
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Stream bool `json:"stream"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		streamed = append(streamed, request.Stream)
+
+		if !request.Stream {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"choices": [{"message": {"content": "# Buffered Slide\n- point"}}]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, fragment := range fragments {
+			chunk, _ := json.Marshal(map[string]interface{}{
+				"choices": []map[string]interface{}{{"delta": map[string]string{"content": fragment}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer openAI.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+		AIStreaming:   true,
+	})
+
+	var deltas []string
+	slide, err := service.GenerateSlideContentWithOptions("TEST", models.ThemeProjectOverview, "en", "token", services.GenerationOptions{
+		OnDelta: func(delta string) { deltas = append(deltas, delta) },
+	})
+	if err != nil {
+		t.Fatalf("Expected a streamed slide, got error: %v", err)
+	}
+	if len(streamed) != 1 || !streamed[0] {
+		t.Fatalf("Expected one streaming request, got %v", streamed)
+	}
+	if strings.Join(deltas, "") != strings.Join(fragments, "") || len(deltas) != len(fragments) {
+		t.Errorf("Expected each fragment to be passed to OnDelta, got %q", deltas)
+	}
+	if slide.Markdown != "# Streamed Slide\n- first point" || slide.Title != "Streamed Slide" {
+		t.Errorf("Expected the slide to hold the assembled markdown, got title %q and %q", slide.Title, slide.Markdown)
+	}
+
+	slide, err = service.GenerateSlideContentWithOptions("TEST", models.ThemeProjectOverview, "en", "token", services.GenerationOptions{BypassCache: true})
+	if err != nil {
+		t.Fatalf("Expected a buffered slide, got error: %v", err)
+	}
+	if len(streamed) != 2 || streamed[1] || slide.Title != "Buffered Slide" {
+		t.Errorf("Expected a buffered request without OnDelta, got %v and title %q", streamed, slide.Title)
+	}
+}
+
+// TestSlideService_OpenAIStreamDeadlines tests that a stream may outlast its
+// idle timeout while it keeps sending data, and is abandoned for a buffered
+// request once it stalls
+func TestSlideService_OpenAIStreamDeadlines(t *testing.T) {
+	bridge, _ := newMockBridge(t, 0)
+	stall := make(chan struct{})
+	defer close(stall)
+
+	var streamedAt, bufferedAt time.Time
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Stream bool `json:"stream"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if !request.Stream {
+			bufferedAt = time.Now()
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"choices": [{"message": {"content": "# Buffered Slide\n- point"}}]}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, fragment := range []string{"# Slow", " Slide\n", "- first", " point"} {
+			chunk, _ := json.Marshal(map[string]interface{}{
+				"choices": []map[string]interface{}{{"delta": map[string]string{"content": fragment}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+			w.(http.Flusher).Flush()
+			time.Sleep(60 * time.Millisecond)
+		}
+		streamedAt = time.Now()
+		select {
+		case <-stall:
+		case <-r.Context().Done():
+		}
+	}))
+	defer openAI.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:          "openai",
+		OpenAIAPIKey:        "test-key",
+		OpenAIBaseURL:       openAI.URL,
+		MCPBacklogURL:       bridge.URL,
+		AIStreaming:         true,
+		AIStreamIdleTimeout: 150 * time.Millisecond,
+	})
+
+	var deltas []string
+	slide, err := service.GenerateSlideContentWithOptions("TEST", models.ThemeProjectOverview, "en", "token", services.GenerationOptions{
+		OnDelta: func(delta string) { deltas = append(deltas, delta) },
+	})
+	if err != nil {
+		t.Fatalf("Expected the buffered retry to succeed, got error: %v", err)
+	}
+	if len(deltas) != 4 {
+		t.Errorf("Expected every fragment sent before the stall to be streamed, got %q", deltas)
+	}
+	if slide.Title != "Buffered Slide" {
+		t.Errorf("Expected the stalled stream to be replaced by the buffered response, got title %q", slide.Title)
+	}
+	if bufferedAt.IsZero() || bufferedAt.Sub(streamedAt) > time.Second {
+		t.Errorf("Expected the stalled stream to be abandoned soon after its idle timeout")
+	}
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"intelligent-presenter-backend/internal/auth"
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestRateLimit_RejectsRequestsOverBurst tests that the request after a user's
+// burst is rejected with 429 and Retry-After while other users are unaffected
+func TestRateLimit_RejectsRequestsOverBurst(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Stand in for RequireAuth by taking the user ID from a test header
+	router.Use(func(c *gin.Context) {
+		userID, _ := strconv.Atoi(c.GetHeader("X-Test-User"))
+		c.Set("userID", userID)
+	})
+	router.POST("/slides/generate", auth.RateLimit(&config.Config{
+		RateLimitRPS:   0.1,
+		RateLimitBurst: 3,
+	}), func(c *gin.Context) {
+		c.Status(http.StatusAccepted)
+	})
+
+	generate := func(userID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/slides/generate", nil)
+		req.Header.Set("X-Test-User", userID)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 1; i <= 3; i++ {
+		if w := generate("1"); w.Code != http.StatusAccepted {
+			t.Fatalf("Expected request %d within the burst to be accepted, got %d", i, w.Code)
+		}
+	}
+
+	w := generate("1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected request 4 to be rate limited, got %d", w.Code)
+	}
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 10 {
+		t.Errorf("Expected a Retry-After of 1-10 seconds, got %q", w.Header().Get("Retry-After"))
+	}
+
+	if w := generate("2"); w.Code != http.StatusAccepted {
+		t.Errorf("Expected another user to be unaffected, got %d", w.Code)
+	}
+}
+
+// TestRateLimit_DisabledWithZeroRate tests that a zero rate disables limiting
+func TestRateLimit_DisabledWithZeroRate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/speech/synthesize", auth.RateLimit(&config.Config{RateLimitBurst: 1}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 10; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/speech/synthesize", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected request %d to pass with limiting disabled, got %d", i+1, w.Code)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// newFlakyServer creates a test server that responds with failStatus on the first
+// request and with successBody afterwards, counting the requests it receives
+func newFlakyServer(t *testing.T, failStatus int, successBody string, attempts *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*attempts++
+		if *attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(failStatus)
+			w.Write([]byte(`{"message": "rate limited"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(successBody))
+	}))
+}
+
+// TestOpenAI_RetriesAfterRateLimit tests that OpenAI calls are retried after a 429 response
+func TestOpenAI_RetriesAfterRateLimit(t *testing.T) {
+	attempts := 0
+	server := newFlakyServer(t, http.StatusTooManyRequests,
+		`{"choices": [{"message": {"content": "Narration after retry"}}]}`, &attempts)
+	defer server.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:       "openai",
+		OpenAIAPIKey:     "test-key",
+		OpenAIBaseURL:    server.URL,
+		AIMaxAttempts:    3,
+		AIRetryBaseDelay: time.Millisecond,
+	})
+
+	slide := &models.SlideContent{Index: 0, Title: "Overview", Markdown: "# Overview"}
+	narration, err := service.GenerateSlideNarration(slide, "en")
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got error: %v", err)
+	}
+	if narration.Text != "Narration after retry" {
+		t.Errorf("Unexpected narration text: %q", narration.Text)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+// TestBedrock_RetriesAfterRateLimit tests that Bedrock calls are retried after a 429 response
+func TestBedrock_RetriesAfterRateLimit(t *testing.T) {
+	attempts := 0
+	server := newFlakyServer(t, http.StatusTooManyRequests,
+		`{"content": [{"type": "text", "text": "Slide after retry"}]}`, &attempts)
+	defer server.Close()
+
+	service := services.NewBedrockService(&config.Config{
+		AWSRegion:          "ap-northeast-1",
+		AWSAccessKeyID:     "test-access-key",
+		AWSSecretAccessKey: "test-secret-key",
+		BedrockModelID:     "anthropic.claude-3-haiku-20240307-v1:0",
+		BedrockEndpoint:    server.URL,
+		AIMaxAttempts:      3,
+		AIRetryBaseDelay:   time.Millisecond,
+	})
+
+	text, err := service.GenerateText("Generate a slide")
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got error: %v", err)
+	}
+	if text != "Slide after retry" {
+		t.Errorf("Unexpected generated text: %q", text)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+// TestRetry_GivesUpAfterMaxAttempts tests that persistent server errors stop after the configured attempts
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:       "openai",
+		OpenAIAPIKey:     "test-key",
+		OpenAIBaseURL:    server.URL,
+		AIMaxAttempts:    2,
+		AIRetryBaseDelay: time.Millisecond,
+	})
+
+	slide := &models.SlideContent{Index: 0, Title: "Overview", Markdown: "# Overview"}
+	if _, err := service.GenerateSlideNarration(slide, "en"); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// TestComputeRiskSignals_FlagsIssues tests that overdue, due-soon, unassigned
+// high-priority, and stalled issues are flagged and scored
+func TestComputeRiskSignals_FlagsIssues(t *testing.T) {
+	var issues []interface{}
+	err := json.Unmarshal([]byte(`[
+		{"issueKey": "RISK-1", "summary": "Overdue", "status": {"id": 2}, "dueDate": "2024-05-20T00:00:00Z", "updated": "2024-05-30T00:00:00Z", "assignee": {"id": 1}},
+		{"issueKey": "RISK-2", "summary": "Due soon", "status": {"id": 1}, "dueDate": "2024-06-02T00:00:00Z", "updated": "2024-05-30T00:00:00Z", "assignee": {"id": 1}},
+		{"issueKey": "RISK-3", "summary": "Unassigned", "status": {"id": 1}, "priority": {"id": 2, "name": "高"}, "assignee": null, "created": "2024-05-20T00:00:00Z", "updated": "2024-05-30T00:00:00Z"},
+		{"issueKey": "RISK-4", "summary": "Stalled and overdue", "status": {"id": 2}, "dueDate": "2024-05-01T00:00:00Z", "updated": "2024-04-01T00:00:00Z", "assignee": {"id": 1}},
+		{"issueKey": "RISK-5", "summary": "Closed", "status": {"id": 4}, "dueDate": "2024-05-01T00:00:00Z", "updated": "2024-04-01T00:00:00Z"},
+		{"issueKey": "RISK-6", "summary": "Healthy", "status": {"id": 2}, "priority": {"id": 3}, "dueDate": "2024-07-01T00:00:00Z", "updated": "2024-05-31T00:00:00Z", "assignee": {"id": 1}},
+		{"issueKey": "RISK-7", "summary": "New high priority", "status": {"id": 1}, "priority": {"id": 2}, "assignee": null, "created": "2024-05-31T00:00:00Z", "updated": "2024-05-31T00:00:00Z"}
+	]`), &issues)
+	if err != nil {
+		t.Fatalf("Failed to parse test issues: %v", err)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	thresholds := services.RiskThresholds{DueSoonDays: 3, UnassignedDays: 3, StalledDays: 14}
+	summary := services.ComputeRiskSignals(issues, thresholds, now)
+
+	if summary.OverdueIssues != 2 || summary.DueSoonIssues != 1 ||
+		summary.UnassignedHighPriority != 1 || summary.StalledIssues != 1 {
+		t.Errorf("Unexpected risk counts: %+v", summary)
+	}
+	if len(summary.TopRisks) != 4 {
+		t.Fatalf("Expected 4 flagged issues, got %d", len(summary.TopRisks))
+	}
+	if summary.TopRisks[0].IssueKey != "RISK-4" || summary.TopRisks[0].Score != 5 {
+		t.Errorf("Expected RISK-4 with score 5 to rank first, got %+v", summary.TopRisks[0])
+	}
+	if summary.TotalScore != 11 {
+		t.Errorf("Expected total score 11, got %d", summary.TotalScore)
+	}
+	for _, risk := range summary.TopRisks {
+		if risk.IssueKey == "RISK-5" || risk.IssueKey == "RISK-6" || risk.IssueKey == "RISK-7" {
+			t.Errorf("Did not expect %s to be flagged: %+v", risk.IssueKey, risk)
+		}
+	}
+}
+
+// TestRiskThresholdsFromConfig tests that unset thresholds fall back to defaults
+func TestRiskThresholdsFromConfig(t *testing.T) {
+	thresholds := services.RiskThresholdsFromConfig(&config.Config{RiskStalledDays: 30})
+	if thresholds.DueSoonDays != 3 || thresholds.UnassignedDays != 3 || thresholds.StalledDays != 30 {
+		t.Errorf("Unexpected thresholds: %+v", thresholds)
+	}
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// newTestSessionRecord creates a session record populated with sample deck data
+func newTestSessionRecord(id string) *models.SlideSessionRecord {
+	return &models.SlideSessionRecord{
+		ID:        id,
+		ProjectID: models.ProjectID("TEST_PROJECT"),
+		Themes:    []models.SlideTheme{models.ThemeProjectOverview},
+		Language:  "ja",
+		Status:    "completed",
+		Slides: []*models.SlideContent{
+			{Index: 0, Theme: models.ThemeProjectOverview, Title: "概要", Markdown: "# 概要"},
+		},
+		Narrations: []*models.SlideNarration{
+			{SlideIndex: 0, Text: "概要を説明します", Language: "ja"},
+		},
+		AudioFiles: []*models.SlideAudio{
+			{SlideIndex: 0, AudioURL: "/cache/test.wav", Duration: 3},
+		},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// TestSessionStore_Implementations tests save, load, list, and delete for every store implementation
+func TestSessionStore_Implementations(t *testing.T) {
+	fileStore, err := services.NewFileSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+
+	stores := map[string]services.SessionStore{
+		"memory": services.NewMemorySessionStore(),
+		"file":   fileStore,
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			record := newTestSessionRecord("session-1")
+			if err := store.Save(record); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+
+			loaded, err := store.Load("session-1")
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if loaded.Status != "completed" || len(loaded.Slides) != 1 || loaded.Slides[0].Title != "概要" {
+				t.Errorf("Loaded record does not match saved record: %+v", loaded)
+			}
+			if len(loaded.Narrations) != 1 || len(loaded.AudioFiles) != 1 {
+				t.Errorf("Expected narrations and audio metadata to be persisted, got %+v", loaded)
+			}
+
+			records, err := store.List()
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if len(records) != 1 {
+				t.Errorf("Expected 1 record, got %d", len(records))
+			}
+
+			if err := store.Delete("session-1"); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+			if _, err := store.Load("session-1"); err != services.ErrSessionNotFound {
+				t.Errorf("Expected ErrSessionNotFound after delete, got %v", err)
+			}
+			if err := store.Delete("session-1"); err != nil {
+				t.Errorf("Deleting an unknown session should not fail, got %v", err)
+			}
+		})
+	}
+}
+
+// TestSessionStore_DropsStaleSnapshots tests that every store implementation
+// keeps the newest snapshot when an older one is saved after it
+func TestSessionStore_DropsStaleSnapshots(t *testing.T) {
+	fileStore, err := services.NewFileSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+
+	stores := map[string]services.SessionStore{
+		"memory": services.NewMemorySessionStore(),
+		"file":   fileStore,
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			newer := newTestSessionRecord("session-1")
+			newer.Version = 2
+			stale := newTestSessionRecord("session-1")
+			stale.Version = 1
+			stale.Status = "generating"
+
+			for _, record := range []*models.SlideSessionRecord{newer, stale} {
+				if err := store.Save(record); err != nil {
+					t.Fatalf("Save failed: %v", err)
+				}
+			}
+
+			loaded, err := store.Load("session-1")
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if loaded.Version != 2 || loaded.Status != "completed" {
+				t.Errorf("Expected the newer snapshot to be kept, got version %d with status %s", loaded.Version, loaded.Status)
+			}
+		})
+	}
+}
+
+// TestFileSessionStore_SurvivesReopen tests that records persist across store instances
+func TestFileSessionStore_SurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	if err := store.Save(newTestSessionRecord("session-2")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reopened, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to reopen file session store: %v", err)
+	}
+	records, err := reopened.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "session-2" {
+		t.Errorf("Expected persisted session-2 after reopen, got %+v", records)
+	}
+}
+
+// TestFileSessionStore_RejectsInvalidIDs tests that session IDs cannot escape the store directory
+func TestFileSessionStore_RejectsInvalidIDs(t *testing.T) {
+	store, err := services.NewFileSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+
+	for _, id := range []string{"", "../escape", "nested/id", ".hidden"} {
+		if _, err := store.Load(id); err == nil || err == services.ErrSessionNotFound {
+			t.Errorf("Expected invalid ID error for %q, got %v", id, err)
+		}
+	}
+}
+
+// TestNewSessionStore_Selection tests that the configured store backend is selected
+func TestNewSessionStore_Selection(t *testing.T) {
+	if _, err := services.NewSessionStore(&config.Config{SessionStore: "memory"}); err != nil {
+		t.Errorf("Expected memory store, got error %v", err)
+	}
+	if _, err := services.NewSessionStore(&config.Config{SessionStore: "file", SessionStoreDir: t.TempDir()}); err != nil {
+		t.Errorf("Expected file store, got error %v", err)
+	}
+	if _, err := services.NewSessionStore(&config.Config{SessionStore: "unknown"}); err == nil {
+		t.Error("Expected error for unsupported session store")
+	}
+}
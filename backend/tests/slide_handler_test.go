@@ -0,0 +1,2253 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"intelligent-presenter-backend/internal/api/handlers"
+	"intelligent-presenter-backend/internal/models"
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// newTestSlideRouter creates a slide handler backed by a file session store in dir
+// and registers its session routes on a test router
+func newTestSlideRouter(t *testing.T, dir string, ttl time.Duration) (*handlers.SlideHandler, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:      "openai",
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+		SessionTTL:      ttl,
+	})
+
+	router := gin.New()
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+	router.GET("/slides/:slideId/audio", handler.GetSlideAudio)
+	router.GET("/slides/:slideId/jsonld", handler.GetSlideJSONLD)
+	router.GET("/slides/:slideId/markdown", handler.GetSlideMarkdown)
+	router.GET("/slides/:slideId/teleprompter", handler.GetSlideTeleprompter)
+	router.GET("/slides/:slideId/subtitles", handler.GetSlideSubtitles)
+	router.DELETE("/slides/:slideId", handler.DeleteSlideSession)
+	return handler, router
+}
+
+// performRequest sends a request to the router and returns the recorded response
+func performRequest(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestSlideHandler_CleanupExpiredSessions tests that only finished sessions older than the TTL are removed
+func TestSlideHandler_CleanupExpiredSessions(t *testing.T) {
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+
+	now := time.Now()
+	expired := newTestSessionRecord("expired-session")
+	expired.CompletedAt = now.Add(-2 * time.Hour)
+	recent := newTestSessionRecord("recent-session")
+	recent.CompletedAt = now.Add(-10 * time.Minute)
+	for _, record := range []*models.SlideSessionRecord{expired, recent} {
+		if err := store.Save(record); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	handler, router := newTestSlideRouter(t, dir, time.Hour)
+
+	if removed := handler.CleanupExpiredSessions(now); removed != 1 {
+		t.Errorf("Expected 1 expired session to be removed, got %d", removed)
+	}
+
+	if w := performRequest(router, http.MethodGet, "/slides/expired-session/status"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected expired session to return 404, got %d", w.Code)
+	}
+	if w := performRequest(router, http.MethodGet, "/slides/recent-session/status"); w.Code != http.StatusOK {
+		t.Errorf("Expected recent session to remain available, got %d", w.Code)
+	}
+
+	if _, err := store.Load("expired-session"); err != services.ErrSessionNotFound {
+		t.Errorf("Expected expired session to be removed from the store, got %v", err)
+	}
+}
+
+// TestSlideHandler_DeleteSlideSession tests manual deletion of a slide session
+func TestSlideHandler_DeleteSlideSession(t *testing.T) {
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	if err := store.Save(newTestSessionRecord("session-to-delete")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	_, router := newTestSlideRouter(t, dir, 0)
+
+	if w := performRequest(router, http.MethodDelete, "/slides/session-to-delete"); w.Code != http.StatusOK {
+		t.Fatalf("Expected delete to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := performRequest(router, http.MethodGet, "/slides/session-to-delete/status"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected deleted session to return 404, got %d", w.Code)
+	}
+	if _, err := store.Load("session-to-delete"); err != services.ErrSessionNotFound {
+		t.Errorf("Expected deleted session to be removed from the store, got %v", err)
+	}
+
+	if w := performRequest(router, http.MethodDelete, "/slides/session-to-delete"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected deleting an unknown session to return 404, got %d", w.Code)
+	}
+}
+
+// TestSlideHandler_DeleteGeneratingSession tests that deleting a session while
+// its slides are still being generated is not undone when generation finishes
+func TestSlideHandler_DeleteGeneratingSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { close(started) })
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer bridge.Close()
+
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	handler := handlers.NewSlideHandler(&config.Config{
+		MCPBacklogURL:   bridge.URL,
+		DisableAudio:    true,
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.DELETE("/slides/:slideId", handler.DeleteSlideSession)
+
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: []models.SlideTheme{models.ThemeProjectOverview}, Language: "en"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+	var generated models.SlideGenerationResponse
+	json.Unmarshal(w.Body.Bytes(), &generated)
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for generation to start")
+	}
+	if w := performRequest(router, http.MethodDelete, "/slides/"+generated.SlideID); w.Code != http.StatusOK {
+		t.Fatalf("Expected delete to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	close(release)
+
+	// Generation fails quickly once the bridge answers; it must not write the
+	// deleted session back to the store when it finishes
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if _, err := store.Load(generated.SlideID); err != services.ErrSessionNotFound {
+			t.Fatalf("Expected the deleted session to stay deleted, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestSlideSession_ReplaceSlideKeepsIndex tests that a regenerated slide replaces
+// the original in place and keeps its index
+func TestSlideSession_ReplaceSlideKeepsIndex(t *testing.T) {
+	session := &handlers.SlideSession{
+		Slides: []*models.SlideContent{
+			{Index: 0, Theme: models.ThemeProjectOverview, Title: "Overview"},
+			{Index: 1, Theme: models.ThemeProjectProgress, Title: "Old Progress"},
+			{Index: 2, Theme: models.ThemeRiskAnalysis, Title: "Risks"},
+		},
+	}
+
+	regenerated := &models.SlideContent{Theme: models.ThemeProjectProgress, Title: "New Progress"}
+	session.ReplaceSlide(1, regenerated)
+
+	if len(session.Slides) != 3 {
+		t.Fatalf("Expected 3 slides after replacement, got %d", len(session.Slides))
+	}
+	if session.Slides[1] != regenerated {
+		t.Errorf("Expected regenerated slide at position 1, got %+v", session.Slides[1])
+	}
+	if session.Slides[1].Index != 1 {
+		t.Errorf("Expected regenerated slide to keep index 1, got %d", session.Slides[1].Index)
+	}
+	if session.Slides[0].Title != "Overview" || session.Slides[2].Title != "Risks" {
+		t.Error("Expected other slides to be left untouched")
+	}
+
+	session.ReplaceNarration(&models.SlideNarration{SlideIndex: 1, Text: "new narration"})
+	session.ReplaceAudio(&models.SlideAudio{SlideIndex: 1, AudioURL: "/cache/new.wav"})
+	if len(session.Narrations) != 1 || len(session.AudioFiles) != 1 {
+		t.Errorf("Expected narration and audio for the regenerated slide, got %d and %d",
+			len(session.Narrations), len(session.AudioFiles))
+	}
+}
+
+// TestSlideHandler_GetSlideAudio tests that the audio list follows the order of
+// session.AudioFiles, which is kept sorted by slide index
+func TestSlideHandler_GetSlideAudio(t *testing.T) {
+	session := &handlers.SlideSession{}
+	for _, index := range []int{2, 0, 1} {
+		session.ReplaceAudio(&models.SlideAudio{SlideIndex: index, AudioURL: fmt.Sprintf("/cache/slide-%d.wav", index), Duration: index + 1})
+	}
+	for i, audio := range session.AudioFiles {
+		if audio.SlideIndex != i {
+			t.Fatalf("Expected AudioFiles to be ordered by slide index, got index %d at position %d", audio.SlideIndex, i)
+		}
+	}
+
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	record := newTestSessionRecord("audio-session")
+	record.AudioFiles = session.AudioFiles
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	_, router := newTestSlideRouter(t, dir, 0)
+
+	w := performRequest(router, http.MethodGet, "/slides/audio-session/audio")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		AudioFiles    []models.SlideAudio `json:"audioFiles"`
+		TotalDuration int                 `json:"totalDuration"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.AudioFiles) != len(session.AudioFiles) {
+		t.Fatalf("Expected %d audio files, got %d", len(session.AudioFiles), len(response.AudioFiles))
+	}
+	for i, audio := range response.AudioFiles {
+		if audio != *session.AudioFiles[i] {
+			t.Errorf("Expected %+v at position %d, got %+v", *session.AudioFiles[i], i, audio)
+		}
+	}
+	if response.TotalDuration != 6 {
+		t.Errorf("Expected a total duration of 6 seconds, got %d", response.TotalDuration)
+	}
+
+	if w := performRequest(router, http.MethodGet, "/slides/missing-session/audio"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing session, got %d", w.Code)
+	}
+}
+
+// TestSlideHandler_GetSlideJSONLD tests that the deck is served as valid
+// schema.org JSON-LD with each slide as a part
+func TestSlideHandler_GetSlideJSONLD(t *testing.T) {
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	if err := store.Save(newTestSessionRecord("jsonld-session")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	_, router := newTestSlideRouter(t, dir, 0)
+
+	w := performRequest(router, http.MethodGet, "/slides/jsonld-session/jsonld")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/ld+json") {
+		t.Errorf("Expected an application/ld+json response, got %q", contentType)
+	}
+	if !json.Valid(w.Body.Bytes()) {
+		t.Fatalf("Expected valid JSON, got %s", w.Body.String())
+	}
+
+	var document struct {
+		Context string `json:"@context"`
+		Type    string `json:"@type"`
+		HasPart []struct {
+			Type            string `json:"@type"`
+			Position        int    `json:"position"`
+			Name            string `json:"name"`
+			AssociatedMedia struct {
+				Type       string `json:"@type"`
+				ContentURL string `json:"contentUrl"`
+				Duration   string `json:"duration"`
+				Transcript string `json:"transcript"`
+			} `json:"associatedMedia"`
+		} `json:"hasPart"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &document); err != nil {
+		t.Fatalf("Failed to decode JSON-LD: %v", err)
+	}
+	if document.Context != "https://schema.org" || document.Type != "PresentationDigitalDocument" {
+		t.Errorf("Expected a schema.org PresentationDigitalDocument, got %q %q", document.Context, document.Type)
+	}
+	if len(document.HasPart) != 1 {
+		t.Fatalf("Expected one part, got %d", len(document.HasPart))
+	}
+	part := document.HasPart[0]
+	if part.Position != 1 || part.Name != "概要" {
+		t.Errorf("Expected the first slide at position 1, got %+v", part)
+	}
+	if part.AssociatedMedia.Type != "AudioObject" || part.AssociatedMedia.ContentURL != "/cache/test.wav" ||
+		part.AssociatedMedia.Duration != "PT3S" || part.AssociatedMedia.Transcript != "概要を説明します" {
+		t.Errorf("Expected the narration audio as an AudioObject, got %+v", part.AssociatedMedia)
+	}
+
+	if w := performRequest(router, http.MethodGet, "/slides/missing-session/jsonld"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing session, got %d", w.Code)
+	}
+}
+
+// TestSlideHandler_GetSlideMarkdown tests that the deck is exported as markdown
+// with slides in index order separated by "---" lines
+func TestSlideHandler_GetSlideMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	record := newTestSessionRecord("markdown-session")
+	record.Slides = []*models.SlideContent{
+		{Index: 2, Theme: models.ThemeSummaryPlan, Title: "総括", Markdown: "# 総括\n\n- 次期計画\n"},
+		{Index: 0, Theme: models.ThemeProjectOverview, Title: "概要", Markdown: "# 概要"},
+		{Index: 1, Theme: models.ThemeProjectProgress, Title: "進捗", Markdown: "\n# 進捗\n\n完了率 50%\n\n"},
+	}
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	_, router := newTestSlideRouter(t, dir, 0)
+
+	w := performRequest(router, http.MethodGet, "/slides/markdown-session/markdown")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/markdown") {
+		t.Errorf("Expected a text/markdown response, got %q", contentType)
+	}
+	if disposition := w.Header().Get("Content-Disposition"); !strings.Contains(disposition, `filename="markdown-session.md"`) {
+		t.Errorf("Expected a downloadable .md file, got %q", disposition)
+	}
+
+	slides := strings.Split(w.Body.String(), "\n---\n")
+	if len(slides) != 3 {
+		t.Fatalf("Expected 3 slides separated by ---, got %d: %q", len(slides), w.Body.String())
+	}
+	for i, title := range []string{"# 概要", "# 進捗", "# 総括"} {
+		if !strings.HasPrefix(strings.TrimSpace(slides[i]), title) {
+			t.Errorf("Expected slide %d to start with %q, got %q", i, title, slides[i])
+		}
+	}
+
+	if w := performRequest(router, http.MethodGet, "/slides/missing-session/markdown"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing session, got %d", w.Code)
+	}
+}
+
+// TestSlideHandler_GetSlideTeleprompter tests that every slide is returned with
+// its narration and a timing estimate, measured from audio when available
+func TestSlideHandler_GetSlideTeleprompter(t *testing.T) {
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	record := newTestSessionRecord("teleprompter-session")
+	record.Language = "en"
+	record.Slides = []*models.SlideContent{
+		{Index: 1, Theme: models.ThemeProjectProgress, Title: "Progress"},
+		{Index: 0, Theme: models.ThemeProjectOverview, Title: "Overview"},
+	}
+	record.Narrations = []*models.SlideNarration{
+		{SlideIndex: 0, Text: "Welcome. This project ships in May.", Language: "en"},
+		{SlideIndex: 1, Text: "Half of the issues are closed. Two are overdue.", Language: "en"},
+	}
+	record.AudioFiles = []*models.SlideAudio{
+		{SlideIndex: 0, AudioURL: "/cache/overview.wav", Duration: 4},
+	}
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	_, router := newTestSlideRouter(t, dir, 0)
+
+	w := performRequest(router, http.MethodGet, "/slides/teleprompter-session/teleprompter")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var teleprompter models.Teleprompter
+	if err := json.Unmarshal(w.Body.Bytes(), &teleprompter); err != nil {
+		t.Fatalf("Failed to decode teleprompter: %v", err)
+	}
+	if len(teleprompter.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(teleprompter.Entries))
+	}
+
+	total := 0
+	for i, entry := range teleprompter.Entries {
+		if entry.Index != i {
+			t.Errorf("Expected entries in slide order, got index %d at position %d", entry.Index, i)
+		}
+		if entry.Narration == "" || len(entry.Lines) != 2 {
+			t.Errorf("Expected slide %d to have narration split into 2 lines, got %+v", i, entry)
+		}
+		if entry.DurationSeconds <= 0 {
+			t.Errorf("Expected slide %d to have a timing estimate, got %d", i, entry.DurationSeconds)
+		}
+		if entry.StartSeconds != total {
+			t.Errorf("Expected slide %d to start at %ds, got %d", i, total, entry.StartSeconds)
+		}
+		total += entry.DurationSeconds
+	}
+	if teleprompter.Entries[0].TimingSource != models.TimingSourceAudio || teleprompter.Entries[0].DurationSeconds != 4 {
+		t.Errorf("Expected slide 0 to be timed by its audio, got %+v", teleprompter.Entries[0])
+	}
+	if teleprompter.Entries[1].TimingSource != models.TimingSourceEstimate {
+		t.Errorf("Expected slide 1 without audio to be estimated, got %+v", teleprompter.Entries[1])
+	}
+	if teleprompter.TotalSeconds != total {
+		t.Errorf("Expected total of %ds, got %d", total, teleprompter.TotalSeconds)
+	}
+
+	if w := performRequest(router, http.MethodGet, "/slides/missing-session/teleprompter"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing session, got %d", w.Code)
+	}
+}
+
+// withBacklogToken stands in for the auth middleware, which sets the
+// authenticated user's Backlog access token on the request context
+func withBacklogToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("backlogToken", token)
+		c.Next()
+	}
+}
+
+// TestSlideHandler_GenerateSlidesRejectsBlankInput tests that whitespace-only
+// project IDs and Backlog tokens are rejected with 400 before generation starts
+func TestSlideHandler_GenerateSlidesRejectsBlankInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSlideHandler(&config.Config{DisableAudio: true})
+
+	testCases := []struct {
+		name      string
+		projectID models.ProjectID
+		token     string
+		wantError string
+	}{
+		{"whitespace project ID", "   ", "token", services.ErrEmptyProjectID.Error()},
+		{"whitespace token", "TEST", "  \t", services.ErrEmptyBacklogToken.Error()},
+		{"missing token", "TEST", "", services.ErrEmptyBacklogToken.Error()},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(withBacklogToken(tc.token))
+			router.POST("/slides/generate", handler.GenerateSlides)
+
+			body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: tc.projectID, Themes: []models.SlideTheme{models.ThemeProjectOverview}, Language: "en"})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var response map[string]string
+			json.Unmarshal(w.Body.Bytes(), &response)
+			if response["error"] != tc.wantError {
+				t.Errorf("Expected error %q, got %q", tc.wantError, response["error"])
+			}
+		})
+	}
+}
+
+// TestSlideHandler_GenerateSlidesValidatesLanguage tests that every language
+// supported by the speech server is accepted and other languages are rejected
+func TestSlideHandler_GenerateSlidesValidatesLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+	handler := handlers.NewSlideHandler(&config.Config{MCPBacklogURL: bridge.URL, DisableAudio: true})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+
+	for language, want := range map[string]int{"es": http.StatusOK, "zh": http.StatusOK, "xx": http.StatusBadRequest} {
+		body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: []models.SlideTheme{models.ThemeProjectOverview}, Language: language})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+		if w.Code != want {
+			t.Errorf("Expected %d for language %q, got %d: %s", want, language, w.Code, w.Body.String())
+		}
+	}
+}
+
+// TestSlideHandler_GenerateSlidesRejectsUnknownThemes tests that unknown themes
+// are listed in a 400 response unless ALLOW_UNKNOWN_THEMES is enabled
+func TestSlideHandler_GenerateSlidesRejectsUnknownThemes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+	body, _ := json.Marshal(models.SlideGenerationRequest{
+		ProjectID: "TEST",
+		Themes:    []models.SlideTheme{models.ThemeProjectOverview, "weather_report"},
+		Language:  "en",
+	})
+
+	for _, allow := range []bool{false, true} {
+		handler := handlers.NewSlideHandler(&config.Config{MCPBacklogURL: bridge.URL, DisableAudio: true, AllowUnknownThemes: allow})
+		router := gin.New()
+		router.Use(withBacklogToken("token"))
+		router.POST("/slides/generate", handler.GenerateSlides)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+
+		if allow {
+			if w.Code != http.StatusOK {
+				t.Errorf("Expected 200 with unknown themes allowed, got %d: %s", w.Code, w.Body.String())
+			}
+			continue
+		}
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for an unknown theme, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			UnknownThemes []string `json:"unknownThemes"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(response.UnknownThemes) != 1 || response.UnknownThemes[0] != "weather_report" {
+			t.Errorf("Expected unknownThemes [weather_report], got %v", response.UnknownThemes)
+		}
+	}
+}
+
+// TestSlideHandler_GenerateSlidesAutoOrdersThemes tests that autoOrder
+// rearranges the requested themes into the configured deck flow
+func TestSlideHandler_GenerateSlidesAutoOrdersThemes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+	handler := handlers.NewSlideHandler(&config.Config{
+		MCPBacklogURL: bridge.URL,
+		DisableAudio:  true,
+		ThemeOrder:    config.DefaultThemeOrder,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+
+	requested := []models.SlideTheme{models.ThemeSummaryPlan, models.ThemeRiskAnalysis, models.ThemeProjectOverview, models.ThemeIssueManagement}
+	expected := map[bool][]models.SlideTheme{
+		false: requested,
+		true:  {models.ThemeProjectOverview, models.ThemeIssueManagement, models.ThemeRiskAnalysis, models.ThemeSummaryPlan},
+	}
+	for autoOrder, want := range expected {
+		body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: requested, Language: "en", AutoOrder: autoOrder})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var generated models.SlideGenerationResponse
+		json.Unmarshal(w.Body.Bytes(), &generated)
+
+		w = performRequest(router, http.MethodGet, "/slides/"+generated.SlideID+"/status")
+		var status struct {
+			Themes []models.SlideTheme `json:"themes"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+			t.Fatalf("Failed to decode status: %v", err)
+		}
+		if !reflect.DeepEqual(status.Themes, want) {
+			t.Errorf("autoOrder=%v: expected themes %v, got %v", autoOrder, want, status.Themes)
+		}
+	}
+}
+
+// TestOrderSlideSpeeds_FollowsReorderedThemes tests that per-slide speed
+// overrides move with their themes when the deck is reordered
+func TestOrderSlideSpeeds_FollowsReorderedThemes(t *testing.T) {
+	requested := []models.SlideTheme{models.ThemeSummaryPlan, models.ThemeRiskAnalysis, models.ThemeProjectOverview}
+	speeds := map[int]float64{0: 0.8, 2: 1.5, 7: 2.0}
+
+	ordered := services.OrderThemes(requested, config.DefaultThemeOrder)
+	got := services.OrderSlideSpeeds(speeds, requested, config.DefaultThemeOrder)
+	if want := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeRiskAnalysis, models.ThemeSummaryPlan}; !reflect.DeepEqual(ordered, want) {
+		t.Fatalf("Expected themes %v, got %v", want, ordered)
+	}
+	if want := map[int]float64{0: 1.5, 2: 0.8, 7: 2.0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected speeds %v to follow their themes, got %v", want, got)
+	}
+	if services.OrderSlideSpeeds(nil, requested, config.DefaultThemeOrder) != nil {
+		t.Error("Expected no overrides to stay nil")
+	}
+}
+
+// TestSplitThemes_ReachesTargetSlides tests that a slide target above the
+// number of themes splits themes into adjacent parts, with speeds following them
+func TestSplitThemes_ReachesTargetSlides(t *testing.T) {
+	requested := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeRiskAnalysis}
+	speeds := map[int]float64{1: 1.5, 7: 2.0}
+
+	split := services.SplitThemes(requested, 5)
+	want := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeProjectOverview, models.ThemeProjectOverview, models.ThemeRiskAnalysis, models.ThemeRiskAnalysis}
+	if !reflect.DeepEqual(split, want) {
+		t.Fatalf("Expected themes %v, got %v", want, split)
+	}
+	if got, want := services.SplitSlideSpeeds(speeds, requested, 5), map[int]float64{3: 1.5, 4: 1.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected speeds %v to follow their themes, got %v", want, got)
+	}
+	if part, parts := services.ThemePart(split, 1); part != 2 || parts != 3 {
+		t.Errorf("Expected slide 2 to be part 2 of 3, got %d of %d", part, parts)
+	}
+	if part, parts := services.ThemePart(split, 3); part != 1 || parts != 2 {
+		t.Errorf("Expected slide 4 to be part 1 of 2, got %d of %d", part, parts)
+	}
+	if kept := services.SplitThemes(requested, 1); !reflect.DeepEqual(kept, requested) {
+		t.Errorf("Expected a target below the theme count to keep one slide per theme, got %v", kept)
+	}
+}
+
+// TestSlideHandler_TemplateTargetSplitsThemeContent tests that a deck template
+// targeting more slides than themes generates the extra slides as parts of a
+// split theme, each asking the AI provider for its own share of the content
+func TestSlideHandler_TemplateTargetSplitsThemeContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+
+	var promptsMutex sync.Mutex
+	var prompts []string
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		promptsMutex.Lock()
+		for _, message := range request.Messages {
+			if strings.Contains(message.Content, "Generate a slide based on") {
+				prompts = append(prompts, message.Content)
+			}
+		}
+		promptsMutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+		DisableAudio:  true,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+
+	themes := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeSummaryPlan}
+	tooMany, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en",
+		Template: &models.DeckTemplate{TargetSlides: services.MaxTargetSlides + 1}})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(tooMany)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a target above %d slides, got %d", services.MaxTargetSlides, w.Code)
+	}
+
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en",
+		Template: &models.DeckTemplate{Name: "detailed", TargetSlides: 3}})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected generation to start, got %d: %s", w.Code, w.Body.String())
+	}
+	var started models.SlideGenerationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &started); err != nil {
+		t.Fatalf("Failed to parse generation response: %v", err)
+	}
+
+	var status struct {
+		Status string                 `json:"status"`
+		Themes []models.SlideTheme    `json:"themes"`
+		Slides []*models.SlideContent `json:"slides"`
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for status.Status != "completed" {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for slide generation to complete")
+		}
+		time.Sleep(20 * time.Millisecond)
+		w := performRequest(router, http.MethodGet, "/slides/"+started.SlideID+"/status")
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+			t.Fatalf("Failed to parse status response: %v", err)
+		}
+	}
+
+	want := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeProjectOverview, models.ThemeSummaryPlan}
+	if !reflect.DeepEqual(status.Themes, want) || len(status.Slides) != len(want) {
+		t.Fatalf("Expected %d slides for themes %v, got %d for %v", len(want), want, len(status.Slides), status.Themes)
+	}
+
+	promptsMutex.Lock()
+	defer promptsMutex.Unlock()
+	if len(prompts) != len(want) {
+		t.Fatalf("Expected one slide prompt per slide, got %d", len(prompts))
+	}
+	split := map[string]bool{}
+	for _, prompt := range prompts {
+		for _, part := range []string{"slide 1 of 2", "slide 2 of 2"} {
+			if strings.Contains(prompt, "split across 2 slides and this is "+part) {
+				split[part] = true
+			}
+		}
+		if strings.Contains(prompt, "for theme: Generate a slide for project summary") && strings.Contains(prompt, "split across") {
+			t.Errorf("Expected the unsplit theme to be generated as one slide, got: %s", prompt)
+		}
+	}
+	if len(split) != 2 {
+		t.Errorf("Expected the split theme to ask for both of its parts, got %v", split)
+	}
+}
+
+// TestSlideHandler_ExportSlideZip tests that the ZIP bundle contains one
+// markdown and one audio entry per slide plus a manifest
+func TestSlideHandler_ExportSlideZip(t *testing.T) {
+	speechServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(newTestWAV(time.Second))
+	}))
+	defer speechServer.Close()
+
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	record := newTestSessionRecord("zip-session")
+	record.Slides = []*models.SlideContent{
+		{Index: 0, Theme: models.ThemeProjectOverview, Title: "概要", Markdown: "# 概要"},
+		{Index: 1, Theme: models.ThemeProjectProgress, Title: "進捗", Markdown: "# 進捗"},
+	}
+	record.AudioFiles = []*models.SlideAudio{
+		{SlideIndex: 0, AudioURL: "/cache/first.wav", Duration: 1},
+		{SlideIndex: 1, AudioURL: "/cache/second.wav", Duration: 1},
+	}
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:      "openai",
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+		MCPSpeechURL:    speechServer.URL,
+	})
+	router := gin.New()
+	router.GET("/slides/:slideId/export/zip", handler.ExportSlideZip)
+
+	w := performRequest(router, http.MethodGet, "/slides/zip-session/export/zip")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/zip" {
+		t.Errorf("Expected application/zip, got %q", contentType)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatalf("Failed to read ZIP: %v", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+	for _, name := range []string{"slides/slide-01.md", "slides/slide-02.md", "audio/slide-01.wav", "audio/slide-02.wav", "manifest.json"} {
+		if files[name] == nil {
+			t.Errorf("Expected %s in the bundle, got %v", name, archive.File)
+		}
+	}
+	if len(files) != 5 {
+		t.Errorf("Expected 5 entries, got %d", len(files))
+	}
+
+	manifestFile, err := files["manifest.json"].Open()
+	if err != nil {
+		t.Fatalf("Failed to open manifest: %v", err)
+	}
+	defer manifestFile.Close()
+	var manifest models.DeckManifest
+	if err := json.NewDecoder(manifestFile).Decode(&manifest); err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+	if len(manifest.Slides) != 2 || manifest.Slides[1].MarkdownFile != "slides/slide-02.md" || manifest.Slides[1].AudioFile != "audio/slide-02.wav" {
+		t.Errorf("Expected the manifest to list each slide's files, got %+v", manifest.Slides)
+	}
+
+	if w := performRequest(router, http.MethodGet, "/slides/missing-session/export/zip"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing session, got %d", w.Code)
+	}
+}
+
+// newTestWAV returns a mono 16-bit 16 kHz PCM WAV file of the given length
+func newTestWAV(d time.Duration) []byte {
+	format := services.WAVFormat{
+		AudioFormat:   1,
+		Channels:      1,
+		SampleRate:    16000,
+		ByteRate:      32000,
+		BlockAlign:    2,
+		BitsPerSample: 16,
+	}
+	data := bytes.Repeat([]byte{0x10, 0x00}, int(d.Seconds()*16000))
+	return services.EncodeWAV(&services.WAVAudio{Format: format, Data: data})
+}
+
+// TestSlideHandler_GetFullSlideAudio tests that slide audio is fetched from the
+// speech server and joined in slide order with silence between slides, and that
+// slides without audio are skipped
+func TestSlideHandler_GetFullSlideAudio(t *testing.T) {
+	speechServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cache/first.wav":
+			w.Write(newTestWAV(2 * time.Second))
+		case "/cache/second.wav":
+			w.Write(newTestWAV(time.Second))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer speechServer.Close()
+
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	record := newTestSessionRecord("full-audio-session")
+	record.Slides = []*models.SlideContent{
+		{Index: 0, Theme: models.ThemeProjectOverview, Title: "概要"},
+		{Index: 1, Theme: models.ThemeProjectProgress, Title: "進捗"},
+		{Index: 2, Theme: models.ThemeSummaryPlan, Title: "総括"},
+	}
+	record.AudioFiles = []*models.SlideAudio{
+		{SlideIndex: 1, AudioURL: "/cache/second.wav", Duration: 1},
+		{SlideIndex: 0, AudioURL: "/cache/first.wav", Duration: 2},
+	}
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:      "openai",
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+		MCPSpeechURL:    speechServer.URL,
+	})
+	router := gin.New()
+	router.GET("/slides/:slideId/audio/full", handler.GetFullSlideAudio)
+
+	w := performRequest(router, http.MethodGet, "/slides/full-audio-session/audio/full?gapMs=500")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "audio/wav" {
+		t.Errorf("Expected audio/wav, got %q", contentType)
+	}
+	if disposition := w.Header().Get("Content-Disposition"); !strings.Contains(disposition, `filename="full-audio-session.wav"`) {
+		t.Errorf("Expected a downloadable .wav file, got %q", disposition)
+	}
+	if skipped := w.Header().Get("X-Skipped-Slides"); skipped != "2" {
+		t.Errorf("Expected slide 2 without audio to be skipped, got %q", skipped)
+	}
+
+	combined, err := services.ParseWAV(w.Body.Bytes())
+	if err != nil {
+		t.Fatalf("Expected a valid WAV file: %v", err)
+	}
+	if duration := combined.Duration(); duration != 3500*time.Millisecond {
+		t.Errorf("Expected 2s + 0.5s gap + 1s = 3.5s of audio, got %v", duration)
+	}
+
+	if w := performRequest(router, http.MethodGet, "/slides/full-audio-session/audio/full?gapMs=-1"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a negative gap, got %d", w.Code)
+	}
+	if w := performRequest(router, http.MethodGet, "/slides/missing-session/audio/full"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing session, got %d", w.Code)
+	}
+}
+
+// TestSlideHandler_GetSlideSubtitles tests that the narration is exported as
+// WebVTT with one cue per sentence, timestamps that only move forward, and a
+// last cue ending at the total audio duration
+func TestSlideHandler_GetSlideSubtitles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	record := newTestSessionRecord("subtitle-session")
+	record.Narrations = []*models.SlideNarration{
+		{SlideIndex: 0, Text: "概要を説明します。進捗は順調です。", Language: "ja"},
+		{SlideIndex: 1, Text: "Version 1.5 shipped. Three issues remain open!", Language: "en"},
+	}
+	record.AudioFiles = []*models.SlideAudio{
+		{SlideIndex: 1, AudioURL: "/cache/second.wav", Duration: 7},
+		{SlideIndex: 0, AudioURL: "/cache/first.wav", Duration: 5},
+	}
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	_, router := newTestSlideRouter(t, dir, 0)
+
+	w := performRequest(router, http.MethodGet, "/slides/subtitle-session/subtitles?format=vtt")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/vtt") {
+		t.Errorf("Expected a text/vtt response, got %q", contentType)
+	}
+	if disposition := w.Header().Get("Content-Disposition"); !strings.Contains(disposition, `filename="subtitle-session.vtt"`) {
+		t.Errorf("Expected a downloadable .vtt file, got %q", disposition)
+	}
+
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "WEBVTT\n") {
+		t.Fatalf("Expected a WEBVTT header, got %q", body)
+	}
+	parseTimestamp := func(value string) time.Duration {
+		var h, m, s, ms int
+		if _, err := fmt.Sscanf(value, "%d:%d:%d.%d", &h, &m, &s, &ms); err != nil {
+			t.Fatalf("Malformed timestamp %q: %v", value, err)
+		}
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+			time.Duration(s)*time.Second + time.Duration(ms)*time.Millisecond
+	}
+
+	var texts []string
+	var previousEnd time.Duration
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		start, end, found := strings.Cut(line, " --> ")
+		if !found {
+			continue
+		}
+		startTime, endTime := parseTimestamp(start), parseTimestamp(end)
+		if startTime != previousEnd || endTime <= startTime {
+			t.Errorf("Expected cue %q to start at %v and move forward", line, previousEnd)
+		}
+		previousEnd = endTime
+		texts = append(texts, lines[i+1])
+	}
+
+	expected := []string{"概要を説明します。", "進捗は順調です。", "Version 1.5 shipped.", "Three issues remain open!"}
+	if strings.Join(texts, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected cues %q, got %q", expected, texts)
+	}
+	if previousEnd != 12*time.Second {
+		t.Errorf("Expected cues to end at the total audio duration of 12s, got %v", previousEnd)
+	}
+
+	w = performRequest(router, http.MethodGet, "/slides/subtitle-session/subtitles?format=srt")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "00:00:12,000") {
+		t.Errorf("Expected SRT timestamps with comma separators, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := performRequest(router, http.MethodGet, "/slides/subtitle-session/subtitles?format=ass"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unsupported format, got %d", w.Code)
+	}
+	if w := performRequest(router, http.MethodGet, "/slides/missing-session/subtitles"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing session, got %d", w.Code)
+	}
+}
+
+// TestSlideSession_AudioRespectsSessionCap tests that concurrent audio synthesis
+// within a session never exceeds the per-session cap
+func TestSlideSession_AudioRespectsSessionCap(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer server.Close()
+
+	// No global cap so that only the session cap applies
+	service := services.NewSlideService(&config.Config{
+		AIProvider:   "openai",
+		MCPSpeechURL: server.URL,
+	})
+	session := &handlers.SlideSession{
+		AudioLimiter: services.NewConcurrencyLimiter(2),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			narration := &models.SlideNarration{
+				SlideIndex: i,
+				Text:       fmt.Sprintf("Narration for slide %d", i),
+				Language:   "en",
+			}
+			if _, err := session.GenerateAudio(service, narration); err != nil {
+				t.Errorf("GenerateAudio failed for slide %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent syntheses, got %d", maxInFlight)
+	}
+	if maxInFlight == 0 {
+		t.Error("Expected audio synthesis requests to reach the speech server")
+	}
+}
+
+// TestSlideSession_BuildReportAggregatesSlides tests that the generation report
+// combines per-slide content, narration, audio, and warning metadata
+func TestSlideSession_BuildReportAggregatesSlides(t *testing.T) {
+	session := &handlers.SlideSession{
+		ID:       "report-session",
+		Status:   "completed",
+		Language: "en",
+		Slides: []*models.SlideContent{
+			{Index: 1, Theme: models.ThemeRiskAnalysis, Title: "Risks", PlainText: "Two risks remain", TokensUsed: 200},
+			{Index: 0, Theme: models.ThemeProjectOverview, Title: "Overview", PlainText: "Project is on track today", TokensUsed: 300},
+		},
+		Narrations: []*models.SlideNarration{
+			{SlideIndex: 0, Text: "Overview narration", TokensUsed: 50},
+			{SlideIndex: 1, Text: "Risk narration", TokensUsed: 40},
+		},
+		AudioFiles: []*models.SlideAudio{
+			{SlideIndex: 0, AudioURL: "/cache/0.wav", Duration: 12},
+		},
+	}
+	session.AddWarning(1, "Failed to generate audio for slide 2")
+	session.AddWarning(2, "Failed to generate slide 3")
+
+	report := session.BuildReport(2.5)
+
+	if report.SlideID != "report-session" || report.TotalSlides != 2 {
+		t.Fatalf("Unexpected report header: %+v", report)
+	}
+	overview, risks := report.Slides[0], report.Slides[1]
+	if overview.Index != 0 || overview.Title != "Overview" || overview.Theme != models.ThemeProjectOverview {
+		t.Errorf("Expected slides ordered by index, got %+v", overview)
+	}
+	if overview.WordCount != 5 || overview.AudioDuration != 12 || overview.TokensUsed != 350 {
+		t.Errorf("Unexpected overview metadata: %+v", overview)
+	}
+	if risks.WordCount != 3 || risks.AudioDuration != 0 || risks.TokensUsed != 240 {
+		t.Errorf("Unexpected risk metadata: %+v", risks)
+	}
+	if len(risks.Warnings) != 1 || len(overview.Warnings) != 0 {
+		t.Errorf("Expected the audio warning on the risk slide, got %v and %v", overview.Warnings, risks.Warnings)
+	}
+	if len(report.Warnings) != 1 || report.Warnings[0] != "Failed to generate slide 3" {
+		t.Errorf("Expected the failed slide warning at the session level, got %v", report.Warnings)
+	}
+	if report.TotalWords != 8 || report.TotalAudioDuration != 12 || report.TotalTokens != 590 {
+		t.Errorf("Unexpected report totals: %+v", report)
+	}
+	if report.CostPerMillion != 2.5 || report.EstimatedCost != 0.0015 {
+		t.Errorf("Expected 590 tokens at 2.5 USD per million to cost 0.0015 USD, got %+v", report)
+	}
+}
+
+// TestSlideHandler_GenerateSlidesConcurrently tests that every theme produces a
+// slide at its own index even when AI responses complete out of order
+func TestSlideHandler_GenerateSlidesConcurrently(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+
+	// Earlier requests take longer so slides complete in reverse order
+	var calls int32
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		if call <= 3 {
+			time.Sleep(time.Duration(4-call) * 30 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	speech := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer speech.Close()
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:          "openai",
+		OpenAIAPIKey:        "test-key",
+		OpenAIBaseURL:       openAI.URL,
+		MCPBacklogURL:       bridge.URL,
+		MCPSpeechURL:        speech.URL,
+		SlideMaxConcurrency: 3,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+
+	themes := []models.SlideTheme{
+		models.ThemeProjectOverview,
+		models.ThemeProjectProgress,
+		models.ThemeIssueManagement,
+		models.ThemeRiskAnalysis,
+		models.ThemeSummaryPlan,
+	}
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected generation to start, got %d: %s", w.Code, w.Body.String())
+	}
+	var started models.SlideGenerationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &started); err != nil {
+		t.Fatalf("Failed to parse generation response: %v", err)
+	}
+
+	var status struct {
+		Status     string                   `json:"status"`
+		Slides     []*models.SlideContent   `json:"slides"`
+		Narrations []*models.SlideNarration `json:"narrations"`
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for status.Status != "completed" {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for slide generation to complete")
+		}
+		time.Sleep(20 * time.Millisecond)
+		w := performRequest(router, http.MethodGet, "/slides/"+started.SlideID+"/status")
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+			t.Fatalf("Failed to parse status response: %v", err)
+		}
+	}
+
+	if len(status.Slides) != len(themes) {
+		t.Fatalf("Expected %d slides, got %d", len(themes), len(status.Slides))
+	}
+	for i, slide := range status.Slides {
+		if slide.Index != i || slide.Theme != themes[i] {
+			t.Errorf("Expected slide %d with theme %s, got index %d with theme %s", i, themes[i], slide.Index, slide.Theme)
+		}
+	}
+	if len(status.Narrations) != len(themes) {
+		t.Errorf("Expected %d narrations, got %d", len(themes), len(status.Narrations))
+	}
+}
+
+// TestSlideHandler_PrefetchesThemeData tests that every theme's Backlog data is
+// fetched before the first slide is generated and is not fetched again while
+// the slides are generated one at a time
+func TestSlideHandler_PrefetchesThemeData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var bridgeCalls int32
+	var toolsMu sync.Mutex
+	tools := make(map[string]bool)
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string `json:"tool"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		atomic.AddInt32(&bridgeCalls, 1)
+		toolsMu.Lock()
+		tools[payload.Tool] = true
+		toolsMu.Unlock()
+
+		text := `{"id": 1}`
+		if payload.Tool == "get_issues" {
+			text = "[]"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": text}},
+			},
+		})
+	}))
+	defer bridge.Close()
+
+	// Record which tools had been called when the first slide reached the AI provider
+	var firstAIOnce sync.Once
+	var callsAtFirstAI int32
+	var toolsAtFirstAI map[string]bool
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		firstAIOnce.Do(func() {
+			callsAtFirstAI = atomic.LoadInt32(&bridgeCalls)
+			toolsMu.Lock()
+			toolsAtFirstAI = make(map[string]bool, len(tools))
+			for tool := range tools {
+				toolsAtFirstAI[tool] = true
+			}
+			toolsMu.Unlock()
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:          "openai",
+		OpenAIAPIKey:        "test-key",
+		OpenAIBaseURL:       openAI.URL,
+		MCPBacklogURL:       bridge.URL,
+		DisableAudio:        true,
+		SlideMaxConcurrency: 1,
+		PrefetchThemeData:   true,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+
+	themes := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeProjectProgress, models.ThemeCodebaseActivity}
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+	var started models.SlideGenerationResponse
+	json.Unmarshal(w.Body.Bytes(), &started)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var status struct {
+			Status string `json:"status"`
+		}
+		json.Unmarshal(performRequest(router, http.MethodGet, "/slides/"+started.SlideID+"/status").Body.Bytes(), &status)
+		if status.Status == "completed" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for generation to complete")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// get_project, count_issues, and get_git_repositories belong to the three themes
+	for _, tool := range []string{"get_project", "count_issues", "get_git_repositories"} {
+		if !toolsAtFirstAI[tool] {
+			t.Errorf("Expected %s to be fetched before the first slide was generated", tool)
+		}
+	}
+	if total := atomic.LoadInt32(&bridgeCalls); callsAtFirstAI == 0 || total != callsAtFirstAI {
+		t.Errorf("Expected all %d Backlog calls to happen before generation, %d did", total, callsAtFirstAI)
+	}
+}
+
+// TestSlideHandler_RecordsThemeTimings tests that the session report includes
+// content, narration, and audio timings for every generated theme
+func TestSlideHandler_RecordsThemeTimings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+
+	stageDelay := 20 * time.Millisecond
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(stageDelay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	speech := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(stageDelay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer speech.Close()
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+		MCPSpeechURL:  speech.URL,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+	router.GET("/slides/:slideId/report", handler.GetSlideReport)
+
+	themes := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeRiskAnalysis}
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+	var started models.SlideGenerationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &started); err != nil {
+		t.Fatalf("Failed to parse generation response: %v", err)
+	}
+
+	var status struct {
+		Status string `json:"status"`
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for status.Status != "completed" {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for slide generation to complete")
+		}
+		time.Sleep(20 * time.Millisecond)
+		w := performRequest(router, http.MethodGet, "/slides/"+started.SlideID+"/status")
+		json.Unmarshal(w.Body.Bytes(), &status)
+	}
+
+	var report models.GenerationReport
+	w = performRequest(router, http.MethodGet, "/slides/"+started.SlideID+"/report")
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+
+	if len(report.Timings) != len(themes) {
+		t.Fatalf("Expected timings for %d themes, got %d", len(themes), len(report.Timings))
+	}
+	minimum := stageDelay.Milliseconds()
+	for i, timing := range report.Timings {
+		if timing.Index != i || timing.Theme != themes[i] {
+			t.Errorf("Expected timing %d for theme %s, got %+v", i, themes[i], timing)
+		}
+		if timing.ContentMs < minimum || timing.NarrationMs < minimum || timing.AudioMs < minimum {
+			t.Errorf("Expected every stage of theme %s to take at least %dms, got %+v", themes[i], minimum, timing)
+		}
+		if timing.TotalMs != timing.ContentMs+timing.NarrationMs+timing.AudioMs {
+			t.Errorf("Expected total to sum the stages, got %+v", timing)
+		}
+	}
+}
+
+// TestSlideHandler_ResolvesAutoLanguage tests that an "auto" language request
+// is resolved from the Backlog user's language setting
+func TestSlideHandler_ResolvesAutoLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(userLang string, defaultLanguage string) *gin.Engine {
+		bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				Tool string `json:"tool"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			text := fmt.Sprintf(`{"tool": %q}`, payload.Tool)
+			if payload.Tool == "get_myself" {
+				text = fmt.Sprintf(`{"id": 1, "name": "Tester", "lang": %s}`, userLang)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"result": map[string]interface{}{
+					"content": []map[string]interface{}{{"type": "text", "text": text}},
+				},
+			})
+		}))
+		t.Cleanup(bridge.Close)
+
+		handler := handlers.NewSlideHandler(&config.Config{
+			AIProvider:      "openai",
+			MCPBacklogURL:   bridge.URL,
+			DefaultLanguage: defaultLanguage,
+			DisableAudio:    true,
+		})
+		router := gin.New()
+		router.Use(withBacklogToken("token"))
+		router.POST("/slides/generate", handler.GenerateSlides)
+		return router
+	}
+
+	generate := func(router *gin.Engine, language string) models.SlideGenerationResponse {
+		t.Helper()
+		body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: []models.SlideTheme{models.ThemeProjectOverview}, Language: language})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+		var response models.SlideGenerationResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse generation response: %v", err)
+		}
+		return response
+	}
+
+	if response := generate(newRouter(`"ja"`, "en"), "auto"); response.Language != "ja" {
+		t.Errorf("Expected a ja user to resolve to ja, got %q", response.Language)
+	}
+	if response := generate(newRouter(`"en"`, "ja"), "auto"); response.Language != "en" {
+		t.Errorf("Expected an en user to resolve to en, got %q", response.Language)
+	}
+	if response := generate(newRouter(`null`, "en"), "auto"); response.Language != "en" {
+		t.Errorf("Expected an unset language to fall back to the default, got %q", response.Language)
+	}
+	if response := generate(newRouter(`"en"`, "ja"), "ja"); response.Language != "ja" {
+		t.Errorf("Expected an explicit language to be kept, got %q", response.Language)
+	}
+}
+
+// TestSlideHandler_TemperatureOverride tests that an administrator's
+// X-AI-Temperature header is applied to AI calls, and that out-of-range values
+// and non-administrators are rejected
+func TestSlideHandler_TemperatureOverride(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(roleType int) (*gin.Engine, chan float64) {
+		bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				Tool string `json:"tool"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			text := fmt.Sprintf(`{"tool": %q}`, payload.Tool)
+			if payload.Tool == "get_myself" {
+				text = fmt.Sprintf(`{"id": 1, "name": "Tester", "roleType": %d}`, roleType)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"result": map[string]interface{}{
+					"content": []map[string]interface{}{{"type": "text", "text": text}},
+				},
+			})
+		}))
+		t.Cleanup(bridge.Close)
+
+		temperatures := make(chan float64, 10)
+		openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				Temperature float64 `json:"temperature"`
+			}
+			json.NewDecoder(r.Body).Decode(&request)
+			select {
+			case temperatures <- request.Temperature:
+			default:
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+		}))
+		t.Cleanup(openAI.Close)
+
+		handler := handlers.NewSlideHandler(&config.Config{
+			AIProvider:    "openai",
+			OpenAIAPIKey:  "test-key",
+			OpenAIBaseURL: openAI.URL,
+			MCPBacklogURL: bridge.URL,
+			DisableAudio:  true,
+		})
+		router := gin.New()
+		router.Use(withBacklogToken("token"))
+		router.POST("/slides/generate", handler.GenerateSlides)
+		return router, temperatures
+	}
+
+	generate := func(router *gin.Engine, temperature string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: []models.SlideTheme{models.ThemeProjectOverview}, Language: "en"})
+		req := httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body))
+		req.Header.Set(handlers.TemperatureHeader, temperature)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("applies an in-range value for administrators", func(t *testing.T) {
+		router, temperatures := newRouter(models.RoleTypeAdministrator)
+		if w := generate(router, "0.2"); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		select {
+		case temperature := <-temperatures:
+			if temperature != 0.2 {
+				t.Errorf("Expected the AI call to use temperature 0.2, got %v", temperature)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the AI call")
+		}
+	})
+
+	t.Run("rejects out-of-range values", func(t *testing.T) {
+		router, _ := newRouter(models.RoleTypeAdministrator)
+		for _, value := range []string{"1.5", "-0.1", "warm"} {
+			if w := generate(router, value); w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400 for %q, got %d", value, w.Code)
+			}
+		}
+	})
+
+	t.Run("rejects non-administrators", func(t *testing.T) {
+		router, temperatures := newRouter(2)
+		if w := generate(router, "0.2"); w.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", w.Code)
+		}
+		select {
+		case <-temperatures:
+			t.Error("Expected no AI call for a rejected request")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("uses the default without the header", func(t *testing.T) {
+		router, temperatures := newRouter(2)
+		if w := generate(router, ""); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		select {
+		case temperature := <-temperatures:
+			if temperature != services.DefaultTemperature {
+				t.Errorf("Expected the default temperature %v, got %v", services.DefaultTemperature, temperature)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the AI call")
+		}
+	})
+}
+
+// TestSlideHandler_RestoredSessionKeepsTemperature tests that a temperature
+// override is persisted with the session and used when a slide of the restored
+// session is regenerated
+func TestSlideHandler_RestoredSessionKeepsTemperature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+	temperatures := make(chan float64, 10)
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Temperature float64 `json:"temperature"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		temperatures <- request.Temperature
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	temperature := 0.3
+	record := newTestSessionRecord("tuned-session")
+	record.Temperature = &temperature
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:      "openai",
+		OpenAIAPIKey:    "test-key",
+		OpenAIBaseURL:   openAI.URL,
+		MCPBacklogURL:   bridge.URL,
+		DisableAudio:    true,
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/:slideId/regenerate", handler.RegenerateSlide)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/tuned-session/regenerate", strings.NewReader(`{"slideIndex": 0}`)))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status 202, got %d: %s", w.Code, w.Body.String())
+	}
+	select {
+	case got := <-temperatures:
+		if got != temperature {
+			t.Errorf("Expected the regenerated slide to use temperature %v, got %v", temperature, got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the AI call")
+	}
+
+	// Wait for the regenerated narration to be persisted before the store
+	// directory is removed
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stored, err := store.Load("tuned-session")
+		if err == nil && len(stored.Narrations) == 1 && stored.Narrations[0].Text != record.Narrations[0].Text {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the regenerated narration to be persisted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestSlideHandler_TenantAICredentials tests that a tenant's X-AI-Credentials
+// header replaces the configured AI key for its request when enabled, and is
+// rejected when disabled or invalid
+func TestSlideHandler_TenantAICredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+
+	newRouter := func(allowed bool) (*gin.Engine, chan string) {
+		keys := make(chan string, 10)
+		openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case keys <- r.Header.Get("Authorization"):
+			default:
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+		}))
+		t.Cleanup(openAI.Close)
+
+		handler := handlers.NewSlideHandler(&config.Config{
+			AIProvider:               "openai",
+			OpenAIAPIKey:             "server-key",
+			OpenAIBaseURL:            openAI.URL,
+			MCPBacklogURL:            bridge.URL,
+			DisableAudio:             true,
+			AllowTenantAICredentials: allowed,
+		})
+		router := gin.New()
+		router.Use(withBacklogToken("token"))
+		router.POST("/slides/generate", handler.GenerateSlides)
+		return router, keys
+	}
+
+	generate := func(router *gin.Engine, credentials string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: []models.SlideTheme{models.ThemeProjectOverview}, Language: "en"})
+		req := httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body))
+		if credentials != "" {
+			req.Header.Set(handlers.AICredentialsHeader, credentials)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	awaitKey := func(t *testing.T, keys chan string) string {
+		select {
+		case key := <-keys:
+			return key
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the AI call")
+			return ""
+		}
+	}
+
+	t.Run("uses the tenant key instead of the configured key", func(t *testing.T) {
+		router, keys := newRouter(true)
+		if w := generate(router, `{"provider": "openai", "apiKey": "tenant-key"}`); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if key := awaitKey(t, keys); key != "Bearer tenant-key" {
+			t.Errorf("Expected the AI call to use the tenant key, got %q", key)
+		}
+	})
+
+	t.Run("uses the configured key without the header", func(t *testing.T) {
+		router, keys := newRouter(true)
+		if w := generate(router, ""); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if key := awaitKey(t, keys); key != "Bearer server-key" {
+			t.Errorf("Expected the AI call to use the configured key, got %q", key)
+		}
+	})
+
+	t.Run("rejects invalid credentials", func(t *testing.T) {
+		router, _ := newRouter(true)
+		for _, credentials := range []string{`not json`, `{"provider": "openai"}`, `{"provider": "watson", "apiKey": "k"}`, `{"provider": "bedrock", "apiKey": "k"}`,
+			`{"provider": "bedrock", "awsAccessKeyId": "AKIA", "awsSecretAccessKey": "s", "awsRegion": "evil.example.com/x"}`} {
+			if w := generate(router, credentials); w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400 for %s, got %d", credentials, w.Code)
+			}
+		}
+	})
+
+	t.Run("rejects the header when disabled", func(t *testing.T) {
+		router, keys := newRouter(false)
+		if w := generate(router, `{"provider": "openai", "apiKey": "tenant-key"}`); w.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", w.Code)
+		}
+		select {
+		case <-keys:
+			t.Error("Expected no AI call for a rejected request")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
+// TestSlideHandler_BroadcastsProgress tests that a progress message is broadcast
+// after each slide finishes, ending at 100 percent before completion
+func TestSlideHandler_BroadcastsProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+
+	// Hold AI responses until the WebSocket client is connected
+	release := make(chan struct{})
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	speech := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer speech.Close()
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+		MCPSpeechURL:  speech.URL,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/ws/slides/:slideId", handler.HandleWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	themes := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeProjectProgress, models.ThemeRiskAnalysis, models.ThemeSummaryPlan}
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en"})
+	resp, err := http.Post(server.URL+"/slides/generate", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to start generation: %v", err)
+	}
+	var started models.SlideGenerationResponse
+	json.NewDecoder(resp.Body).Decode(&started)
+	resp.Body.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/slides/" + started.SlideID
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		close(release)
+		t.Fatalf("Failed to connect WebSocket: %v", err)
+	}
+	defer conn.Close()
+	// The session state is sent under the lock that registers the connection,
+	// so once it arrives no later broadcast can be missed
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	var state models.WebSocketMessage
+	if err := conn.ReadJSON(&state); err != nil || state.Type != models.MessageTypeSessionState {
+		close(release)
+		t.Fatalf("Expected the session state first, got %+v (%v)", state, err)
+	}
+	close(release)
+
+	var progress []models.GenerationProgress
+	for {
+		var message struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("Failed to read WebSocket message: %v", err)
+		}
+		if message.Type == models.MessageTypePresentationComplete {
+			break
+		}
+		if message.Type == models.MessageTypeProgress {
+			var update models.GenerationProgress
+			if err := json.Unmarshal(message.Data, &update); err != nil {
+				t.Fatalf("Failed to parse progress message: %v", err)
+			}
+			progress = append(progress, update)
+		}
+	}
+
+	if len(progress) != len(themes) {
+		t.Fatalf("Expected %d progress messages, got %d", len(themes), len(progress))
+	}
+	for i, update := range progress {
+		if update.Completed != i+1 || update.Total != len(themes) {
+			t.Errorf("Unexpected progress message %d: %+v", i, update)
+		}
+		if update.Percent != (i+1)*100/len(themes) {
+			t.Errorf("Expected %d percent, got %d", (i+1)*100/len(themes), update.Percent)
+		}
+	}
+	if progress[len(progress)-1].Percent != 100 {
+		t.Errorf("Expected final progress of 100 percent, got %d", progress[len(progress)-1].Percent)
+	}
+}
+
+// TestSlideHandler_ReplaysMessagesToLateClients tests that a client connecting
+// mid-generation receives the slide_content messages broadcast before it joined
+func TestSlideHandler_ReplaysMessagesToLateClients(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+
+	// The first slide's content and narration are answered immediately; later
+	// AI calls wait until the late client has connected
+	var calls int32
+	release := make(chan struct{})
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 2 {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+	defer func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	}()
+
+	speech := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer speech.Close()
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:          "openai",
+		OpenAIAPIKey:        "test-key",
+		OpenAIBaseURL:       openAI.URL,
+		MCPBacklogURL:       bridge.URL,
+		MCPSpeechURL:        speech.URL,
+		SlideMaxConcurrency: 1,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+	router.GET("/ws/slides/:slideId", handler.HandleWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	themes := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeSummaryPlan}
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en"})
+	resp, err := http.Post(server.URL+"/slides/generate", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to start generation: %v", err)
+	}
+	var started models.SlideGenerationResponse
+	json.NewDecoder(resp.Body).Decode(&started)
+	resp.Body.Close()
+
+	// Wait until the first slide has been broadcast. Either theme may win the
+	// single generation slot, so note which one finished first.
+	firstIndex := -1
+	deadline := time.Now().Add(10 * time.Second)
+	for firstIndex < 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the first slide")
+		}
+		var status struct {
+			Slides []*models.SlideContent `json:"slides"`
+		}
+		w := performRequest(router, http.MethodGet, "/slides/"+started.SlideID+"/status")
+		json.Unmarshal(w.Body.Bytes(), &status)
+		if len(status.Slides) > 0 {
+			firstIndex = status.Slides[0].Index
+			continue
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/slides/" + started.SlideID
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect WebSocket: %v", err)
+	}
+	defer conn.Close()
+
+	var contentIndexes []int
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		var message struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("Failed to read WebSocket message: %v", err)
+		}
+		if message.Type == models.MessageTypeSlideContent {
+			var content models.SlideContent
+			json.Unmarshal(message.Data, &content)
+			contentIndexes = append(contentIndexes, content.Index)
+			// The replayed first slide arrives before any live update is released
+			if len(contentIndexes) == 1 {
+				close(release)
+			}
+		}
+		if message.Type == models.MessageTypePresentationComplete {
+			break
+		}
+	}
+
+	if len(contentIndexes) != 2 || contentIndexes[0] != firstIndex || contentIndexes[1] != 1-firstIndex {
+		t.Errorf("Expected replayed slide %d followed by live slide %d, got %v", firstIndex, 1-firstIndex, contentIndexes)
+	}
+}
+
+// TestSlideHandler_BroadcastsSlidePartials tests that with AI_STREAMING enabled
+// a regenerated slide's markdown is broadcast as slide_partial messages that
+// add up to the slide_content message finalizing it
+func TestSlideHandler_BroadcastsSlidePartials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+	fragments := []string{"# Partial", " Slide\n", "- point"}
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Stream bool `json:"stream"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if !request.Stream {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"choices": [{"message": {"content": "Narration"}}]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, fragment := range fragments {
+			chunk, _ := json.Marshal(map[string]interface{}{
+				"choices": []map[string]interface{}{{"delta": map[string]string{"content": fragment}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer openAI.Close()
+
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	if err := store.Save(newTestSessionRecord("partial-session")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:      "openai",
+		OpenAIAPIKey:    "test-key",
+		OpenAIBaseURL:   openAI.URL,
+		MCPBacklogURL:   bridge.URL,
+		AIStreaming:     true,
+		DisableAudio:    true,
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/:slideId/regenerate", handler.RegenerateSlide)
+	router.GET("/ws/slides/:slideId", handler.HandleWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/slides/partial-session"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect WebSocket: %v", err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(3 * time.Second)
+	for handler.ActiveConnections("partial-session") != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the WebSocket connection")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	resp, err := http.Post(server.URL+"/slides/partial-session/regenerate", "application/json", strings.NewReader(`{"slideIndex": 0}`))
+	if err != nil {
+		t.Fatalf("Failed to regenerate slide: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("Expected status 202, got %d", resp.StatusCode)
+	}
+
+	var partials []string
+	var content models.SlideContent
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	// Read through the narration, the last message of the regeneration
+	for {
+		var message struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("Failed to read WebSocket message: %v", err)
+		}
+		switch message.Type {
+		case models.MessageTypeSlidePartial:
+			var partial models.SlidePartial
+			json.Unmarshal(message.Data, &partial)
+			if partial.SlideIndex != 0 {
+				t.Errorf("Expected partials of slide 0, got slide %d", partial.SlideIndex)
+			}
+			if content.Markdown != "" {
+				t.Error("Expected every partial to arrive before the final slide_content")
+			}
+			partials = append(partials, partial.Delta)
+		case models.MessageTypeSlideContent:
+			json.Unmarshal(message.Data, &content)
+		}
+		if message.Type == models.MessageTypeSlideNarration {
+			break
+		}
+	}
+
+	if strings.Join(partials, "") != strings.Join(fragments, "") || len(partials) != len(fragments) {
+		t.Errorf("Expected one partial per streamed fragment, got %q", partials)
+	}
+	if content.Markdown != strings.Join(fragments, "") {
+		t.Errorf("Expected slide_content to hold the assembled markdown, got %q", content.Markdown)
+	}
+}
+
+// TestSlideHandler_DropsUnresponsiveWebSocketClients tests that a client which
+// stops answering pings is removed while a responsive client stays connected
+func TestSlideHandler_DropsUnresponsiveWebSocketClients(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	if err := store.Save(newTestSessionRecord("heartbeat-session")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:      "openai",
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+		WSPingInterval:  50 * time.Millisecond,
+		WSPongTimeout:   200 * time.Millisecond,
+	})
+	router := gin.New()
+	router.GET("/ws/slides/:slideId", handler.HandleWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	waitForConnections := func(expected int) {
+		t.Helper()
+		deadline := time.Now().Add(3 * time.Second)
+		for handler.ActiveConnections("heartbeat-session") != expected {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %d connections, got %d", expected, handler.ActiveConnections("heartbeat-session"))
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/slides/heartbeat-session"
+
+	// The responsive client keeps reading, so its pings are answered with pongs
+	alive, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect WebSocket: %v", err)
+	}
+	defer alive.Close()
+	var pings int32
+	alive.SetPingHandler(func(data string) error {
+		atomic.AddInt32(&pings, 1)
+		return alive.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := alive.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// The unresponsive client never reads, so it never answers a ping
+	dead, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect WebSocket: %v", err)
+	}
+	defer dead.Close()
+
+	waitForConnections(2)
+	waitForConnections(1)
+
+	// The responsive client survives enough further pings to outlast the pong
+	// timeout several times over
+	target := atomic.LoadInt32(&pings) + 10
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&pings) < target {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for pings, got %d of %d", atomic.LoadInt32(&pings), target)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if count := handler.ActiveConnections("heartbeat-session"); count != 1 {
+		t.Errorf("Expected the responsive client to stay connected, got %d connections", count)
+	}
+}
+
+// TestSlideHandler_SendsStateSnapshotOnConnect tests that a newly connected
+// client first receives a session_state snapshot with the current counts
+func TestSlideHandler_SendsStateSnapshotOnConnect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	store, err := services.NewFileSessionStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create file session store: %v", err)
+	}
+	record := newTestSessionRecord("snapshot-session")
+	record.Warnings = []*models.SlideWarning{{SlideIndex: 0, Message: "Audio fell back to a secondary engine"}}
+	if err := store.Save(record); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:      "openai",
+		SessionStore:    "file",
+		SessionStoreDir: dir,
+	})
+	router := gin.New()
+	router.GET("/ws/slides/:slideId", handler.HandleWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/slides/snapshot-session"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect WebSocket: %v", err)
+	}
+	defer conn.Close()
+
+	var message struct {
+		Type string              `json:"type"`
+		Data models.SessionState `json:"data"`
+	}
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatalf("Failed to read WebSocket message: %v", err)
+	}
+	if message.Type != models.MessageTypeSessionState {
+		t.Fatalf("Expected the first message to be %q, got %q", models.MessageTypeSessionState, message.Type)
+	}
+	expected := models.SessionState{Status: "completed", TotalSlides: 1, Slides: 1, Narrations: 1, AudioFiles: 1, Warnings: 1}
+	if message.Data != expected {
+		t.Errorf("Expected state %+v, got %+v", expected, message.Data)
+	}
+}
+
+// TestSlideHandler_DisableAudioSkipsSynthesis tests that with audio disabled the
+// deck completes with slides and narration but without calling the speech server
+func TestSlideHandler_DisableAudioSkipsSynthesis(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bridge, _ := newMockBridge(t, 0)
+
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	var speechCalls int32
+	speech := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&speechCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer speech.Close()
+
+	handler := handlers.NewSlideHandler(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+		MCPSpeechURL:  speech.URL,
+		DisableAudio:  true,
+	})
+	router := gin.New()
+	router.Use(withBacklogToken("token"))
+	router.POST("/slides/generate", handler.GenerateSlides)
+	router.GET("/slides/:slideId/status", handler.GetSlideStatus)
+	router.GET("/capabilities", handler.GetCapabilities)
+
+	themes := []models.SlideTheme{models.ThemeProjectOverview, models.ThemeSummaryPlan}
+	body, _ := json.Marshal(models.SlideGenerationRequest{ProjectID: "TEST", Themes: themes, Language: "en"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/slides/generate", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected generation to start, got %d: %s", w.Code, w.Body.String())
+	}
+	var started models.SlideGenerationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &started); err != nil {
+		t.Fatalf("Failed to parse generation response: %v", err)
+	}
+
+	var status struct {
+		Status     string                   `json:"status"`
+		Slides     []*models.SlideContent   `json:"slides"`
+		Narrations []*models.SlideNarration `json:"narrations"`
+		AudioFiles []*models.SlideAudio     `json:"audioFiles"`
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for status.Status != "completed" {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for slide generation to complete")
+		}
+		time.Sleep(20 * time.Millisecond)
+		w := performRequest(router, http.MethodGet, "/slides/"+started.SlideID+"/status")
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+			t.Fatalf("Failed to parse status response: %v", err)
+		}
+	}
+
+	if len(status.Slides) != len(themes) || len(status.Narrations) != len(themes) {
+		t.Errorf("Expected %d slides and narrations, got %d and %d", len(themes), len(status.Slides), len(status.Narrations))
+	}
+	if len(status.AudioFiles) != 0 {
+		t.Errorf("Expected no audio files, got %d", len(status.AudioFiles))
+	}
+	if calls := atomic.LoadInt32(&speechCalls); calls != 0 {
+		t.Errorf("Expected no speech synthesis requests, got %d", calls)
+	}
+
+	var capabilities map[string]bool
+	w = performRequest(router, http.MethodGet, "/capabilities")
+	if err := json.Unmarshal(w.Body.Bytes(), &capabilities); err != nil {
+		t.Fatalf("Failed to parse capabilities: %v", err)
+	}
+	if capabilities["audio"] || !capabilities["narration"] {
+		t.Errorf("Expected capabilities to report narration without audio, got %v", capabilities)
+	}
+}
@@ -1,11 +1,24 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"intelligent-presenter-backend/internal/api/handlers"
 	"intelligent-presenter-backend/internal/models"
 	"intelligent-presenter-backend/internal/services"
 	"intelligent-presenter-backend/pkg/config"
+	"intelligent-presenter-backend/pkg/logging"
 )
 
 // TestSlideService_NewSlideService tests the creation of a new SlideService instance
@@ -35,6 +48,9 @@ func TestSlideTheme_Constants(t *testing.T) {
 		models.ThemeNotifications,
 		models.ThemePredictiveAnalysis,
 		models.ThemeSummaryPlan,
+		models.ThemePeriodComparison,
+		models.ThemeSpaceOverview,
+		models.ThemePortfolioComparison,
 	}
 
 	expectedThemes := map[models.SlideTheme]string{
@@ -48,6 +64,9 @@ func TestSlideTheme_Constants(t *testing.T) {
 		models.ThemeNotifications:       "notifications",
 		models.ThemePredictiveAnalysis:  "predictive_analysis",
 		models.ThemeSummaryPlan:         "summary_plan",
+		models.ThemePeriodComparison:    "period_comparison",
+		models.ThemeSpaceOverview:       "space_overview",
+		models.ThemePortfolioComparison: "portfolio_comparison",
 	}
 
 	for theme, expectedValue := range expectedThemes {
@@ -57,8 +76,8 @@ func TestSlideTheme_Constants(t *testing.T) {
 		}
 	}
 
-	if len(themes) != 10 {
-		t.Errorf("Expected 10 themes, got %d", len(themes))
+	if len(themes) != 13 {
+		t.Errorf("Expected 13 themes, got %d", len(themes))
 	}
 
 	// Test that no theme is empty
@@ -235,6 +254,15 @@ func TestSlideGenerationRequest_Validation(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "Spanish language",
+			request: models.SlideGenerationRequest{
+				ProjectID: models.ProjectID("123"),
+				Themes:    []models.SlideTheme{models.ThemeProjectOverview},
+				Language:  "es",
+			},
+			valid: true,
+		},
 		{
 			name: "Invalid language",
 			request: models.SlideGenerationRequest{
@@ -251,8 +279,7 @@ func TestSlideGenerationRequest_Validation(t *testing.T) {
 			// Validation logic based on the actual requirements
 			isValid := tc.request.ProjectID != "" && 
 				len(tc.request.Themes) > 0 && 
-				tc.request.Language != "" &&
-				(tc.request.Language == "ja" || tc.request.Language == "en")
+				services.IsSupportedLanguage(tc.request.Language)
 			
 			if isValid != tc.valid {
 				t.Errorf("Expected validity %v, got %v for request: %+v", tc.valid, isValid, tc.request)
@@ -283,6 +310,9 @@ func TestAllSlideThemes_Uniqueness(t *testing.T) {
 		models.ThemeNotifications,
 		models.ThemePredictiveAnalysis,
 		models.ThemeSummaryPlan,
+		models.ThemePeriodComparison,
+		models.ThemeSpaceOverview,
+		models.ThemePortfolioComparison,
 	}
 
 	seen := make(map[models.SlideTheme]bool)
@@ -293,3 +323,776 @@ func TestAllSlideThemes_Uniqueness(t *testing.T) {
 		seen[theme] = true
 	}
 }
+
+// TestSlideService_NarrationPromptUsesConfiguredTarget tests that the narration
+// prompt includes the per-language target length from configuration
+func TestSlideService_NarrationPromptUsesConfiguredTarget(t *testing.T) {
+	cfg := &config.Config{
+		AIProvider: "openai",
+		NarrationTargets: map[string]string{
+			"ja": "1-2",
+			"en": "3-4",
+		},
+	}
+	service := services.NewSlideService(cfg)
+
+	jaPrompt := service.BuildNarrationPrompt("# テスト", "ja")
+	if !strings.Contains(jaPrompt, "1-2分程度で読める長さ") {
+		t.Errorf("Expected ja prompt to use configured ja target, got: %s", jaPrompt)
+	}
+
+	enPrompt := service.BuildNarrationPrompt("# Test", "en")
+	if !strings.Contains(enPrompt, "3-4 minutes reading time") {
+		t.Errorf("Expected en prompt to use configured en target, got: %s", enPrompt)
+	}
+
+	defaultService := services.NewSlideService(&config.Config{AIProvider: "openai"})
+	if !strings.Contains(defaultService.BuildNarrationPrompt("# テスト", "ja"), "2-3分程度で読める長さ") {
+		t.Error("Expected ja prompt to fall back to the default 2-3 minute target")
+	}
+}
+
+// TestSlideService_GenerateSlideAudioUsesDeckSpeed tests that the deck-level speed,
+// with per-slide overrides, is sent to the speech server when generating audio
+func TestSlideService_GenerateSlideAudioUsesDeckSpeed(t *testing.T) {
+	var receivedSpeed float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Speed float64 `json:"speed"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode speech request: %v", err)
+		}
+		receivedSpeed = req.Speed
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer server.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:   "openai",
+		MCPSpeechURL: server.URL,
+	})
+
+	session := &handlers.SlideSession{
+		Speed:       1.25,
+		SlideSpeeds: map[int]float64{2: 0.9},
+	}
+
+	testCases := []struct {
+		slideIndex int
+		expected   float64
+	}{
+		{slideIndex: 0, expected: 1.25},
+		{slideIndex: 2, expected: 0.9},
+	}
+
+	for _, tc := range testCases {
+		narration := &models.SlideNarration{
+			SlideIndex: tc.slideIndex,
+			Text:       fmt.Sprintf("Narration for slide %d at deck speed", tc.slideIndex),
+			Language:   "en",
+			Speed:      session.SpeedFor(tc.slideIndex),
+		}
+		if _, err := service.GenerateSlideAudio(narration); err != nil {
+			t.Fatalf("GenerateSlideAudio failed: %v", err)
+		}
+		if receivedSpeed != tc.expected {
+			t.Errorf("Slide %d: expected speed %v to reach the speech server, got %v", tc.slideIndex, tc.expected, receivedSpeed)
+		}
+	}
+}
+
+// TestSlideService_OpenAIRequestUsesConfiguredBaseURL tests that OpenAI requests
+// are sent to the configured OpenAI-compatible API, ignoring a trailing slash
+func TestSlideService_OpenAIRequestUsesConfiguredBaseURL(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("Unexpected request path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "Narration text"}}]}`))
+	}))
+	defer server.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: server.URL + "/v1/",
+	})
+
+	slide := &models.SlideContent{Index: 0, Title: "Overview", Markdown: "# Overview"}
+	if _, err := service.GenerateSlideNarration(slide, "en"); err != nil {
+		t.Fatalf("GenerateSlideNarration failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected one request to the configured base URL, got %d", requests)
+	}
+}
+
+// TestBedrockService_UsesConfiguredEndpoint tests that Bedrock requests are sent
+// to the configured runtime endpoint instead of the regional one
+func TestBedrockService_UsesConfiguredEndpoint(t *testing.T) {
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"content": [{"type": "text", "text": "Generated slide"}]}`))
+	}))
+	defer server.Close()
+
+	service := services.NewBedrockService(&config.Config{
+		AWSRegion:          "ap-northeast-1",
+		AWSAccessKeyID:     "test-access-key",
+		AWSSecretAccessKey: "test-secret-key",
+		BedrockModelID:     "anthropic.claude-3-haiku-20240307-v1:0",
+		BedrockEndpoint:    server.URL + "/",
+	})
+
+	text, err := service.GenerateText("Generate a slide")
+	if err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+	if text != "Generated slide" {
+		t.Errorf("Unexpected generated text: %q", text)
+	}
+	if requestPath != "/model/anthropic.claude-3-haiku-20240307-v1:0/invoke" {
+		t.Errorf("Unexpected request path: %s", requestPath)
+	}
+}
+
+// TestSlideService_OpenAIRequestUsesConfiguredModel tests that the OpenAI request
+// body reflects the configured model and token budget
+func TestSlideService_OpenAIRequestUsesConfiguredModel(t *testing.T) {
+	var requestBody struct {
+		Model     string `json:"model"`
+		MaxTokens int    `json:"max_tokens"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("Unexpected request path: %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+			t.Errorf("Failed to decode OpenAI request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "Narration text"}}]}`))
+	}))
+	defer server.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:      "openai",
+		OpenAIAPIKey:    "test-key",
+		OpenAIModel:     "gpt-4o",
+		OpenAIMaxTokens: 1500,
+		OpenAIBaseURL:   server.URL,
+	})
+
+	slide := &models.SlideContent{Index: 0, Title: "Overview", Markdown: "# Overview"}
+	if _, err := service.GenerateSlideNarration(slide, "en"); err != nil {
+		t.Fatalf("GenerateSlideNarration failed: %v", err)
+	}
+
+	if requestBody.Model != "gpt-4o" {
+		t.Errorf("Expected model gpt-4o in request body, got %q", requestBody.Model)
+	}
+	if requestBody.MaxTokens != 1500 {
+		t.Errorf("Expected max_tokens 1500 in request body, got %d", requestBody.MaxTokens)
+	}
+}
+
+// TestSlideService_WarnsOnUnknownProvider tests that an unrecognized AI provider
+// logs a warning once before falling back to OpenAI
+func TestSlideService_WarnsOnUnknownProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "Narration text"}}]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(&buf, slog.LevelWarn)))
+	defer slog.SetDefault(previous)
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "claude",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: server.URL,
+	})
+
+	slide := &models.SlideContent{Index: 0, Title: "Overview", Markdown: "# Overview"}
+	for i := 0; i < 2; i++ {
+		if _, err := service.GenerateSlideNarration(slide, "en"); err != nil {
+			t.Fatalf("Expected fallback to OpenAI to succeed, got %v", err)
+		}
+	}
+
+	output := buf.String()
+	if count := strings.Count(output, "Unknown AI provider"); count != 1 {
+		t.Errorf("Expected one unknown provider warning, got %d: %s", count, output)
+	}
+	if !strings.Contains(output, "provider=claude") {
+		t.Errorf("Expected the warning to name the provider, got %s", output)
+	}
+}
+
+// TestStripMarkdown_RemovesSyntax tests that markdown heading and bullet syntax
+// is removed from the plaintext version of a slide
+func TestStripMarkdown_RemovesSyntax(t *testing.T) {
+	markdown := "# プロジェクト概要\n\n## Status\n- **Open** issues: 12\n* Closed issues: 30\n1. Review [milestones](https://example.com)\n\n```mermaid\ngraph TD\n  A-->B\n```"
+
+	plain := services.StripMarkdown(markdown)
+	expected := "プロジェクト概要\nStatus\nOpen issues: 12\nClosed issues: 30\nReview milestones"
+	if plain != expected {
+		t.Errorf("Unexpected plaintext:\nexpected: %q\ngot:      %q", expected, plain)
+	}
+
+	for _, syntax := range []string{"#", "- ", "* ", "**", "](", "```"} {
+		if strings.Contains(plain, syntax) {
+			t.Errorf("Expected %q to be removed from plaintext, got: %s", syntax, plain)
+		}
+	}
+}
+
+// TestContentHash_DetectsChanges tests that identical slide content yields the
+// same hash while edits to the title or markdown change it
+func TestContentHash_DetectsChanges(t *testing.T) {
+	title := "プロジェクト概要"
+	markdown := "# プロジェクト概要\n- Open issues: 12"
+	hash := services.ContentHash(title, markdown)
+
+	if again := services.ContentHash(title, markdown); again != hash {
+		t.Errorf("Expected identical content to yield identical hashes, got %s and %s", hash, again)
+	}
+	if edited := services.ContentHash(title, markdown+"\n- Closed issues: 30"); edited == hash {
+		t.Error("Expected a markdown edit to change the hash")
+	}
+	if renamed := services.ContentHash("Project Overview", markdown); renamed == hash {
+		t.Error("Expected a title edit to change the hash")
+	}
+	if services.ContentHash("ab", "c") == services.ContentHash("a", "bc") {
+		t.Error("Expected moving text between title and markdown to change the hash")
+	}
+}
+
+// TestSlideService_ThemePromptIncludesAudience tests that the configured audience
+// appears in the generated theme prompt
+func TestSlideService_ThemePromptIncludesAudience(t *testing.T) {
+	data := map[string]interface{}{"project": map[string]interface{}{"name": "Test"}}
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:     "openai",
+		PromptAudience: "non-technical executives",
+	})
+	for _, language := range []string{"ja", "en"} {
+		prompt := service.BuildPromptForTheme(data, models.ThemeProjectOverview, language)
+		if !strings.Contains(prompt, "non-technical executives") {
+			t.Errorf("Expected %s prompt to include the configured audience, got: %s", language, prompt)
+		}
+	}
+
+	defaultPrompt := services.NewSlideService(&config.Config{AIProvider: "openai"}).
+		BuildPromptForTheme(data, models.ThemeProjectOverview, "en")
+	if strings.Contains(defaultPrompt, "Audience") {
+		t.Error("Expected no audience instruction when none is configured")
+	}
+}
+
+// TestSlideService_CodebaseSlideDegradesOnGitPermissionError tests that a 403 on
+// Git data still produces a codebase slide with a limitation note
+func TestSlideService_CodebaseSlideDegradesOnGitPermissionError(t *testing.T) {
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string `json:"tool"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		if payload.Tool == "get_git_repositories" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "API error: You do not have permission", "code": -32603, "status": 403}`))
+			return
+		}
+		w.Write([]byte(`{"result": {"content": [{"type": "text", "text": "{\"name\": \"Test\"}"}]}}`))
+	}))
+	defer bridge.Close()
+
+	var prompt string
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if len(request.Messages) > 0 {
+			prompt = request.Messages[0].Content
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Codebase Activity\n- Git data unavailable"}}]}`))
+	}))
+	defer openAI.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+	})
+
+	slide, err := service.GenerateSlideContent("TEST", models.ThemeCodebaseActivity, "en", "token")
+	if err != nil {
+		t.Fatalf("Expected a degraded codebase slide, got error: %v", err)
+	}
+	if len(slide.Limitations) != 1 || !strings.Contains(slide.Limitations[0], "Git") {
+		t.Errorf("Expected a Git limitation note, got %v", slide.Limitations)
+	}
+	if !strings.Contains(prompt, "Data limitations") {
+		t.Errorf("Expected the prompt to mention the data limitation, got: %s", prompt)
+	}
+}
+
+// TestSlideService_OversizeDataModes tests that project data over the prompt
+// size limit is truncated by default and rejected when ON_OVERSIZE_DATA is "error"
+func TestSlideService_OversizeDataModes(t *testing.T) {
+	description := strings.Repeat("x", 500)
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"result": {"content": [{"type": "text", "text": "{\"name\": \"Test\", \"description\": \"%s\"}"}]}}`, description)
+	}))
+	defer bridge.Close()
+
+	var prompts []string
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if len(request.Messages) > 0 {
+			prompts = append(prompts, request.Messages[0].Content)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Project Overview\n- Test"}}]}`))
+	}))
+	defer openAI.Close()
+
+	newService := func(mode string) *services.SlideService {
+		return services.NewSlideService(&config.Config{
+			AIProvider:         "openai",
+			OpenAIAPIKey:       "test-key",
+			OpenAIBaseURL:      openAI.URL,
+			MCPBacklogURL:      bridge.URL,
+			PromptDataMaxBytes: 200,
+			OnOversizeData:     mode,
+		})
+	}
+
+	t.Run("truncate", func(t *testing.T) {
+		prompts = nil
+		if _, err := newService(config.OversizeDataTruncate).GenerateSlideContent("TEST", models.ThemeProjectOverview, "en", "token"); err != nil {
+			t.Fatalf("Expected truncated data to generate a slide, got error: %v", err)
+		}
+		if len(prompts) == 0 {
+			t.Fatal("Expected the AI provider to be called")
+		}
+		if strings.Contains(prompts[0], description) || !strings.Contains(prompts[0], "...}") {
+			t.Errorf("Expected the project data to be truncated in the prompt, got: %s", prompts[0])
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		prompts = nil
+		_, err := newService(config.OversizeDataError).GenerateSlideContent("TEST", models.ThemeProjectOverview, "en", "token")
+		if !errors.Is(err, services.ErrDataTooLarge) {
+			t.Fatalf("Expected ErrDataTooLarge, got %v", err)
+		}
+		if len(prompts) != 0 {
+			t.Errorf("Expected the AI provider not to be called for oversized data, got %d calls", len(prompts))
+		}
+	})
+}
+
+// TestIsPermissionError tests detection of Backlog permission failures
+func TestIsPermissionError(t *testing.T) {
+	forbidden := fmt.Errorf("failed: %w", &services.BacklogToolError{Tool: "get_git_repositories", StatusCode: 403})
+	if !services.IsPermissionError(forbidden) {
+		t.Error("Expected a wrapped 403 tool error to be a permission error")
+	}
+	if services.IsPermissionError(&services.BacklogToolError{Tool: "get_project", StatusCode: 404}) {
+		t.Error("Expected a 404 tool error not to be a permission error")
+	}
+	if services.IsPermissionError(fmt.Errorf("connection refused")) {
+		t.Error("Expected a plain error not to be a permission error")
+	}
+}
+
+// TestSlideService_CachesGeneratedSlides tests that a slide is reused for
+// unchanged project data, regenerated when the data changes or the cache is
+// bypassed, and regenerated once the cached slide expires
+func TestSlideService_CachesGeneratedSlides(t *testing.T) {
+	var projectName atomic.Value
+	projectName.Store("Alpha")
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"content": []map[string]interface{}{
+					{"type": "text", "text": fmt.Sprintf(`{"name": %q}`, projectName.Load())},
+				},
+			},
+		})
+	}))
+	defer bridge.Close()
+
+	var aiCalls int32
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&aiCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Overview\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	ttl := time.Minute
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+		SlideCacheTTL: ttl,
+	})
+	// A fake clock lets the test expire the cache without waiting out the TTL
+	var clock atomic.Value
+	clock.Store(time.Now())
+	service.SetCacheClock(func() time.Time { return clock.Load().(time.Time) })
+	generate := func(opts services.GenerationOptions) *models.SlideContent {
+		t.Helper()
+		slide, err := service.GenerateSlideContentWithOptions("TEST", models.ThemeProjectOverview, "en", "token", opts)
+		if err != nil {
+			t.Fatalf("GenerateSlideContentWithOptions failed: %v", err)
+		}
+		return slide
+	}
+	expectAICalls := func(want int32, context string) {
+		t.Helper()
+		if got := atomic.LoadInt32(&aiCalls); got != want {
+			t.Errorf("%s: expected %d AI calls, got %d", context, want, got)
+		}
+	}
+
+	first := generate(services.GenerationOptions{})
+	expectAICalls(1, "first generation")
+
+	cached := generate(services.GenerationOptions{})
+	expectAICalls(1, "unchanged project data")
+	if cached.Markdown != first.Markdown || cached.ContentHash != first.ContentHash {
+		t.Errorf("Expected the cached slide to match the first one, got %+v", cached)
+	}
+	if cached.TokensUsed != 0 {
+		t.Errorf("Expected no tokens used for a cached slide, got %d", cached.TokensUsed)
+	}
+
+	generate(services.GenerationOptions{BypassCache: true})
+	expectAICalls(2, "bypassed cache")
+
+	projectName.Store("Beta")
+	generate(services.GenerationOptions{})
+	expectAICalls(3, "changed project data")
+	generate(services.GenerationOptions{})
+	expectAICalls(3, "changed project data again")
+
+	clock.Store(clock.Load().(time.Time).Add(ttl + time.Second))
+	generate(services.GenerationOptions{})
+	expectAICalls(4, "expired cache entry")
+}
+
+// TestSlideService_SpanishPrompts tests that Spanish slides and narration use
+// the English templates with an instruction to write in Spanish
+func TestSlideService_SpanishPrompts(t *testing.T) {
+	service := services.NewSlideService(&config.Config{})
+	projectData := map[string]interface{}{"project": map[string]interface{}{"name": "Test"}}
+
+	prompt := service.BuildPromptForTheme(projectData, models.ThemeProjectOverview, "es")
+	if !strings.Contains(prompt, "in Spanish (Español)") {
+		t.Errorf("Expected the slide prompt to ask for Spanish, got: %s", prompt)
+	}
+	if !strings.Contains(prompt, "Generate a slide for project overview") {
+		t.Errorf("Expected the English theme template, got: %s", prompt)
+	}
+
+	narration := service.BuildNarrationPrompt("# Resumen", "es")
+	if !strings.Contains(narration, "narration text in Spanish (Español)") {
+		t.Errorf("Expected the narration prompt to ask for Spanish, got: %s", narration)
+	}
+
+	if english := service.BuildPromptForTheme(projectData, models.ThemeProjectOverview, "en"); strings.Contains(english, "Write all of the output") {
+		t.Errorf("Expected no language instruction for English, got: %s", english)
+	}
+}
+
+// TestSlideService_TransitionCues tests that transition cues are appended to
+// narration once and that the audio reports when to advance
+func TestSlideService_TransitionCues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"audioUrl": "/cache/test.wav"}`))
+	}))
+	defer server.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:   "openai",
+		MCPSpeechURL: server.URL,
+	})
+
+	narration := &models.SlideNarration{
+		SlideIndex: 0,
+		Text:       "This sprint closed twelve issues. ",
+		Language:   "en",
+	}
+	services.AppendTransitionCue(narration)
+	services.AppendTransitionCue(narration)
+
+	expected := "This sprint closed twelve issues. " + services.TransitionCueMarker
+	if narration.Text != expected {
+		t.Errorf("Expected narration %q, got %q", expected, narration.Text)
+	}
+	if narration.TransitionPause != services.TransitionPauseSeconds {
+		t.Errorf("Expected transition pause %d, got %d", services.TransitionPauseSeconds, narration.TransitionPause)
+	}
+
+	audio, err := service.GenerateSlideAudio(narration)
+	if err != nil {
+		t.Fatalf("GenerateSlideAudio failed: %v", err)
+	}
+	if audio.AdvanceAfter != audio.Duration+services.TransitionPauseSeconds {
+		t.Errorf("Expected advance after %d seconds, got %d", audio.Duration+services.TransitionPauseSeconds, audio.AdvanceAfter)
+	}
+
+	plain := &models.SlideNarration{SlideIndex: 1, Text: "No cue here.", Language: "en"}
+	audio, err = service.GenerateSlideAudio(plain)
+	if err != nil {
+		t.Fatalf("GenerateSlideAudio failed: %v", err)
+	}
+	if audio.AdvanceAfter != 0 {
+		t.Errorf("Expected no advance time without a transition cue, got %d", audio.AdvanceAfter)
+	}
+}
+
+// TestSlideService_SpaceOverviewSummarizesSpace tests that the space overview
+// slide prompt carries user and project counts and that administrator-only
+// disk usage degrades to a limitation note
+func TestSlideService_SpaceOverviewSummarizesSpace(t *testing.T) {
+	toolResults := map[string]string{
+		"get_space":        `{"spaceKey": "acme", "name": "Acme"}`,
+		"get_users":        `[{"id": 1, "roleType": 1}, {"id": 2, "roleType": 2}, {"id": 3, "roleType": 2}]`,
+		"get_project_list": `[{"id": 10}, {"id": 11}]`,
+		"get_licence":      `{"maxUserCount": 30, "limitDate": "2027-03-31T00:00:00Z"}`,
+	}
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string `json:"tool"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		result, ok := toolResults[payload.Tool]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "API error: You do not have permission", "code": -32603, "status": 403}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{"content": []map[string]interface{}{{"type": "text", "text": result}}},
+		})
+	}))
+	defer bridge.Close()
+
+	var prompt string
+	completion := "# Space Overview\n- 3 users"
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if len(request.Messages) > 0 {
+			prompt = request.Messages[0].Content
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"content": completion}}},
+		})
+	}))
+	defer openAI.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+	})
+
+	slide, err := service.GenerateSlideContent("TEST", models.ThemeSpaceOverview, "en", "token")
+	if err != nil {
+		t.Fatalf("Expected a space overview slide, got error: %v", err)
+	}
+	if len(slide.Limitations) != 1 || !strings.Contains(slide.Limitations[0], "Disk usage") {
+		t.Errorf("Expected a disk usage limitation note, got %v", slide.Limitations)
+	}
+	for _, want := range []string{"whole Backlog space", `"userCount":3`, `"normal":2`, `"projectCount":2`, `"maxUserCount":30`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected the prompt to contain %s, got: %s", want, prompt)
+		}
+	}
+
+	// Without a heading in the response, the slide takes the theme's default title
+	completion = "- 3 users"
+	for language, want := range map[string]string{"en": "Space Overview", "ja": "スペース概要"} {
+		slide, err := service.GenerateSlideContentWithOptions("TEST", models.ThemeSpaceOverview, language, "token", services.GenerationOptions{BypassCache: true})
+		if err != nil {
+			t.Fatalf("Expected a %s space overview slide, got error: %v", language, err)
+		}
+		if slide.Title != want {
+			t.Errorf("Expected the %s default title %q, got %q", language, want, slide.Title)
+		}
+	}
+}
+
+// TestSlideService_PortfolioComparisonAssemblesProjects tests that a portfolio
+// slide prompt carries each project's issue statistics in the requested order,
+// that an unavailable project becomes a limitation, and that a comparison chart
+// is appended
+func TestSlideService_PortfolioComparisonAssemblesProjects(t *testing.T) {
+	projects := map[string]string{
+		"ALPHA": `{"id": 1, "projectKey": "ALPHA", "name": "Alpha"}`,
+		"BETA":  `{"id": 2, "projectKey": "BETA", "name": "Beta"}`,
+	}
+	issues := map[string]string{
+		"ALPHA": `[{"id": 1, "status": {"id": 4, "name": "Closed"}}, {"id": 2, "status": {"id": 1, "name": "Open"}}]`,
+		"BETA":  `[{"id": 3, "status": {"id": 1, "name": "Open"}}]`,
+	}
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool      string                 `json:"tool"`
+			Arguments map[string]interface{} `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		var projectID string
+		if payload.Tool == "get_project" {
+			projectID, _ = payload.Arguments["projectIdOrKey"].(string)
+		} else if ids, ok := payload.Arguments["projectId"].([]interface{}); ok && len(ids) > 0 {
+			projectID, _ = ids[0].(string)
+		}
+		results := issues
+		if payload.Tool == "get_project" {
+			results = projects
+		}
+		w.Header().Set("Content-Type", "application/json")
+		result, ok := results[projectID]
+		if !ok {
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": "No project"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{"content": []map[string]interface{}{{"type": "text", "text": result}}},
+		})
+	}))
+	defer bridge.Close()
+
+	var prompt string
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if len(request.Messages) > 0 {
+			prompt = request.Messages[0].Content
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Portfolio\n- Alpha leads"}}]}`))
+	}))
+	defer openAI.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "test-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+	})
+
+	slide, err := service.GeneratePortfolioSlideContent([]string{"ALPHA", "GONE", "BETA"}, "en", "token")
+	if err != nil {
+		t.Fatalf("Expected a portfolio slide, got error: %v", err)
+	}
+	if slide.Theme != models.ThemePortfolioComparison {
+		t.Errorf("Expected the portfolio theme, got %q", slide.Theme)
+	}
+	if len(slide.Limitations) != 1 || !strings.Contains(slide.Limitations[0], "GONE") {
+		t.Errorf("Expected a limitation for the unavailable project, got %v", slide.Limitations)
+	}
+
+	alpha := strings.Index(prompt, `"projectKey":"ALPHA"`)
+	beta := strings.Index(prompt, `"projectKey":"BETA"`)
+	if alpha < 0 || beta < 0 || alpha > beta {
+		t.Errorf("Expected both projects in request order in the prompt, got: %s", prompt)
+	}
+	for _, want := range []string{`"totalIssues":2`, `"completedIssues":1`, `"totalIssues":1`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected the prompt to contain %s, got: %s", want, prompt)
+		}
+	}
+	if len(slide.DiagramAltTexts) != 1 || !strings.Contains(slide.DiagramAltTexts[0].AltText, "Completed: ALPHA 1, BETA 0.") {
+		t.Errorf("Expected a chart comparing the fetched projects, got: %s", slide.Markdown)
+	}
+
+	if _, err := service.GeneratePortfolioSlideContent([]string{"GONE"}, "en", "token"); err == nil {
+		t.Error("Expected an error when no project can be fetched")
+	}
+}
+
+// TestSlideService_TenantCredentialsBypassSlideCache tests that a slide cached
+// for the server's credentials is not served to a request made with a tenant's
+// credentials, and that the tenant's slide is not cached for others
+func TestSlideService_TenantCredentialsBypassSlideCache(t *testing.T) {
+	bridge, _ := newMockBridge(t, 0)
+	var keys []string
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"content": "# Slide\n- point"}}]}`))
+	}))
+	defer openAI.Close()
+
+	service := services.NewSlideService(&config.Config{
+		AIProvider:    "openai",
+		OpenAIAPIKey:  "server-key",
+		OpenAIBaseURL: openAI.URL,
+		MCPBacklogURL: bridge.URL,
+		SlideCacheTTL: time.Hour,
+	})
+	tenant, err := service.WithAICredentials(&models.AICredentials{Provider: "openai", APIKey: "tenant-key"})
+	if err != nil {
+		t.Fatalf("WithAICredentials failed: %v", err)
+	}
+
+	generate := func(service *services.SlideService) {
+		t.Helper()
+		if _, err := service.GenerateSlideContent("TEST", models.ThemeProjectOverview, "en", "token"); err != nil {
+			t.Fatalf("GenerateSlideContent failed: %v", err)
+		}
+	}
+	generate(service)
+	generate(tenant)
+	generate(tenant)
+	generate(service)
+
+	want := []string{"Bearer server-key", "Bearer tenant-key", "Bearer tenant-key"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected AI calls %v, got %v", want, keys)
+	}
+}
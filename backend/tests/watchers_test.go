@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"intelligent-presenter-backend/internal/services"
+	"intelligent-presenter-backend/pkg/config"
+)
+
+// TestAggregateWatcherCounts tests watcher counting per issue filtered by project
+func TestAggregateWatcherCounts(t *testing.T) {
+	var watchings []interface{}
+	err := json.Unmarshal([]byte(`[
+		{"id": 1, "type": "issue", "issue": {"id": 10, "projectId": 100, "issueKey": "TEST-1"}},
+		{"id": 2, "type": "issue", "issue": {"id": 10, "projectId": 100, "issueKey": "TEST-1"}},
+		{"id": 3, "type": "issue", "issue": {"id": 11, "projectId": 100, "issueKey": "TEST-2"}},
+		{"id": 4, "type": "issue", "issue": {"id": 20, "projectId": 200, "issueKey": "OTHER-1"}},
+		{"id": 5, "type": "issue"}
+	]`), &watchings)
+	if err != nil {
+		t.Fatalf("Failed to parse test watchings: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		projectID string
+	}{
+		{name: "Project key", projectID: "TEST"},
+		{name: "Numeric project ID", projectID: "100"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := services.AggregateWatcherCounts(watchings, tc.projectID)
+			if stats.WatchedIssues != 2 {
+				t.Errorf("Expected 2 watched issues, got %d", stats.WatchedIssues)
+			}
+			if stats.Counts["TEST-1"] != 2 || stats.Counts["TEST-2"] != 1 {
+				t.Errorf("Unexpected watcher counts: %v", stats.Counts)
+			}
+			if _, exists := stats.Counts["OTHER-1"]; exists {
+				t.Error("Expected issues from other projects to be excluded")
+			}
+		})
+	}
+
+	empty := services.AggregateWatcherCounts(nil, "TEST")
+	if empty.WatchedIssues != 0 || empty.Counts == nil {
+		t.Errorf("Expected empty stats with initialized counts, got %+v", empty)
+	}
+}
+
+// newWatchingsBridge creates an MCP bridge mock serving get_users and paged
+// get_watching_list_items in the shape Backlog returns them. Watch lists are
+// keyed by user ID, with 0 for the authenticated user; users without a list
+// fail. Each watching tool call is recorded as "userID@offset".
+func newWatchingsBridge(t *testing.T, users string, lists map[int][]string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	calls := &[]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Tool string                 `json:"tool"`
+			Args map[string]interface{} `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+
+		text := users
+		if payload.Tool == "get_watching_list_items" {
+			userID, _ := payload.Args["userId"].(float64)
+			offset, _ := payload.Args["offset"].(float64)
+			count, _ := payload.Args["count"].(float64)
+			mu.Lock()
+			*calls = append(*calls, fmt.Sprintf("%.0f@%.0f", userID, offset))
+			mu.Unlock()
+
+			issueKeys, ok := lists[int(userID)]
+			if !ok {
+				json.NewEncoder(w).Encode(map[string]interface{}{"error": "No permission to access the watch list"})
+				return
+			}
+			page := make([]map[string]interface{}, 0)
+			for i := int(offset); i < int(offset+count) && i < len(issueKeys); i++ {
+				projectID := 200
+				if strings.HasPrefix(issueKeys[i], "DEMO-") {
+					projectID = 100
+				}
+				page = append(page, map[string]interface{}{
+					"id":    i + 1,
+					"type":  "issue",
+					"issue": map[string]interface{}{"id": 1000 + i, "projectId": projectID, "issueKey": issueKeys[i]},
+				})
+			}
+			encoded, _ := json.Marshal(page)
+			text = string(encoded)
+		} else if users == "" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": "Failed to list users"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{"content": []map[string]interface{}{{"type": "text", "text": text}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, calls
+}
+
+// TestMCPService_GetIssueWatchersCountsEveryUser tests that the watch list of
+// each space user is paged through and the project's watchings counted per
+// issue, noting users whose watch lists cannot be read
+func TestMCPService_GetIssueWatchersCountsEveryUser(t *testing.T) {
+	// Alice's second page holds her only watching of the project
+	alice := make([]string, 0, 150)
+	for i := 1; i <= 149; i++ {
+		alice = append(alice, fmt.Sprintf("OTHER-%d", i))
+	}
+	alice = append(alice, "DEMO-1")
+	bridge, calls := newWatchingsBridge(t,
+		`[{"id": 1, "name": "Alice"}, {"id": 2, "name": "Bob"}, {"id": 3, "name": "Carol"}]`,
+		map[int][]string{1: alice, 2: {"DEMO-1", "DEMO-2", "OTHER-1"}})
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: bridge.URL})
+
+	stats, err := service.GetIssueWatchers("DEMO", "token")
+	if err != nil {
+		t.Fatalf("GetIssueWatchers failed: %v", err)
+	}
+	if stats.Scope != "space_users" || stats.WatchedIssues != 2 {
+		t.Errorf("Expected two watched issues across space users, got %+v", stats)
+	}
+	if stats.Counts["DEMO-1"] != 2 || stats.Counts["DEMO-2"] != 1 {
+		t.Errorf("Expected DEMO-1 watched by Alice and Bob and DEMO-2 by Bob, got %v", stats.Counts)
+	}
+	if !strings.Contains(stats.Note, "1 users could not be read") {
+		t.Errorf("Expected a note about Carol's unreadable watch list, got %q", stats.Note)
+	}
+
+	sort.Strings(*calls)
+	if want := []string{"1@0", "1@100", "2@0", "3@0"}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("Expected each user's watch list to be paged through, got %v", *calls)
+	}
+}
+
+// TestMCPService_GetIssueWatchersFallsBackToCurrentUser tests that only the
+// authenticated user's watch list is counted when the users cannot be listed
+func TestMCPService_GetIssueWatchersFallsBackToCurrentUser(t *testing.T) {
+	bridge, calls := newWatchingsBridge(t, "", map[int][]string{0: {"DEMO-3", "OTHER-1"}})
+	service := services.NewMCPService(&config.Config{MCPBacklogURL: bridge.URL})
+
+	stats, err := service.GetIssueWatchers("DEMO", "token")
+	if err != nil {
+		t.Fatalf("GetIssueWatchers failed: %v", err)
+	}
+	if stats.Scope != "current_user" || stats.Note == "" || stats.Counts["DEMO-3"] != 1 || stats.WatchedIssues != 1 {
+		t.Errorf("Expected the current user's watch list to be counted and labelled, got %+v", stats)
+	}
+	if want := []string{"0@0"}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("Expected only the authenticated user's watch list to be read, got %v", *calls)
+	}
+}
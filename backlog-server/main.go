@@ -23,12 +23,18 @@ package main
 
 import (
 	"bufio"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-resty/resty/v2"
@@ -162,6 +168,10 @@ func NewBacklogClient(domain, accessToken, apiKey string) (*BacklogClient, error
 
 	client := resty.New()
 	baseURL := fmt.Sprintf("https://%s/api/v2", domain)
+	// Allow pointing the client at a proxy or a mock Backlog API
+	if override := os.Getenv("BACKLOG_API_BASE_URL"); override != "" {
+		baseURL = strings.TrimRight(override, "/")
+	}
 
 	bc := &BacklogClient{
 		client:      client,
@@ -191,6 +201,27 @@ func (bc *BacklogClient) setupAuth() {
 	}
 }
 
+// BacklogAPIError is returned when the Backlog API responds with an error status.
+// The status code is kept so that callers can tell permission failures apart
+// from other errors.
+type BacklogAPIError struct {
+	StatusCode int    // HTTP status code returned by Backlog
+	Body       string // Raw error response body
+}
+
+func (e *BacklogAPIError) Error() string {
+	return fmt.Sprintf("API error: %s", e.Body)
+}
+
+// upstreamStatus returns the Backlog HTTP status code carried by a tool error, or 0
+func upstreamStatus(err error) int {
+	var apiErr *BacklogAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
 func (bc *BacklogClient) makeRequest(method, endpoint string, params map[string]interface{}, body interface{}) (interface{}, error) {
 	var result interface{}
 	req := bc.client.R().SetResult(&result)
@@ -212,12 +243,12 @@ func (bc *BacklogClient) makeRequest(method, endpoint string, params map[string]
 		}
 	}
 
-	// Add form data for POST/PUT requests with body
-	if (method == "POST" || method == "PUT") && body != nil {
+	// Add form data for requests with body
+	if (method == "POST" || method == "PUT" || method == "PATCH" || method == "DELETE") && body != nil {
 		if bodyMap, ok := body.(map[string]interface{}); ok {
 			formData := make(map[string]string)
 			for key, value := range bodyMap {
-				if key == "categoryId" || key == "versionId" || key == "milestoneId" || key == "notifiedUserId" || key == "attachmentId" {
+				if key == "categoryId" || key == "versionId" || key == "milestoneId" || key == "notifiedUserId" || key == "attachmentId" || key == "activityTypeIds" {
 					if ids, ok := value.([]interface{}); ok {
 						for i, id := range ids {
 							formData[key+"["+fmt.Sprintf("%d", i)+"]"] = fmt.Sprintf("%v", id)
@@ -243,6 +274,8 @@ func (bc *BacklogClient) makeRequest(method, endpoint string, params map[string]
 		resp, err = req.Post(bc.baseURL + endpoint)
 	case "PUT":
 		resp, err = req.Put(bc.baseURL + endpoint)
+	case "PATCH":
+		resp, err = req.Patch(bc.baseURL + endpoint)
 	case "DELETE":
 		resp, err = req.Delete(bc.baseURL + endpoint)
 	default:
@@ -258,7 +291,13 @@ func (bc *BacklogClient) makeRequest(method, endpoint string, params map[string]
 
 	if resp.IsError() {
 		log.Printf("API error for %s %s: status=%d, response=%s", method, endpoint, resp.StatusCode(), resp.String())
-		return nil, fmt.Errorf("API error: %s", resp.String())
+		return nil, &BacklogAPIError{StatusCode: resp.StatusCode(), Body: resp.String()}
+	}
+
+	// Binary responses such as shared file downloads are not JSON; return
+	// their content base64-encoded alongside the content type
+	if contentType := resp.Header().Get("Content-Type"); contentType != "" && !isJSONContentType(contentType) {
+		return binaryResult(resp.Body(), contentType, resp.Header().Get("Content-Disposition")), nil
 	}
 
 	return result, nil
@@ -292,12 +331,127 @@ func NewMCPServer(backlogClient *BacklogClient) *MCPServer {
 	return s
 }
 
+// issueTypeColors lists the display colors Backlog accepts for issue types
+var issueTypeColors = []string{"#e30000", "#990000", "#934981", "#814fbc", "#2779ca", "#007e9a", "#7ea800", "#ff9200", "#ff3265", "#666665"}
+
+// isIssueTypeColor reports whether color is one of Backlog's issue type colors
+func isIssueTypeColor(color string) bool {
+	for _, allowed := range issueTypeColors {
+		if strings.EqualFold(color, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// starTargets lists the arguments that identify what add_star stars
+var starTargets = []string{"issueId", "commentId", "wikiId", "pullRequestId"}
+
+// isJSONContentType reports whether a response Content-Type carries JSON
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// binaryResult describes a binary response body as a JSON-friendly value,
+// taking the file name from the Content-Disposition header when present
+func binaryResult(body []byte, contentType, contentDisposition string) map[string]interface{} {
+	result := map[string]interface{}{
+		"contentType": contentType,
+		"size":        len(body),
+		"content":     base64.StdEncoding.EncodeToString(body),
+	}
+	if _, params, err := mime.ParseMediaType(contentDisposition); err == nil && params["filename"] != "" {
+		result["filename"] = params["filename"]
+	}
+	return result
+}
+
+// watchingsPath returns the path of the user whose watch list a watching tool
+// reads: the given userId, or the authenticated user when it is omitted
+func watchingsPath(args map[string]interface{}) string {
+	if userId, ok := args["userId"].(float64); ok && userId > 0 {
+		return fmt.Sprintf("/users/%.0f", userId)
+	}
+	return "/users/myself"
+}
+
+// versionBody builds the form body of a version create or update request from
+// the tool arguments, validating the dates Backlog expects as yyyy-MM-dd
+func versionBody(args map[string]interface{}) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	for _, key := range []string{"name", "description"} {
+		if value, ok := args[key].(string); ok {
+			body[key] = value
+		}
+	}
+
+	dates := make(map[string]time.Time)
+	for _, key := range []string{"startDate", "releaseDueDate"} {
+		value, ok := args[key].(string)
+		if !ok || value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date in yyyy-MM-dd format", key)
+		}
+		dates[key] = date
+		body[key] = value
+	}
+	if start, ok := dates["startDate"]; ok {
+		if due, ok := dates["releaseDueDate"]; ok && due.Before(start) {
+			return nil, fmt.Errorf("releaseDueDate must not be before startDate")
+		}
+	}
+
+	if archived, ok := args["archived"]; ok {
+		flag, ok := archived.(bool)
+		if !ok {
+			return nil, fmt.Errorf("archived must be a boolean")
+		}
+		body["archived"] = flag
+	}
+	return body, nil
+}
+
 func (s *MCPServer) initializeTools() {
 	s.tools = []Tool{
 		// Space tools
 		{Name: "get_space", Description: "Get information about the Backlog space", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
+		{Name: "get_disk_usage", Description: "Get the storage used by the space, in total and per project", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
+		{Name: "get_licence", Description: "Get the licence of the space, including user and storage limits and the contract period", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
 		{Name: "get_users", Description: "Get list of users in the space", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
 		{Name: "get_myself", Description: "Get information about the current user", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
+		{Name: "get_rate_limit", Description: "Get the remaining Backlog API quota and reset times (Unix seconds) for read, update, search, and icon requests, to back off before hitting 429 responses", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
+
+		// Team tools (Premium and above)
+		{
+			Name:        "get_teams",
+			Description: "Get list of teams in the space",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"order":  {Type: "string", Enum: []string{"asc", "desc"}, Description: "Sort order"},
+					"offset": {Type: "number", Description: "Offset for pagination"},
+					"count":  {Type: "number", Description: "Number of teams to return (1-100)"},
+				},
+			},
+		},
+		{
+			Name:        "get_team",
+			Description: "Get a team and its members",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"teamId": {Type: "number", Description: "Team ID"},
+				},
+				Required: []string{"teamId"},
+			},
+		},
 
 		// Project tools
 		{
@@ -507,6 +661,18 @@ func (s *MCPServer) initializeTools() {
 				},
 			},
 		},
+		{
+			Name:        "get_issue_count_by_group",
+			Description: "Count a project's issues per status, priority, or issue type, returning each group's name, color, and count with chart-ready labels and counts",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":  {Type: "number", Description: "Project ID"},
+					"projectKey": {Type: "string", Description: "Project key"},
+					"groupBy":    {Type: "string", Enum: []string{"status", "priority", "issueType"}, Description: "Field to group issues by (default status)"},
+				},
+			},
+		},
 		{
 			Name:        "get_custom_fields",
 			Description: "Get custom fields for a project",
@@ -540,6 +706,42 @@ func (s *MCPServer) initializeTools() {
 				},
 			},
 		},
+		{
+			Name:        "add_watching",
+			Description: "Watch an issue",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"issueId":      {Type: "number", Description: "Issue ID"},
+					"issueIdOrKey": {Type: "string", Description: "Issue ID or key, used when issueId is not given"},
+					"note":         {Type: "string", Description: "Note about the watch"},
+				},
+			},
+		},
+		{
+			Name:        "delete_watching",
+			Description: "Stop watching an issue",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"watchingId": {Type: "number", Description: "Watching ID"},
+				},
+				Required: []string{"watchingId"},
+			},
+		},
+		{
+			Name:        "add_star",
+			Description: "Star an issue, comment, wiki page, or pull request; give exactly one target ID",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"issueId":       {Type: "number", Description: "Issue ID"},
+					"commentId":     {Type: "number", Description: "Comment ID"},
+					"wikiId":        {Type: "number", Description: "Wiki page ID"},
+					"pullRequestId": {Type: "number", Description: "Pull request ID"},
+				},
+			},
+		},
 
 		// Issue metadata tools
 		{
@@ -551,6 +753,46 @@ func (s *MCPServer) initializeTools() {
 				Required:   []string{"projectIdOrKey"},
 			},
 		},
+		{
+			Name:        "add_issue_type",
+			Description: "Add an issue type to a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectIdOrKey": {Type: "string", Description: "Project ID or key"},
+					"name":           {Type: "string", Description: "Issue type name"},
+					"color":          {Type: "string", Description: "Display color", Enum: issueTypeColors},
+				},
+				Required: []string{"projectIdOrKey", "name", "color"},
+			},
+		},
+		{
+			Name:        "update_issue_type",
+			Description: "Update an issue type in a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectIdOrKey": {Type: "string", Description: "Project ID or key"},
+					"id":             {Type: "number", Description: "Issue type ID"},
+					"name":           {Type: "string", Description: "New issue type name"},
+					"color":          {Type: "string", Description: "New display color", Enum: issueTypeColors},
+				},
+				Required: []string{"projectIdOrKey", "id"},
+			},
+		},
+		{
+			Name:        "delete_issue_type",
+			Description: "Delete an issue type, moving its issues to a substitute type",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectIdOrKey":        {Type: "string", Description: "Project ID or key"},
+					"id":                    {Type: "number", Description: "Issue type ID to delete"},
+					"substituteIssueTypeId": {Type: "number", Description: "Issue type ID that receives the deleted type's issues"},
+				},
+				Required: []string{"projectIdOrKey", "id", "substituteIssueTypeId"},
+			},
+		},
 		{Name: "get_priorities", Description: "Get issue priorities", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
 		{Name: "get_resolutions", Description: "Get issue resolutions", InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}}},
 		{
@@ -562,6 +804,146 @@ func (s *MCPServer) initializeTools() {
 				Required:   []string{"projectIdOrKey"},
 			},
 		},
+		{
+			Name:        "add_category",
+			Description: "Add a category to a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectIdOrKey": {Type: "string", Description: "Project ID or key"},
+					"name":           {Type: "string", Description: "Category name"},
+				},
+				Required: []string{"projectIdOrKey", "name"},
+			},
+		},
+		{
+			Name:        "update_category",
+			Description: "Rename a category in a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectIdOrKey": {Type: "string", Description: "Project ID or key"},
+					"id":             {Type: "number", Description: "Category ID"},
+					"name":           {Type: "string", Description: "New category name"},
+				},
+				Required: []string{"projectIdOrKey", "id", "name"},
+			},
+		},
+		{
+			Name:        "delete_category",
+			Description: "Delete a category from a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectIdOrKey": {Type: "string", Description: "Project ID or key"},
+					"id":             {Type: "number", Description: "Category ID"},
+				},
+				Required: []string{"projectIdOrKey", "id"},
+			},
+		},
+
+		// Version (milestone) tools
+		{
+			Name:        "get_versions",
+			Description: "Get versions (milestones) for a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":  {Type: "number", Description: "Project ID"},
+					"projectKey": {Type: "string", Description: "Project key"},
+				},
+			},
+		},
+		{
+			Name:        "add_version",
+			Description: "Add a version (milestone) to a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":      {Type: "number", Description: "Project ID"},
+					"projectKey":     {Type: "string", Description: "Project key"},
+					"name":           {Type: "string", Description: "Version name"},
+					"description":    {Type: "string", Description: "Version description"},
+					"startDate":      {Type: "string", Description: "Start date (yyyy-MM-dd)"},
+					"releaseDueDate": {Type: "string", Description: "Release due date (yyyy-MM-dd)"},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "update_version",
+			Description: "Update a version (milestone) in a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":      {Type: "number", Description: "Project ID"},
+					"projectKey":     {Type: "string", Description: "Project key"},
+					"id":             {Type: "number", Description: "Version ID"},
+					"name":           {Type: "string", Description: "Version name"},
+					"description":    {Type: "string", Description: "Version description"},
+					"startDate":      {Type: "string", Description: "Start date (yyyy-MM-dd)"},
+					"releaseDueDate": {Type: "string", Description: "Release due date (yyyy-MM-dd)"},
+					"archived":       {Type: "boolean", Description: "Archive the version"},
+				},
+				Required: []string{"id", "name"},
+			},
+		},
+		{
+			Name:        "delete_version",
+			Description: "Delete a version (milestone) from a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":  {Type: "number", Description: "Project ID"},
+					"projectKey": {Type: "string", Description: "Project key"},
+					"id":         {Type: "number", Description: "Version ID"},
+				},
+				Required: []string{"id"},
+			},
+		},
+
+		// Webhook tools
+		{
+			Name:        "get_webhooks",
+			Description: "Get webhooks registered for a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":  {Type: "number", Description: "Project ID"},
+					"projectKey": {Type: "string", Description: "Project key"},
+				},
+			},
+		},
+		{
+			Name:        "add_webhook",
+			Description: "Add a webhook to a project that posts the selected activity types, or every activity when allEvent is true, to hookUrl",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":       {Type: "number", Description: "Project ID"},
+					"projectKey":      {Type: "string", Description: "Project key"},
+					"name":            {Type: "string", Description: "Webhook name"},
+					"hookUrl":         {Type: "string", Description: "HTTP or HTTPS URL to notify"},
+					"description":     {Type: "string", Description: "Webhook description"},
+					"allEvent":        {Type: "boolean", Description: "Notify on every activity type"},
+					"activityTypeIds": {Type: "array", Items: &Property{Type: "number"}, Description: "Activity type IDs to notify on, required unless allEvent is true"},
+				},
+				Required: []string{"name", "hookUrl"},
+			},
+		},
+		{
+			Name:        "delete_webhook",
+			Description: "Delete a webhook from a project",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":  {Type: "number", Description: "Project ID"},
+					"projectKey": {Type: "string", Description: "Project key"},
+					"webhookId":  {Type: "number", Description: "Webhook ID"},
+				},
+				Required: []string{"webhookId"},
+			},
+		},
 
 		// Wiki tools
 		{
@@ -672,6 +1054,18 @@ func (s *MCPServer) initializeTools() {
 				},
 			},
 		},
+		{
+			Name:        "get_project_pull_request_summary",
+			Description: "Get open, merged, and closed pull request counts for each Git repository of a project and in total",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":       {Type: "number", Description: "Project ID"},
+					"projectKey":      {Type: "string", Description: "Project key"},
+					"maxRepositories": {Type: "number", Description: fmt.Sprintf("Most repositories to count, in Backlog's order (default %d, max %d)", defaultSummaryRepositories, maxSummaryRepositories)},
+				},
+			},
+		},
 		{
 			Name:        "get_pull_request",
 			Description: "Get pull request details",
@@ -818,6 +1212,34 @@ func (s *MCPServer) initializeTools() {
 				Required: []string{"documentId"},
 			},
 		},
+		{
+			Name:        "get_shared_files",
+			Description: "List shared files in a project directory",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":  {Type: "number", Description: "Project ID"},
+					"projectKey": {Type: "string", Description: "Project key"},
+					"path":       {Type: "string", Description: "Directory path (empty for the root)"},
+					"order":      {Type: "string", Description: "Sort order", Enum: []string{"asc", "desc"}},
+					"offset":     {Type: "number", Description: "Offset"},
+					"count":      {Type: "number", Description: "Number of files (1-1000)"},
+				},
+			},
+		},
+		{
+			Name:        "download_shared_file",
+			Description: "Download a shared file as base64-encoded content with its content type",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"projectId":    {Type: "number", Description: "Project ID"},
+					"projectKey":   {Type: "string", Description: "Project key"},
+					"sharedFileId": {Type: "number", Description: "Shared file ID"},
+				},
+				Required: []string{"sharedFileId"},
+			},
+		},
 
 		// Notifications tools
 		{
@@ -845,7 +1267,7 @@ func (s *MCPServer) initializeTools() {
 		},
 		{
 			Name:        "reset_unread_notification_count",
-			Description: "Reset unread notification count",
+			Description: "Reset the unread notification counter to zero. Notifications themselves are not marked as read; use mark_notification_as_read or mark_notifications_read_until for that",
 			InputSchema: InputSchema{Type: "object", Properties: map[string]Property{}},
 		},
 		{
@@ -859,11 +1281,311 @@ func (s *MCPServer) initializeTools() {
 				Required: []string{"id"},
 			},
 		},
+		{
+			Name:        "mark_notifications_read_until",
+			Description: "Mark every unread notification with an ID up to maxId as read, newest first",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"maxId": {Type: "number", Description: "Highest notification ID to mark as read"},
+					"limit": {Type: "number", Description: "Maximum notifications to mark in one call (default 100, at most 1000)"},
+				},
+				Required: []string{"maxId"},
+			},
+		},
 
 		
 	}
 }
 
+// Bounds of mark_notifications_read_until. Backlog returns at most 100
+// notifications per page; the limit and page cap keep one call from issuing
+// an unbounded number of requests.
+const (
+	notificationPageSize = 100
+	maxNotificationPages = 20
+	defaultMarkReadLimit = 100
+	maxMarkReadLimit     = 1000
+)
+
+// markNotificationsReadUntil pages through the user's notifications from maxId
+// downwards, marking each unread one as read, until the pages run out, limit
+// notifications have been marked, or maxNotificationPages pages were scanned.
+//
+// Returns a summary with the number of notifications marked and scanned, the
+// lowest ID scanned, and whether a bound stopped the iteration early.
+func (s *MCPServer) markNotificationsReadUntil(maxId int64, limit int) (map[string]interface{}, error) {
+	marked, scanned := 0, 0
+	truncated := false
+	lowestId := maxId + 1
+
+	for cursor, pages := maxId, 0; cursor > 0; pages++ {
+		if marked >= limit || pages >= maxNotificationPages {
+			truncated = true
+			break
+		}
+		result, err := s.backlogClient.makeRequest("GET", "/notifications", map[string]interface{}{
+			"maxId": fmt.Sprintf("%d", cursor),
+			"count": notificationPageSize,
+			"order": "desc",
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		page, ok := result.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected notifications format: %T", result)
+		}
+
+		for _, item := range page {
+			notification, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, ok := notification["id"].(float64)
+			if !ok || int64(id) > cursor {
+				continue
+			}
+			if alreadyRead, _ := notification["alreadyRead"].(bool); !alreadyRead {
+				if marked >= limit {
+					truncated = true
+					break
+				}
+				if _, err := s.backlogClient.makeRequest("PUT", fmt.Sprintf("/notifications/%.0f/markAsRead", id), nil, nil); err != nil {
+					return nil, err
+				}
+				marked++
+			}
+			scanned++
+			if int64(id) < lowestId {
+				lowestId = int64(id)
+			}
+		}
+
+		// A short page is the last one; a page that does not move the cursor
+		// down would repeat forever
+		if truncated || len(page) < notificationPageSize || lowestId > cursor {
+			break
+		}
+		cursor = lowestId - 1
+	}
+
+	summary := map[string]interface{}{
+		"marked":    marked,
+		"scanned":   scanned,
+		"truncated": truncated,
+	}
+	if scanned > 0 {
+		summary["lowestId"] = lowestId
+	}
+	return summary, nil
+}
+
+// issueCountGroup describes how get_issue_count_by_group lists the groups of
+// a groupBy field and filters the issue count by one of them
+type issueCountGroup struct {
+	endpoint string // Endpoint listing the groups; %s is replaced by the project ID
+	filter   string // Issue count filter selecting one group
+}
+
+// issueCountGroups lists the fields get_issue_count_by_group can group by
+var issueCountGroups = map[string]issueCountGroup{
+	"status":    {endpoint: "/projects/%s/statuses", filter: "statusId"},
+	"priority":  {endpoint: "/priorities", filter: "priorityId"},
+	"issueType": {endpoint: "/projects/%s/issueTypes", filter: "issueTypeId"},
+}
+
+// countIssuesByGroup counts a project's issues in each group of the groupBy
+// field with one /issues/count request per group, since Backlog has no grouped
+// count endpoint.
+//
+// Returns the groups with their counts in Backlog's display order, the total,
+// and parallel labels and counts arrays ready for a pie chart.
+func (s *MCPServer) countIssuesByGroup(projectIdOrKey, groupBy string) (map[string]interface{}, error) {
+	group := issueCountGroups[groupBy]
+
+	// The count filter takes a numeric project ID, not a key
+	project, err := s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	projectFields, _ := project.(map[string]interface{})
+	projectId, ok := projectFields["id"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected project format: %T", project)
+	}
+
+	endpoint := group.endpoint
+	if strings.Contains(endpoint, "%s") {
+		endpoint = fmt.Sprintf(endpoint, fmt.Sprintf("%.0f", projectId))
+	}
+	result, err := s.backlogClient.makeRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s format: %T", groupBy, result)
+	}
+
+	groups := make([]map[string]interface{}, 0, len(items))
+	labels := make([]string, 0, len(items))
+	counts := make([]int, 0, len(items))
+	total := 0
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := fields["id"].(float64)
+		if !ok {
+			continue
+		}
+		countResult, err := s.backlogClient.makeRequest("GET", "/issues/count", map[string]interface{}{
+			"projectId":  []interface{}{fmt.Sprintf("%.0f", projectId)},
+			group.filter: []interface{}{fmt.Sprintf("%.0f", id)},
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		countFields, _ := countResult.(map[string]interface{})
+		count, ok := countFields["count"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected issue count format: %T", countResult)
+		}
+
+		name, _ := fields["name"].(string)
+		entry := map[string]interface{}{"id": id, "name": name, "count": int(count)}
+		if color, ok := fields["color"].(string); ok && color != "" {
+			entry["color"] = color
+		}
+		groups = append(groups, entry)
+		labels = append(labels, name)
+		counts = append(counts, int(count))
+		total += int(count)
+	}
+
+	return map[string]interface{}{
+		"projectId": projectId,
+		"groupBy":   groupBy,
+		"total":     total,
+		"groups":    groups,
+		"labels":    labels,
+		"counts":    counts,
+	}, nil
+}
+
+const (
+	defaultSummaryRepositories = 20
+	maxSummaryRepositories     = 50
+	// summaryConcurrency is how many repositories' pull requests are counted
+	// at once, keeping the summary fast without flooding Backlog
+	summaryConcurrency = 5
+)
+
+// pullRequestStatuses maps the summary fields of get_project_pull_request_summary
+// to Backlog pull request status IDs
+var pullRequestStatuses = []struct {
+	field    string
+	statusId string
+}{
+	{"open", "1"},
+	{"closed", "2"},
+	{"merged", "3"},
+}
+
+// summarizeProjectPullRequests counts the open, closed, and merged pull
+// requests of each of the project's first limit Git repositories, up to
+// summaryConcurrency repositories at a time. Repositories without pull requests
+// are reported with zero counts.
+//
+// Returns per-repository and total counts, the number of repositories in the
+// project, and whether repositories beyond limit were left out.
+func (s *MCPServer) summarizeProjectPullRequests(projectIdOrKey string, limit int) (map[string]interface{}, error) {
+	result, err := s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey+"/git/repositories", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	repositories, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected repositories format: %T", result)
+	}
+	repositoryCount := len(repositories)
+	truncated := repositoryCount > limit
+	if truncated {
+		repositories = repositories[:limit]
+	}
+
+	summaries := make([]map[string]interface{}, len(repositories))
+	errs := make([]error, len(repositories))
+	slots := make(chan struct{}, summaryConcurrency)
+	var wg sync.WaitGroup
+	for i, item := range repositories {
+		repository, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := repository["id"].(float64)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, id float64, name interface{}) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			summaries[i], errs[i] = s.countRepositoryPullRequests(projectIdOrKey, id, name)
+		}(i, id, repository["name"])
+	}
+	wg.Wait()
+
+	totals := map[string]int{"open": 0, "closed": 0, "merged": 0, "total": 0}
+	counted := make([]map[string]interface{}, 0, len(summaries))
+	for i, summary := range summaries {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if summary == nil {
+			continue
+		}
+		for field := range totals {
+			totals[field] += summary[field].(int)
+		}
+		counted = append(counted, summary)
+	}
+
+	return map[string]interface{}{
+		"repositories":    counted,
+		"totals":          totals,
+		"repositoryCount": repositoryCount,
+		"truncated":       truncated,
+	}, nil
+}
+
+// countRepositoryPullRequests counts the pull requests of one repository by
+// status for summarizeProjectPullRequests
+func (s *MCPServer) countRepositoryPullRequests(projectIdOrKey string, id float64, name interface{}) (map[string]interface{}, error) {
+	summary := map[string]interface{}{"id": id, "name": name}
+	repositoryTotal := 0
+	for _, status := range pullRequestStatuses {
+		countResult, err := s.backlogClient.makeRequest("GET", fmt.Sprintf("/projects/%s/git/repositories/%.0f/pullRequests/count", projectIdOrKey, id), map[string]interface{}{
+			"statusId": []interface{}{status.statusId},
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		countFields, _ := countResult.(map[string]interface{})
+		count, ok := countFields["count"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected pull request count format: %T", countResult)
+		}
+		summary[status.field] = int(count)
+		repositoryTotal += int(count)
+	}
+	summary["total"] = repositoryTotal
+	return summary, nil
+}
+
 func (s *MCPServer) HandleRequest(request MCPRequest) MCPResponse {
 	switch request.Method {
 	case "initialize":
@@ -917,7 +1639,12 @@ func (s *MCPServer) handleToolsCall(request MCPRequest) MCPResponse {
 
 	result, err := s.executeTool(params.Name, params.Arguments)
 	if err != nil {
-		return MCPResponse{JSONRPC: "2.0", ID: request.ID, Error: &MCPError{Code: -32603, Message: err.Error()}}
+		mcpErr := &MCPError{Code: -32603, Message: err.Error()}
+		// Expose the Backlog status so clients can detect permission errors
+		if status := upstreamStatus(err); status != 0 {
+			mcpErr.Data = map[string]interface{}{"status": status}
+		}
+		return MCPResponse{JSONRPC: "2.0", ID: request.ID, Error: mcpErr}
 	}
 
 	resultBytes, _ := json.Marshal(result)
@@ -937,6 +1664,10 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 	case "get_space":
 		log.Printf("Making request to /space")
 		data, err = s.backlogClient.makeRequest("GET", "/space", nil, nil)
+	case "get_disk_usage":
+		data, err = s.backlogClient.makeRequest("GET", "/space/diskUsage", nil, nil)
+	case "get_licence":
+		data, err = s.backlogClient.makeRequest("GET", "/space/licence", nil, nil)
 	case "get_users":
 		log.Printf("Making request to /users")
 		data, err = s.backlogClient.makeRequest("GET", "/users", nil, nil)
@@ -945,9 +1676,27 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 		} else {
 			log.Printf("get_users succeeded, data type: %T", data)
 		}
-	case "get_myself":
-		log.Printf("Making request to /users/myself")
-		data, err = s.backlogClient.makeRequest("GET", "/users/myself", nil, nil)
+	case "get_myself":
+		log.Printf("Making request to /users/myself")
+		data, err = s.backlogClient.makeRequest("GET", "/users/myself", nil, nil)
+	case "get_rate_limit":
+		data, err = s.backlogClient.makeRequest("GET", "/rateLimit", nil, nil)
+
+	// Team tools
+	case "get_teams":
+		params := make(map[string]interface{})
+		for _, key := range []string{"order", "offset", "count"} {
+			if value, ok := args[key]; ok {
+				params[key] = value
+			}
+		}
+		data, err = s.backlogClient.makeRequest("GET", "/teams", params, nil)
+	case "get_team":
+		teamId, ok := args["teamId"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("teamId is required")
+		}
+		data, err = s.backlogClient.makeRequest("GET", fmt.Sprintf("/teams/%.0f", teamId), nil, nil)
 
 	// Project tools
 	case "get_project_list":
@@ -1081,6 +1830,24 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 		}
 		data, err = s.backlogClient.makeRequest("GET", "/issues/count", params, nil)
 
+	case "get_issue_count_by_group":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok && projectKey != "" {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		groupBy := "status"
+		if value, ok := args["groupBy"].(string); ok && value != "" {
+			groupBy = value
+		}
+		if _, ok := issueCountGroups[groupBy]; !ok {
+			return nil, fmt.Errorf("groupBy must be status, priority, or issueType")
+		}
+		data, err = s.countIssuesByGroup(projectIdOrKey, groupBy)
+
 	case "get_custom_fields":
 		projectIdOrKey, ok := args["projectIdOrKey"].(string)
 		if !ok {
@@ -1091,16 +1858,54 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 	case "get_watching_list_items":
 		params := make(map[string]interface{})
 		for key, value := range args {
-			params[key] = value
+			if key != "userId" {
+				params[key] = value
+			}
 		}
-		data, err = s.backlogClient.makeRequest("GET", "/users/myself/watchings", params, nil)
+		data, err = s.backlogClient.makeRequest("GET", watchingsPath(args)+"/watchings", params, nil)
 
 	case "get_watching_list_count":
-		params := make(map[string]interface{})
-		if userId, ok := args["userId"]; ok {
-			params["userId"] = userId
+		data, err = s.backlogClient.makeRequest("GET", watchingsPath(args)+"/watchings/count", nil, nil)
+
+	case "add_watching":
+		var issueIdOrKey string
+		if issueId, ok := args["issueId"].(float64); ok {
+			issueIdOrKey = fmt.Sprintf("%.0f", issueId)
+		} else if key, ok := args["issueIdOrKey"].(string); ok && key != "" {
+			issueIdOrKey = key
+		} else {
+			return nil, fmt.Errorf("either issueId or issueIdOrKey is required")
+		}
+		body := map[string]interface{}{"issueIdOrKey": issueIdOrKey}
+		if note, ok := args["note"].(string); ok && note != "" {
+			body["note"] = note
+		}
+		data, err = s.backlogClient.makeRequest("POST", "/watchings", nil, body)
+
+	case "delete_watching":
+		watchingId, ok := args["watchingId"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("watchingId is required")
+		}
+		data, err = s.backlogClient.makeRequest("DELETE", fmt.Sprintf("/watchings/%.0f", watchingId), nil, nil)
+
+	case "add_star":
+		body := make(map[string]interface{})
+		for _, key := range starTargets {
+			value, exists := args[key]
+			if !exists {
+				continue
+			}
+			id, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a number", key)
+			}
+			body[key] = fmt.Sprintf("%.0f", id)
 		}
-		data, err = s.backlogClient.makeRequest("GET", "/users/myself/watchings/count", params, nil)
+		if len(body) != 1 {
+			return nil, fmt.Errorf("exactly one of %s is required", strings.Join(starTargets, ", "))
+		}
+		data, err = s.backlogClient.makeRequest("POST", "/stars", nil, body)
 
 	// Issue metadata tools
 	case "get_issue_types":
@@ -1110,6 +1915,71 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 		}
 		data, err = s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey+"/issueTypes", nil, nil)
 
+	case "add_issue_type":
+		projectIdOrKey, ok := args["projectIdOrKey"].(string)
+		if !ok || projectIdOrKey == "" {
+			return nil, fmt.Errorf("projectIdOrKey is required")
+		}
+		name, ok := args["name"].(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		color, ok := args["color"].(string)
+		if !ok || color == "" {
+			return nil, fmt.Errorf("color is required")
+		}
+		if !isIssueTypeColor(color) {
+			return nil, fmt.Errorf("color must be one of %s", strings.Join(issueTypeColors, ", "))
+		}
+		body := map[string]interface{}{"name": name, "color": color}
+		data, err = s.backlogClient.makeRequest("POST", "/projects/"+projectIdOrKey+"/issueTypes", nil, body)
+
+	case "update_issue_type":
+		projectIdOrKey, ok := args["projectIdOrKey"].(string)
+		if !ok || projectIdOrKey == "" {
+			return nil, fmt.Errorf("projectIdOrKey is required")
+		}
+		id, ok := args["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+		body := make(map[string]interface{})
+		if name, ok := args["name"].(string); ok {
+			if strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("name must not be blank")
+			}
+			body["name"] = name
+		}
+		if color, ok := args["color"].(string); ok {
+			if !isIssueTypeColor(color) {
+				return nil, fmt.Errorf("color must be one of %s", strings.Join(issueTypeColors, ", "))
+			}
+			body["color"] = color
+		}
+		if len(body) == 0 {
+			return nil, fmt.Errorf("name or color is required")
+		}
+		data, err = s.backlogClient.makeRequest("PATCH", fmt.Sprintf("/projects/%s/issueTypes/%.0f", projectIdOrKey, id), nil, body)
+
+	case "delete_issue_type":
+		projectIdOrKey, ok := args["projectIdOrKey"].(string)
+		if !ok || projectIdOrKey == "" {
+			return nil, fmt.Errorf("projectIdOrKey is required")
+		}
+		id, ok := args["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+		substituteId, ok := args["substituteIssueTypeId"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("substituteIssueTypeId is required")
+		}
+		if substituteId == id {
+			return nil, fmt.Errorf("substituteIssueTypeId must differ from id")
+		}
+		body := map[string]interface{}{"substituteIssueTypeId": fmt.Sprintf("%.0f", substituteId)}
+		data, err = s.backlogClient.makeRequest("DELETE", fmt.Sprintf("/projects/%s/issueTypes/%.0f", projectIdOrKey, id), nil, body)
+
 	case "get_priorities":
 		data, err = s.backlogClient.makeRequest("GET", "/priorities", nil, nil)
 
@@ -1123,6 +1993,181 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 		}
 		data, err = s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey+"/categories", nil, nil)
 
+	case "add_category":
+		projectIdOrKey, ok := args["projectIdOrKey"].(string)
+		if !ok || projectIdOrKey == "" {
+			return nil, fmt.Errorf("projectIdOrKey is required")
+		}
+		name, ok := args["name"].(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		body := map[string]interface{}{"name": name}
+		data, err = s.backlogClient.makeRequest("POST", "/projects/"+projectIdOrKey+"/categories", nil, body)
+
+	case "update_category":
+		projectIdOrKey, ok := args["projectIdOrKey"].(string)
+		if !ok || projectIdOrKey == "" {
+			return nil, fmt.Errorf("projectIdOrKey is required")
+		}
+		id, ok := args["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+		name, ok := args["name"].(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		body := map[string]interface{}{"name": name}
+		data, err = s.backlogClient.makeRequest("PATCH", fmt.Sprintf("/projects/%s/categories/%.0f", projectIdOrKey, id), nil, body)
+
+	case "delete_category":
+		projectIdOrKey, ok := args["projectIdOrKey"].(string)
+		if !ok || projectIdOrKey == "" {
+			return nil, fmt.Errorf("projectIdOrKey is required")
+		}
+		id, ok := args["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+		data, err = s.backlogClient.makeRequest("DELETE", fmt.Sprintf("/projects/%s/categories/%.0f", projectIdOrKey, id), nil, nil)
+
+	// Version (milestone) tools
+	case "get_versions":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		data, err = s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey+"/versions", nil, nil)
+
+	case "add_version":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		if name, ok := args["name"].(string); !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		body, bodyErr := versionBody(args)
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+		data, err = s.backlogClient.makeRequest("POST", "/projects/"+projectIdOrKey+"/versions", nil, body)
+
+	case "update_version":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		id, ok := args["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+		// Backlog requires the name on every version update
+		if name, ok := args["name"].(string); !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		body, bodyErr := versionBody(args)
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+		data, err = s.backlogClient.makeRequest("PATCH", fmt.Sprintf("/projects/%s/versions/%.0f", projectIdOrKey, id), nil, body)
+
+	case "delete_version":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		id, ok := args["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+		data, err = s.backlogClient.makeRequest("DELETE", fmt.Sprintf("/projects/%s/versions/%.0f", projectIdOrKey, id), nil, nil)
+
+	// Webhook tools
+	case "get_webhooks":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		data, err = s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey+"/webhooks", nil, nil)
+
+	case "add_webhook":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		name, ok := args["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		hookUrl, ok := args["hookUrl"].(string)
+		if !ok || hookUrl == "" {
+			return nil, fmt.Errorf("hookUrl is required")
+		}
+		if parsed, parseErr := url.Parse(hookUrl); parseErr != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("hookUrl must be an http or https URL")
+		}
+		body := map[string]interface{}{"name": name, "hookUrl": hookUrl}
+		if description, ok := args["description"].(string); ok {
+			body["description"] = description
+		}
+		allEvent, _ := args["allEvent"].(bool)
+		if allEvent {
+			body["allEvent"] = true
+		} else {
+			activityTypeIds, ok := args["activityTypeIds"].([]interface{})
+			if !ok || len(activityTypeIds) == 0 {
+				return nil, fmt.Errorf("activityTypeIds is required unless allEvent is true")
+			}
+			for _, id := range activityTypeIds {
+				if _, ok := id.(float64); !ok {
+					return nil, fmt.Errorf("activityTypeIds must be numbers")
+				}
+			}
+			body["activityTypeIds"] = activityTypeIds
+		}
+		data, err = s.backlogClient.makeRequest("POST", "/projects/"+projectIdOrKey+"/webhooks", nil, body)
+
+	case "delete_webhook":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		webhookId, ok := args["webhookId"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("webhookId is required")
+		}
+		data, err = s.backlogClient.makeRequest("DELETE", fmt.Sprintf("/projects/%s/webhooks/%.0f", projectIdOrKey, webhookId), nil, nil)
+
 	// Wiki tools
 	case "get_wiki_pages":
 		params := make(map[string]interface{})
@@ -1246,6 +2291,25 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 		}
 		data, err = s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey+"/git/repositories/"+repoIdOrName+"/pullRequests/count", params, nil)
 
+	case "get_project_pull_request_summary":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok && projectKey != "" {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		limit := defaultSummaryRepositories
+		if value, ok := args["maxRepositories"]; ok {
+			maxRepositories, ok := value.(float64)
+			if !ok || maxRepositories < 1 || maxRepositories > maxSummaryRepositories {
+				return nil, fmt.Errorf("maxRepositories must be between 1 and %d", maxSummaryRepositories)
+			}
+			limit = int(maxRepositories)
+		}
+		data, err = s.summarizeProjectPullRequests(projectIdOrKey, limit)
+
 	case "get_pull_request":
 		pullRequestId, ok := args["pullRequestId"].(float64)
 		if !ok {
@@ -1450,6 +2514,47 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 		}
 		data, err = s.backlogClient.makeRequest("GET", "/files/"+fmt.Sprintf("%.0f", documentId), nil, nil)
 
+	case "get_shared_files":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		// Escape each path segment but keep the separators
+		var segments []string
+		if path, ok := args["path"].(string); ok {
+			for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+				if segment != "" {
+					segments = append(segments, url.PathEscape(segment))
+				}
+			}
+		}
+		params := make(map[string]interface{})
+		for _, key := range []string{"order", "offset", "count"} {
+			if value, ok := args[key]; ok {
+				params[key] = value
+			}
+		}
+		data, err = s.backlogClient.makeRequest("GET", "/projects/"+projectIdOrKey+"/files/metadata/"+strings.Join(segments, "/"), params, nil)
+
+	case "download_shared_file":
+		var projectIdOrKey string
+		if projectId, ok := args["projectId"].(float64); ok {
+			projectIdOrKey = fmt.Sprintf("%.0f", projectId)
+		} else if projectKey, ok := args["projectKey"].(string); ok {
+			projectIdOrKey = projectKey
+		} else {
+			return nil, fmt.Errorf("either projectId or projectKey is required")
+		}
+		sharedFileId, ok := args["sharedFileId"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("sharedFileId is required")
+		}
+		data, err = s.backlogClient.makeRequest("GET", fmt.Sprintf("/projects/%s/files/%.0f", projectIdOrKey, sharedFileId), nil, nil)
+
 	// Notifications tools
 	case "get_notifications":
 		params := make(map[string]interface{})
@@ -1475,6 +2580,21 @@ func (s *MCPServer) executeTool(toolName string, args map[string]interface{}) (*
 		}
 		data, err = s.backlogClient.makeRequest("PUT", "/notifications/"+fmt.Sprintf("%.0f", id)+"/markAsRead", nil, nil)
 
+	case "mark_notifications_read_until":
+		maxId, ok := args["maxId"].(float64)
+		if !ok || maxId < 1 {
+			return nil, fmt.Errorf("maxId is required")
+		}
+		limit := defaultMarkReadLimit
+		if value, exists := args["limit"]; exists {
+			requested, ok := value.(float64)
+			if !ok || requested < 1 || requested > maxMarkReadLimit {
+				return nil, fmt.Errorf("limit must be between 1 and %d", maxMarkReadLimit)
+			}
+			limit = int(requested)
+		}
+		data, err = s.markNotificationsReadUntil(int64(maxId), limit)
+
 	default:
 		return nil, fmt.Errorf("unknown tool: %s", toolName)
 	}
@@ -1541,7 +2661,7 @@ func (h *HTTPBridge) handleMCPCall(c *gin.Context) {
 		resp := tempServer.HandleRequest(mcpReq)
 		
 		if resp.Error != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": resp.Error.Message, "code": resp.Error.Code})
+			c.JSON(http.StatusBadRequest, bridgeError(resp.Error))
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"result": resp.Result})
@@ -1556,13 +2676,54 @@ func (h *HTTPBridge) handleMCPCall(c *gin.Context) {
 	
 	resp := h.mcpServer.HandleRequest(mcpReq)
 	if resp.Error != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Error.Message, "code": resp.Error.Code})
+		c.JSON(http.StatusBadRequest, bridgeError(resp.Error))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"result": resp.Result})
 }
 
+// handleRPC serves standard JSON-RPC over HTTP for generic MCP clients. The body
+// is a raw MCPRequest and the reply is the MCPResponse from HandleRequest, so
+// protocol and tool errors are returned in the JSON-RPC error member with HTTP
+// 200. An OAuth access token may be passed as "Authorization: Bearer <token>".
+func (h *HTTPBridge) handleRPC(c *gin.Context) {
+	var req MCPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, MCPResponse{JSONRPC: "2.0", Error: &MCPError{Code: -32700, Message: "Parse error"}})
+		return
+	}
+
+	server := h.mcpServer
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && token != "" {
+		tempClient, err := NewBacklogClient(os.Getenv("BACKLOG_DOMAIN"), token, "")
+		if err != nil {
+			c.JSON(http.StatusOK, MCPResponse{JSONRPC: "2.0", ID: req.ID, Error: &MCPError{Code: -32603, Message: err.Error()}})
+			return
+		}
+		server = NewMCPServer(tempClient)
+	}
+
+	if req.Method == "tools/call" && server.backlogClient == nil {
+		c.JSON(http.StatusOK, MCPResponse{JSONRPC: "2.0", ID: req.ID, Error: &MCPError{Code: -32603, Message: "No credentials configured. Please provide a Bearer token or configure environment variables."}})
+		return
+	}
+
+	c.JSON(http.StatusOK, server.HandleRequest(req))
+}
+
+// bridgeError builds the HTTP bridge error body for an MCP error, including the
+// Backlog HTTP status (e.g., 403) when the tool failed because of an API error.
+func bridgeError(mcpErr *MCPError) gin.H {
+	body := gin.H{"error": mcpErr.Message, "code": mcpErr.Code}
+	if data, ok := mcpErr.Data.(map[string]interface{}); ok {
+		if status, ok := data["status"].(int); ok {
+			body["status"] = status
+		}
+	}
+	return body
+}
+
 // ==========================================
 // Main Application
 // ==========================================
@@ -1610,6 +2771,9 @@ func runMCPServer(domain, accessToken, apiKey string) {
 	scanner := bufio.NewScanner(os.Stdin)
 	writer := os.Stdout
 
+	// JSON-RPC batch arrays are accepted unless explicitly disabled
+	batchEnabled := os.Getenv("MCP_BATCH_ENABLED") != "false"
+
 	log.Println("Backlog MCP Server (Golang) started")
 
 	for scanner.Scan() {
@@ -1618,14 +2782,25 @@ func runMCPServer(domain, accessToken, apiKey string) {
 			continue
 		}
 
-		var request MCPRequest
-		if err := json.Unmarshal([]byte(line), &request); err != nil {
-			log.Printf("Error parsing request: %v", err)
+		var response interface{}
+		if strings.HasPrefix(line, "[") {
+			if batchEnabled {
+				response = handleBatch(mcpServer, []byte(line))
+			} else {
+				response = MCPResponse{JSONRPC: "2.0", Error: &MCPError{Code: -32600, Message: "Batch requests are disabled"}}
+			}
+		} else {
+			var request MCPRequest
+			if err := json.Unmarshal([]byte(line), &request); err != nil {
+				log.Printf("Error parsing request: %v", err)
+				continue
+			}
+			response = mcpServer.HandleRequest(request)
+		}
+		if response == nil {
 			continue
 		}
 
-		response := mcpServer.HandleRequest(request)
-
 		responseBytes, err := json.Marshal(response)
 		if err != nil {
 			log.Printf("Error marshaling response: %v", err)
@@ -1659,10 +2834,52 @@ func runHTTPBridge(domain, accessToken, apiKey string) {
 	// Setup Gin router
 	r := gin.Default()
 	r.POST("/mcp/call", bridge.handleMCPCall)
+	r.POST("/rpc", bridge.handleRPC)
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
-	log.Println("Backlog MCP Server (Golang HTTP Bridge) starting on :3001")
-	log.Fatal(http.ListenAndServe(":3001", r))
-}
\ No newline at end of file
+	port := os.Getenv("BACKLOG_MCP_PORT")
+	if port == "" {
+		port = "3001"
+	}
+
+	log.Printf("Backlog MCP Server (Golang HTTP Bridge) starting on :%s", port)
+	log.Fatal(http.ListenAndServe(":"+port, r))
+}
+
+// handleBatch processes a JSON-RPC batch array and returns one response per
+// request in the same order. Notifications, which have no id, are handled but
+// get no response, so a batch of only notifications returns nil. Elements that
+// are not valid requests get an Invalid Request error, and an empty or
+// malformed batch gets a single error response as required by the JSON-RPC 2.0
+// specification.
+func handleBatch(mcpServer *MCPServer, line []byte) interface{} {
+	var batch []json.RawMessage
+	if err := json.Unmarshal(line, &batch); err != nil {
+		log.Printf("Error parsing batch request: %v", err)
+		return MCPResponse{JSONRPC: "2.0", Error: &MCPError{Code: -32700, Message: "Parse error"}}
+	}
+	if len(batch) == 0 {
+		return MCPResponse{JSONRPC: "2.0", Error: &MCPError{Code: -32600, Message: "Invalid Request"}}
+	}
+
+	responses := make([]MCPResponse, 0, len(batch))
+	for _, raw := range batch {
+		var request MCPRequest
+		if err := json.Unmarshal(raw, &request); err != nil {
+			log.Printf("Error parsing batch element: %v", err)
+			responses = append(responses, MCPResponse{JSONRPC: "2.0", Error: &MCPError{Code: -32600, Message: "Invalid Request"}})
+			continue
+		}
+		response := mcpServer.HandleRequest(request)
+		if request.ID == nil {
+			continue
+		}
+		responses = append(responses, response)
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+	return responses
+}
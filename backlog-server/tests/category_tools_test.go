@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestBacklogMCP_CategoryToolValidation tests that the category management
+// tools reject missing required arguments before calling Backlog
+func TestBacklogMCP_CategoryToolValidation(t *testing.T) {
+	binary := buildServer(t)
+
+	cases := []struct {
+		call     string
+		expected string
+	}{
+		{`{"name": "add_category", "arguments": {"name": "Backend"}}`, "projectIdOrKey is required"},
+		{`{"name": "add_category", "arguments": {"projectIdOrKey": "PRJ"}}`, "name is required"},
+		{`{"name": "add_category", "arguments": {"projectIdOrKey": "PRJ", "name": "  "}}`, "name is required"},
+		{`{"name": "update_category", "arguments": {"id": 1, "name": "Backend"}}`, "projectIdOrKey is required"},
+		{`{"name": "update_category", "arguments": {"projectIdOrKey": "PRJ", "name": "Backend"}}`, "id is required"},
+		{`{"name": "update_category", "arguments": {"projectIdOrKey": "PRJ", "id": 1}}`, "name is required"},
+		{`{"name": "delete_category", "arguments": {"id": 1}}`, "projectIdOrKey is required"},
+		{`{"name": "delete_category", "arguments": {"projectIdOrKey": "PRJ"}}`, "id is required"},
+	}
+
+	calls := make([]string, len(cases))
+	for i, tc := range cases {
+		calls[i] = tc.call
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	for i, tc := range cases {
+		if got := messages[int64(i+1)]; !strings.HasPrefix(got, tc.expected) {
+			t.Errorf("Call %s: expected error %q, got %q", tc.call, tc.expected, got)
+		}
+	}
+}
+
+// TestBacklogMCP_CategoryToolsCallBacklog tests that the category management
+// tools send their fields to the project's category endpoints and return
+// Backlog's category
+func TestBacklogMCP_CategoryToolsCallBacklog(t *testing.T) {
+	binary := buildServer(t)
+	category := `{"id": 8, "name": "Frontend", "displayOrder": 0}`
+
+	cases := []struct {
+		tool, arguments, method, path string
+		form                          url.Values
+	}{
+		{"add_category", `{"projectIdOrKey": "PRJ", "name": "Frontend"}`,
+			http.MethodPost, "/projects/PRJ/categories", url.Values{"name": {"Frontend"}}},
+		{"update_category", `{"projectIdOrKey": "PRJ", "id": 8, "name": "Web"}`,
+			http.MethodPatch, "/projects/PRJ/categories/8", url.Values{"name": {"Web"}}},
+		{"delete_category", `{"projectIdOrKey": "PRJ", "id": 8}`,
+			http.MethodDelete, "/projects/PRJ/categories/8", url.Values{}},
+	}
+	for _, tc := range cases {
+		backlog, requests := newRecordingBacklog(t, category)
+		result := callTool(t, binary, backlog.URL, tc.tool, tc.arguments)
+
+		received := requests()
+		if len(received) != 1 || received[0].Method != tc.method || received[0].Path != tc.path {
+			t.Fatalf("%s: expected %s %s, got %+v", tc.tool, tc.method, tc.path, received)
+		}
+		if !reflect.DeepEqual(received[0].Form, tc.form) {
+			t.Errorf("%s: expected form %v, got %v", tc.tool, tc.form, received[0].Form)
+		}
+		assertSameJSON(t, result, category)
+	}
+}
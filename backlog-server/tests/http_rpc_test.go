@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startHTTPBridge runs the server in HTTP bridge mode on a free port and
+// returns its base URL once the health endpoint responds
+func startHTTPBridge(t *testing.T, binary string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	// A character device on stdin selects the HTTP bridge over stdio mode
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+	}
+	t.Cleanup(func() { devNull.Close() })
+
+	cmd := exec.Command(binary)
+	cmd.Env = append(os.Environ(), "BACKLOG_DOMAIN=example.backlog.com", "BACKLOG_MCP_PORT="+strconv.Itoa(port))
+	cmd.Stdin = devNull
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	baseURL := "http://127.0.0.1:" + strconv.Itoa(port)
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if resp, err := http.Get(baseURL + "/health"); err == nil {
+			resp.Body.Close()
+			return baseURL
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("HTTP bridge did not become ready")
+	return ""
+}
+
+// TestHTTPBridge_RPCToolsList tests that POST /rpc accepts a raw JSON-RPC
+// tools/list request and returns the tool list as a JSON-RPC response
+func TestHTTPBridge_RPCToolsList(t *testing.T) {
+	binary := buildServer(t)
+	baseURL := startHTTPBridge(t, binary)
+
+	resp, err := http.Post(baseURL+"/rpc", "application/json", strings.NewReader(`{"jsonrpc": "2.0", "id": 7, "method": "tools/list"}`))
+	if err != nil {
+		t.Fatalf("Failed to call /rpc: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var response struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      int64  `json:"id"`
+		Result  *struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Error != nil || response.Result == nil {
+		t.Fatalf("Expected a result, got error %+v", response.Error)
+	}
+	if response.JSONRPC != "2.0" || response.ID != 7 {
+		t.Errorf("Expected a JSON-RPC 2.0 response with id 7, got %q and %d", response.JSONRPC, response.ID)
+	}
+
+	found := false
+	for _, tool := range response.Result.Tools {
+		if tool.Name == "get_space" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected get_space in %d listed tools", len(response.Result.Tools))
+	}
+}
+
+// TestHTTPBridge_RPCToolsCallWithoutCredentials tests that a tools/call
+// without configured credentials returns a JSON-RPC error instead of failing
+func TestHTTPBridge_RPCToolsCallWithoutCredentials(t *testing.T) {
+	binary := buildServer(t)
+	baseURL := startHTTPBridge(t, binary)
+
+	resp, err := http.Post(baseURL+"/rpc", "application/json", strings.NewReader(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_space", "arguments": {}}}`))
+	if err != nil {
+		t.Fatalf("Failed to call /rpc: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Error *struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Error == nil || response.Error.Code != -32603 {
+		t.Errorf("Expected a -32603 credentials error, got %+v", response.Error)
+	}
+}
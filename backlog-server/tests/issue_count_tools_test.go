@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestBacklogMCP_IssueCountByGroupCountsEachStatus tests that
+// get_issue_count_by_group resolves a project key, counts the issues of each
+// of the project's statuses, and returns chart-ready labels and counts
+func TestBacklogMCP_IssueCountByGroupCountsEachStatus(t *testing.T) {
+	binary := buildServer(t)
+
+	statusCounts := map[string]int{"1": 4, "2": 2, "3": 0, "4": 7}
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects/DEMO":
+			w.Write([]byte(`{"id": 12, "projectKey": "DEMO"}`))
+		case "/projects/12/statuses":
+			w.Write([]byte(`[
+				{"id": 1, "name": "Open", "color": "#ed8077"},
+				{"id": 2, "name": "In Progress", "color": "#4488c5"},
+				{"id": 3, "name": "Resolved", "color": "#5eb5a6"},
+				{"id": 4, "name": "Closed", "color": "#b0be3c"}
+			]`))
+		case "/issues/count":
+			if got := r.URL.Query()["projectId[]"]; len(got) != 1 || got[0] != "12" {
+				t.Errorf("Expected the count to filter by project 12, got %v", got)
+			}
+			json.NewEncoder(w).Encode(map[string]int{"count": statusCounts[r.URL.Query().Get("statusId[]")]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer backlog.Close()
+
+	request := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_issue_count_by_group", "arguments": {"projectKey": "DEMO"}}}`
+	line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+
+	var response struct {
+		Result *struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.Result == nil || len(response.Result.Content) == 0 {
+		t.Fatalf("Expected a tool result, got %q (%v)", line, err)
+	}
+	var counts struct {
+		GroupBy string   `json:"groupBy"`
+		Total   int      `json:"total"`
+		Labels  []string `json:"labels"`
+		Counts  []int    `json:"counts"`
+		Groups  []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+			Count int    `json:"count"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(response.Result.Content[0].Text), &counts); err != nil {
+		t.Fatalf("Failed to decode counts: %v", err)
+	}
+
+	if counts.GroupBy != "status" || counts.Total != 13 {
+		t.Errorf("Expected status counts totalling 13, got %s totalling %d", counts.GroupBy, counts.Total)
+	}
+	if want := []string{"Open", "In Progress", "Resolved", "Closed"}; !reflect.DeepEqual(counts.Labels, want) {
+		t.Errorf("Expected labels %v, got %v", want, counts.Labels)
+	}
+	if want := []int{4, 2, 0, 7}; !reflect.DeepEqual(counts.Counts, want) {
+		t.Errorf("Expected counts %v, got %v", want, counts.Counts)
+	}
+	if len(counts.Groups) != 4 || counts.Groups[0].Color != "#ed8077" || counts.Groups[3].Count != 7 {
+		t.Errorf("Expected each status with its color and count, got %+v", counts.Groups)
+	}
+}
+
+// TestBacklogMCP_IssueCountByGroupValidatesArguments tests that
+// get_issue_count_by_group requires a project and a supported groupBy
+func TestBacklogMCP_IssueCountByGroupValidatesArguments(t *testing.T) {
+	binary := buildServer(t)
+
+	calls := []string{
+		`{"name": "get_issue_count_by_group", "arguments": {}}`,
+		`{"name": "get_issue_count_by_group", "arguments": {"projectKey": "DEMO", "groupBy": "assignee"}}`,
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	want := []string{"either projectId or projectKey is required", "groupBy must be status, priority, or issueType"}
+	for i, call := range calls {
+		if got := messages[int64(i+1)]; got != want[i] {
+			t.Errorf("Call %s: expected error %q, got %q", call, want[i], got)
+		}
+	}
+}
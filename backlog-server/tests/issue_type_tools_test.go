@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// backlogRequest is a request received by a mock Backlog API
+type backlogRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Form   url.Values
+}
+
+// newRecordingBacklog starts a mock Backlog API that answers every request
+// with the given JSON, or with 204 No Content when it is empty, as Backlog
+// does for stars, and returns a function listing the requests received. Form
+// bodies are decoded for every method, including DELETE.
+func newRecordingBacklog(t *testing.T, response string) (*httptest.Server, func() []backlogRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []backlogRequest
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		mu.Lock()
+		requests = append(requests, backlogRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Form: form})
+		mu.Unlock()
+		if response == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(backlog.Close)
+	return backlog, func() []backlogRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]backlogRequest(nil), requests...)
+	}
+}
+
+// callTool calls a tool against the Backlog API at backlogURL and returns
+// the text of its result
+func callTool(t *testing.T, binary, backlogURL, name, arguments string) string {
+	t.Helper()
+	request := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": %q, "arguments": %s}}`, name, arguments)
+	line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlogURL)
+
+	var response struct {
+		Result *struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.Result == nil || len(response.Result.Content) == 0 {
+		t.Fatalf("Expected a result from %s, got %q (%v)", name, line, err)
+	}
+	return response.Result.Content[0].Text
+}
+
+// assertSameJSON fails the test unless got and want hold the same JSON value
+func assertSameJSON(t *testing.T, got, want string) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal([]byte(got), &gotValue); err != nil {
+		t.Fatalf("Expected a JSON result, got %q: %v", got, err)
+	}
+	json.Unmarshal([]byte(want), &wantValue)
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("Expected result %s, got %s", want, got)
+	}
+}
+
+// toolCallErrors sends each tools/call as one batch and returns the error message per request id
+func toolCallErrors(t *testing.T, binary string, calls []string) map[int64]string {
+	t.Helper()
+	requests := make([]string, len(calls))
+	for i, call := range calls {
+		requests[i] = fmt.Sprintf(`{"jsonrpc": "2.0", "id": %d, "method": "tools/call", "params": %s}`, i+1, call)
+	}
+	line := runStdio(t, binary, "["+strings.Join(requests, ",")+"]")
+
+	var responses []struct {
+		ID    int64 `json:"id"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(line), &responses); err != nil {
+		t.Fatalf("Expected a batch response array, got %q: %v", line, err)
+	}
+
+	messages := make(map[int64]string)
+	for _, response := range responses {
+		if response.Error == nil {
+			t.Errorf("Expected an error for request %d", response.ID)
+			continue
+		}
+		if response.Error.Code != -32603 {
+			t.Errorf("Expected error code -32603 for request %d, got %d", response.ID, response.Error.Code)
+		}
+		messages[response.ID] = response.Error.Message
+	}
+	return messages
+}
+
+// TestBacklogMCP_IssueTypeToolValidation tests that the issue type management
+// tools reject missing or invalid arguments before calling Backlog
+func TestBacklogMCP_IssueTypeToolValidation(t *testing.T) {
+	binary := buildServer(t)
+
+	cases := []struct {
+		call     string
+		expected string
+	}{
+		{`{"name": "add_issue_type", "arguments": {"name": "Bug", "color": "#e30000"}}`, "projectIdOrKey is required"},
+		{`{"name": "add_issue_type", "arguments": {"projectIdOrKey": "PRJ", "name": " ", "color": "#e30000"}}`, "name is required"},
+		{`{"name": "add_issue_type", "arguments": {"projectIdOrKey": "PRJ", "name": "Bug"}}`, "color is required"},
+		{`{"name": "add_issue_type", "arguments": {"projectIdOrKey": "PRJ", "name": "Bug", "color": "#123456"}}`, "color must be one of"},
+		{`{"name": "update_issue_type", "arguments": {"id": 1, "name": "Bug"}}`, "projectIdOrKey is required"},
+		{`{"name": "update_issue_type", "arguments": {"projectIdOrKey": "PRJ", "name": "Bug"}}`, "id is required"},
+		{`{"name": "update_issue_type", "arguments": {"projectIdOrKey": "PRJ", "id": 1}}`, "name or color is required"},
+		{`{"name": "update_issue_type", "arguments": {"projectIdOrKey": "PRJ", "id": 1, "color": "red"}}`, "color must be one of"},
+		{`{"name": "delete_issue_type", "arguments": {"id": 1, "substituteIssueTypeId": 2}}`, "projectIdOrKey is required"},
+		{`{"name": "delete_issue_type", "arguments": {"projectIdOrKey": "PRJ", "substituteIssueTypeId": 2}}`, "id is required"},
+		{`{"name": "delete_issue_type", "arguments": {"projectIdOrKey": "PRJ", "id": 1}}`, "substituteIssueTypeId is required"},
+		{`{"name": "delete_issue_type", "arguments": {"projectIdOrKey": "PRJ", "id": 1, "substituteIssueTypeId": 1}}`, "substituteIssueTypeId must differ from id"},
+	}
+
+	calls := make([]string, len(cases))
+	for i, tc := range cases {
+		calls[i] = tc.call
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	for i, tc := range cases {
+		if got := messages[int64(i+1)]; !strings.HasPrefix(got, tc.expected) {
+			t.Errorf("Call %s: expected error %q, got %q", tc.call, tc.expected, got)
+		}
+	}
+}
+
+// TestBacklogMCP_IssueTypeToolsCallBacklog tests that the issue type
+// management tools send their fields to the project's issue type endpoints
+// and return Backlog's issue type
+func TestBacklogMCP_IssueTypeToolsCallBacklog(t *testing.T) {
+	binary := buildServer(t)
+	issueType := `{"id": 5, "projectId": 12, "name": "Bug", "color": "#e30000", "displayOrder": 0}`
+
+	cases := []struct {
+		tool, arguments, method, path string
+		form                          url.Values
+	}{
+		{"add_issue_type", `{"projectIdOrKey": "PRJ", "name": "Bug", "color": "#e30000"}`,
+			http.MethodPost, "/projects/PRJ/issueTypes", url.Values{"name": {"Bug"}, "color": {"#e30000"}}},
+		{"update_issue_type", `{"projectIdOrKey": "PRJ", "id": 5, "color": "#990000"}`,
+			http.MethodPatch, "/projects/PRJ/issueTypes/5", url.Values{"color": {"#990000"}}},
+		{"delete_issue_type", `{"projectIdOrKey": "PRJ", "id": 5, "substituteIssueTypeId": 6}`,
+			http.MethodDelete, "/projects/PRJ/issueTypes/5", url.Values{"substituteIssueTypeId": {"6"}}},
+	}
+	for _, tc := range cases {
+		backlog, requests := newRecordingBacklog(t, issueType)
+		result := callTool(t, binary, backlog.URL, tc.tool, tc.arguments)
+
+		received := requests()
+		if len(received) != 1 || received[0].Method != tc.method || received[0].Path != tc.path {
+			t.Fatalf("%s: expected %s %s, got %+v", tc.tool, tc.method, tc.path, received)
+		}
+		if !reflect.DeepEqual(received[0].Form, tc.form) {
+			t.Errorf("%s: expected form %v, got %v", tc.tool, tc.form, received[0].Form)
+		}
+		assertSameJSON(t, result, issueType)
+	}
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newMockNotifications serves descending pages of notifications with IDs from
+// 1 up to the requested maxId and counts list and mark-as-read requests
+func newMockNotifications(t *testing.T, alreadyRead bool) (*httptest.Server, *int64, *int64) {
+	t.Helper()
+	var pages, marks int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/markAsRead") {
+			atomic.AddInt64(&marks, 1)
+			w.Write([]byte(`{}`))
+			return
+		}
+		atomic.AddInt64(&pages, 1)
+		maxId, _ := strconv.Atoi(r.URL.Query().Get("maxId"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		page := make([]map[string]interface{}, 0, count)
+		for id := maxId; id > 0 && len(page) < count; id-- {
+			page = append(page, map[string]interface{}{"id": id, "alreadyRead": alreadyRead})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	return server, &pages, &marks
+}
+
+// callMarkReadUntil runs mark_notifications_read_until against a mock Backlog
+// and returns the decoded summary
+func callMarkReadUntil(t *testing.T, binary, backlogURL, arguments string) map[string]interface{} {
+	t.Helper()
+	request := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "mark_notifications_read_until", "arguments": %s}}`, arguments)
+	line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlogURL)
+
+	var response struct {
+		Result *struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.Result == nil || len(response.Result.Content) == 0 {
+		t.Fatalf("Expected a tool result, got %q (%v)", line, err)
+	}
+	var summary map[string]interface{}
+	if err := json.Unmarshal([]byte(response.Result.Content[0].Text), &summary); err != nil {
+		t.Fatalf("Failed to decode summary: %v", err)
+	}
+	return summary
+}
+
+// TestBacklogMCP_MarkNotificationsReadUntilStopsAtLimit tests that marking
+// stops after limit notifications and reports the truncation
+func TestBacklogMCP_MarkNotificationsReadUntilStopsAtLimit(t *testing.T) {
+	binary := buildServer(t)
+	backlog, _, marks := newMockNotifications(t, false)
+
+	summary := callMarkReadUntil(t, binary, backlog.URL, `{"maxId": 250, "limit": 120}`)
+	if *marks != 120 || summary["marked"] != float64(120) || summary["truncated"] != true {
+		t.Errorf("Expected 120 notifications marked and truncated, got %d requests and %v", *marks, summary)
+	}
+
+	backlog, _, marks = newMockNotifications(t, false)
+	summary = callMarkReadUntil(t, binary, backlog.URL, `{"maxId": 150, "limit": 500}`)
+	if *marks != 150 || summary["truncated"] != false || summary["lowestId"] != float64(1) {
+		t.Errorf("Expected all 150 notifications marked without truncation, got %d requests and %v", *marks, summary)
+	}
+}
+
+// TestBacklogMCP_MarkNotificationsReadUntilCapsPages tests that a long history
+// of already read notifications is scanned for a bounded number of pages
+func TestBacklogMCP_MarkNotificationsReadUntilCapsPages(t *testing.T) {
+	binary := buildServer(t)
+	backlog, pages, marks := newMockNotifications(t, true)
+
+	summary := callMarkReadUntil(t, binary, backlog.URL, `{"maxId": 1000000}`)
+	if *pages != 20 || *marks != 0 {
+		t.Errorf("Expected 20 pages scanned and nothing marked, got %d pages and %d marks", *pages, *marks)
+	}
+	if summary["scanned"] != float64(2000) || summary["truncated"] != true {
+		t.Errorf("Expected 2000 notifications scanned and truncated, got %v", summary)
+	}
+}
+
+// TestBacklogMCP_MarkNotificationsReadUntilValidation tests argument validation
+func TestBacklogMCP_MarkNotificationsReadUntilValidation(t *testing.T) {
+	binary := buildServer(t)
+
+	messages := toolCallErrors(t, binary, []string{
+		`{"name": "mark_notifications_read_until", "arguments": {}}`,
+		`{"name": "mark_notifications_read_until", "arguments": {"maxId": 10, "limit": 0}}`,
+		`{"name": "mark_notifications_read_until", "arguments": {"maxId": 10, "limit": 5000}}`,
+	})
+	expected := []string{"maxId is required", "limit must be between 1 and 1000", "limit must be between 1 and 1000"}
+	for i, want := range expected {
+		if got := messages[int64(i+1)]; got != want {
+			t.Errorf("Call %d: expected error %q, got %q", i+1, want, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pullRequestSummary is the decoded result of get_project_pull_request_summary
+type pullRequestSummary struct {
+	Repositories []struct {
+		Name   string `json:"name"`
+		Open   int    `json:"open"`
+		Closed int    `json:"closed"`
+		Merged int    `json:"merged"`
+		Total  int    `json:"total"`
+	} `json:"repositories"`
+	Totals          map[string]int `json:"totals"`
+	RepositoryCount int            `json:"repositoryCount"`
+	Truncated       bool           `json:"truncated"`
+}
+
+// callPullRequestSummary runs get_project_pull_request_summary against a mock
+// Backlog with two repositories, the second of which has no pull requests
+func callPullRequestSummary(t *testing.T, binary, arguments string) pullRequestSummary {
+	t.Helper()
+	// Pull request counts by repository and status ID (1 open, 2 closed, 3 merged)
+	counts := map[string]map[string]int{
+		"/projects/DEMO/git/repositories/7/pullRequests/count": {"1": 3, "2": 1, "3": 5},
+		"/projects/DEMO/git/repositories/8/pullRequests/count": {},
+	}
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/projects/DEMO/git/repositories" {
+			w.Write([]byte(`[{"id": 7, "name": "api"}, {"id": 8, "name": "docs"}]`))
+			return
+		}
+		byStatus, ok := counts[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]int{"count": byStatus[r.URL.Query().Get("statusId[]")]})
+	}))
+	defer backlog.Close()
+
+	request := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_project_pull_request_summary", "arguments": %s}}`, arguments)
+	line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+
+	var response struct {
+		Result *struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.Result == nil || len(response.Result.Content) == 0 {
+		t.Fatalf("Expected a tool result, got %q (%v)", line, err)
+	}
+	var summary pullRequestSummary
+	if err := json.Unmarshal([]byte(response.Result.Content[0].Text), &summary); err != nil {
+		t.Fatalf("Failed to decode summary: %v", err)
+	}
+	return summary
+}
+
+// TestBacklogMCP_PullRequestSummaryAggregatesRepositories tests that pull
+// request counts are reported per repository and in total, with zero counts
+// for a repository without pull requests
+func TestBacklogMCP_PullRequestSummaryAggregatesRepositories(t *testing.T) {
+	binary := buildServer(t)
+
+	summary := callPullRequestSummary(t, binary, `{"projectKey": "DEMO"}`)
+	if len(summary.Repositories) != 2 || summary.RepositoryCount != 2 || summary.Truncated {
+		t.Fatalf("Expected both repositories without truncation, got %+v", summary)
+	}
+	api, docs := summary.Repositories[0], summary.Repositories[1]
+	if api.Name != "api" || api.Open != 3 || api.Closed != 1 || api.Merged != 5 || api.Total != 9 {
+		t.Errorf("Expected api with 3 open, 1 closed, and 5 merged, got %+v", api)
+	}
+	if docs.Name != "docs" || docs.Total != 0 {
+		t.Errorf("Expected docs with no pull requests, got %+v", docs)
+	}
+	want := map[string]int{"open": 3, "closed": 1, "merged": 5, "total": 9}
+	for field, count := range want {
+		if summary.Totals[field] != count {
+			t.Errorf("Expected total %s of %d, got %d", field, count, summary.Totals[field])
+		}
+	}
+
+	summary = callPullRequestSummary(t, binary, `{"projectKey": "DEMO", "maxRepositories": 1}`)
+	if len(summary.Repositories) != 1 || summary.RepositoryCount != 2 || !summary.Truncated {
+		t.Errorf("Expected one of two repositories counted and truncation reported, got %+v", summary)
+	}
+}
+
+// TestBacklogMCP_PullRequestSummaryCountsRepositoriesConcurrently tests that
+// the repositories' pull requests are counted several at a time, but never
+// more than five repositories at once, keeping Backlog's order in the result
+func TestBacklogMCP_PullRequestSummaryCountsRepositoriesConcurrently(t *testing.T) {
+	binary := buildServer(t)
+
+	var (
+		mu               sync.Mutex
+		inFlight, peak   int
+		repositoryIDs    []string
+		repositoryValues []string
+	)
+	for id := 1; id <= 12; id++ {
+		repositoryIDs = append(repositoryIDs, fmt.Sprint(id))
+		repositoryValues = append(repositoryValues, fmt.Sprintf(`{"id": %d, "name": "repo-%d"}`, id, id))
+	}
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/projects/DEMO/git/repositories" {
+			w.Write([]byte("[" + strings.Join(repositoryValues, ", ") + "]"))
+			return
+		}
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(`{"count": 1}`))
+	}))
+	defer backlog.Close()
+
+	request := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_project_pull_request_summary", "arguments": {"projectKey": "DEMO"}}}`
+	line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+
+	var response struct {
+		Result *struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.Result == nil || len(response.Result.Content) == 0 {
+		t.Fatalf("Expected a tool result, got %q (%v)", line, err)
+	}
+	var summary pullRequestSummary
+	if err := json.Unmarshal([]byte(response.Result.Content[0].Text), &summary); err != nil {
+		t.Fatalf("Failed to decode summary: %v", err)
+	}
+
+	if len(summary.Repositories) != len(repositoryIDs) || summary.Totals["total"] != 3*len(repositoryIDs) {
+		t.Fatalf("Expected three pull requests in each of %d repositories, got %+v", len(repositoryIDs), summary)
+	}
+	for i, repository := range summary.Repositories {
+		if want := "repo-" + repositoryIDs[i]; repository.Name != want {
+			t.Errorf("Expected repository %d to be %s, got %s", i, want, repository.Name)
+		}
+	}
+	if peak < 2 || peak > 5 {
+		t.Errorf("Expected between 2 and 5 repositories counted at once, got %d", peak)
+	}
+}
+
+// TestBacklogMCP_PullRequestSummaryValidatesArguments tests that the summary
+// requires a project and a bounded maxRepositories
+func TestBacklogMCP_PullRequestSummaryValidatesArguments(t *testing.T) {
+	binary := buildServer(t)
+
+	calls := []string{
+		`{"name": "get_project_pull_request_summary", "arguments": {}}`,
+		`{"name": "get_project_pull_request_summary", "arguments": {"projectKey": "DEMO", "maxRepositories": 0}}`,
+		`{"name": "get_project_pull_request_summary", "arguments": {"projectKey": "DEMO", "maxRepositories": 51}}`,
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	want := []string{
+		"either projectId or projectKey is required",
+		"maxRepositories must be between 1 and 50",
+		"maxRepositories must be between 1 and 50",
+	}
+	for i, call := range calls {
+		if got := messages[int64(i+1)]; got != want[i] {
+			t.Errorf("Call %s: expected error %q, got %q", call, want[i], got)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBacklogMCP_GetRateLimitReturnsQuota tests that get_rate_limit calls
+// /rateLimit and returns the remaining quota and reset time per category
+func TestBacklogMCP_GetRateLimitReturnsQuota(t *testing.T) {
+	binary := buildServer(t)
+
+	var requestedPath string
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rateLimit": {
+			"read": {"limit": 600, "remaining": 12, "reset": 1700000060},
+			"update": {"limit": 150, "remaining": 150, "reset": 1700000060},
+			"search": {"limit": 150, "remaining": 149, "reset": 1700000060},
+			"icon": {"limit": 60, "remaining": 60, "reset": 1700000060}
+		}}`))
+	}))
+	defer backlog.Close()
+
+	request := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_rate_limit", "arguments": {}}}`
+	line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+
+	var response struct {
+		Result *struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.Result == nil || len(response.Result.Content) == 0 {
+		t.Fatalf("Expected a tool result, got %q (%v)", line, err)
+	}
+	if requestedPath != "/rateLimit" {
+		t.Errorf("Expected request to /rateLimit, got %s", requestedPath)
+	}
+
+	var quota struct {
+		RateLimit map[string]struct {
+			Limit     int   `json:"limit"`
+			Remaining int   `json:"remaining"`
+			Reset     int64 `json:"reset"`
+		} `json:"rateLimit"`
+	}
+	if err := json.Unmarshal([]byte(response.Result.Content[0].Text), &quota); err != nil {
+		t.Fatalf("Failed to decode rate limit: %v", err)
+	}
+	read, ok := quota.RateLimit["read"]
+	if !ok || read.Remaining != 12 || read.Limit != 600 || read.Reset != 1700000060 {
+		t.Errorf("Expected the read quota to be returned, got %+v", quota.RateLimit)
+	}
+	if len(quota.RateLimit) != 4 {
+		t.Errorf("Expected all four quota categories, got %d", len(quota.RateLimit))
+	}
+}
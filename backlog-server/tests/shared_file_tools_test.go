@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBacklogMCP_DownloadSharedFileReturnsBase64 tests that a binary shared
+// file response is returned base64-encoded with its content type and name
+func TestBacklogMCP_DownloadSharedFileReturnsBase64(t *testing.T) {
+	binary := buildServer(t)
+
+	content := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0xff}
+	var requestedPath string
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Disposition", `attachment; filename="logo.png"`)
+		w.Write(content)
+	}))
+	defer backlog.Close()
+
+	request := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "download_shared_file", "arguments": {"projectKey": "PRJ", "sharedFileId": 7}}}`
+	line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+
+	var response struct {
+		Result *struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil {
+		t.Fatalf("Failed to decode response %q: %v", line, err)
+	}
+	if response.Error != nil || response.Result == nil || len(response.Result.Content) == 0 {
+		t.Fatalf("Expected a tool result, got %q", line)
+	}
+	if requestedPath != "/projects/PRJ/files/7" {
+		t.Errorf("Expected request to /projects/PRJ/files/7, got %s", requestedPath)
+	}
+
+	var file struct {
+		ContentType string `json:"contentType"`
+		Filename    string `json:"filename"`
+		Size        int    `json:"size"`
+		Content     string `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(response.Result.Content[0].Text), &file); err != nil {
+		t.Fatalf("Failed to decode file result: %v", err)
+	}
+	if file.ContentType != "image/png" || file.Filename != "logo.png" || file.Size != len(content) {
+		t.Errorf("Unexpected file metadata: %+v", file)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil || !bytes.Equal(decoded, content) {
+		t.Errorf("Expected the original bytes after base64 decoding, got %v (%v)", decoded, err)
+	}
+}
+
+// TestBacklogMCP_SharedFileToolValidation tests that the shared file tools
+// reject missing arguments before calling Backlog
+func TestBacklogMCP_SharedFileToolValidation(t *testing.T) {
+	binary := buildServer(t)
+
+	messages := toolCallErrors(t, binary, []string{
+		`{"name": "get_shared_files", "arguments": {"path": "docs"}}`,
+		`{"name": "download_shared_file", "arguments": {"sharedFileId": 7}}`,
+		`{"name": "download_shared_file", "arguments": {"projectKey": "PRJ"}}`,
+	})
+
+	expected := []string{
+		"either projectId or projectKey is required",
+		"either projectId or projectKey is required",
+		"sharedFileId is required",
+	}
+	for i, want := range expected {
+		if got := messages[int64(i+1)]; got != want {
+			t.Errorf("Call %d: expected error %q, got %q", i+1, want, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestBacklogMCP_SpaceUsageToolsCallEndpoints tests that get_disk_usage and
+// get_licence request their space endpoints and return the response
+func TestBacklogMCP_SpaceUsageToolsCallEndpoints(t *testing.T) {
+	binary := buildServer(t)
+
+	cases := []struct {
+		tool string
+		path string
+	}{
+		{"get_disk_usage", "/space/diskUsage"},
+		{"get_licence", "/space/licence"},
+	}
+
+	for _, tc := range cases {
+		var requestedPath string
+		backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestedPath = r.URL.Path
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"capacity": 1073741824}`))
+		}))
+
+		request := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": %q, "arguments": {}}}`, tc.tool)
+		line := runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+		backlog.Close()
+
+		if requestedPath != tc.path {
+			t.Errorf("%s: expected request to %s, got %s", tc.tool, tc.path, requestedPath)
+		}
+		if !strings.Contains(line, `1073741824`) {
+			t.Errorf("%s: expected the response to be returned, got %q", tc.tool, line)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestBacklogMCP_AddStarRequiresExactlyOneTarget tests that add_star rejects
+// calls naming no target or more than one target
+func TestBacklogMCP_AddStarRequiresExactlyOneTarget(t *testing.T) {
+	binary := buildServer(t)
+
+	const exactlyOne = "exactly one of issueId, commentId, wikiId, pullRequestId is required"
+	cases := []struct {
+		call     string
+		expected string
+	}{
+		{`{"name": "add_star", "arguments": {}}`, exactlyOne},
+		{`{"name": "add_star", "arguments": {"issueId": 1, "commentId": 2}}`, exactlyOne},
+		{`{"name": "add_star", "arguments": {"wikiId": 3, "pullRequestId": 4, "issueId": 5}}`, exactlyOne},
+		{`{"name": "add_star", "arguments": {"issueId": "TEST-1"}}`, "issueId must be a number"},
+	}
+
+	calls := make([]string, len(cases))
+	for i, tc := range cases {
+		calls[i] = tc.call
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	for i, tc := range cases {
+		if got := messages[int64(i+1)]; got != tc.expected {
+			t.Errorf("Call %s: expected error %q, got %q", tc.call, tc.expected, got)
+		}
+	}
+}
+
+// TestBacklogMCP_AddStarPostsTarget tests that add_star posts only the given
+// target to the stars endpoint
+func TestBacklogMCP_AddStarPostsTarget(t *testing.T) {
+	binary := buildServer(t)
+
+	for _, target := range []string{"issueId", "commentId", "wikiId", "pullRequestId"} {
+		backlog, requests := newRecordingBacklog(t, "")
+		callTool(t, binary, backlog.URL, "add_star", fmt.Sprintf(`{%q: 27}`, target))
+
+		received := requests()
+		if len(received) != 1 || received[0].Method != http.MethodPost || received[0].Path != "/stars" {
+			t.Fatalf("%s: expected POST /stars, got %+v", target, received)
+		}
+		if want := (url.Values{target: {"27"}}); !reflect.DeepEqual(received[0].Form, want) {
+			t.Errorf("%s: expected form %v, got %v", target, want, received[0].Form)
+		}
+	}
+}
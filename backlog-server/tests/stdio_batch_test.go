@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	buildOnce   sync.Once
+	buildDir    string
+	buildBinary string
+	buildErr    error
+)
+
+// TestMain removes the server binary shared by the tests once they have run
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if buildDir != "" {
+		os.RemoveAll(buildDir)
+	}
+	os.Exit(code)
+}
+
+// buildServer compiles the Backlog MCP server once per test run and returns
+// the path of the binary
+func buildServer(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	buildOnce.Do(func() {
+		if buildDir, buildErr = os.MkdirTemp("", "backlog-mcp-server"); buildErr != nil {
+			return
+		}
+		buildBinary = filepath.Join(buildDir, "backlog-mcp-server")
+		if output, err := exec.Command("go", "build", "-o", buildBinary, "..").CombinedOutput(); err != nil {
+			buildErr = fmt.Errorf("%v\n%s", err, output)
+		}
+	})
+	if buildErr != nil {
+		t.Fatalf("Failed to build server: %v", buildErr)
+	}
+	return buildBinary
+}
+
+// runStdio sends one line to the server in stdio mode and returns the first
+// response line. Extra environment variables are given as KEY=value pairs.
+func runStdio(t *testing.T, binary, input string, env ...string) string {
+	t.Helper()
+	cmd := exec.Command(binary)
+	cmd.Env = append(append(os.Environ(), "BACKLOG_DOMAIN=example.backlog.com"), env...)
+	cmd.Stdin = strings.NewReader(input + "\n")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("Failed to open stdout: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer cmd.Wait()
+
+	lines := make(chan string, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		if scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(30 * time.Second):
+		cmd.Process.Kill()
+		t.Fatal("Timed out waiting for a response")
+		return ""
+	}
+}
+
+// TestBacklogMCP_StdioBatchRequest tests that a JSON-RPC batch array receives
+// a batch response with one element per request
+func TestBacklogMCP_StdioBatchRequest(t *testing.T) {
+	binary := buildServer(t)
+
+	batch := `[{"jsonrpc": "2.0", "id": 1, "method": "initialize"}, {"jsonrpc": "2.0", "id": 2, "method": "tools/list"}]`
+	line := runStdio(t, binary, batch)
+
+	var responses []struct {
+		ID     *int64          `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(line), &responses); err != nil {
+		t.Fatalf("Expected a batch response array, got %q: %v", line, err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("Expected 2 responses, got %d", len(responses))
+	}
+	for i, response := range responses {
+		if response.ID == nil || *response.ID != int64(i+1) {
+			t.Errorf("Expected response %d to have id %d, got %v", i, i+1, response.ID)
+		}
+		if response.Error != nil || len(response.Result) == 0 {
+			t.Errorf("Expected a successful result for response %d, got error %+v", i, response.Error)
+		}
+	}
+}
+
+// TestBacklogMCP_StdioEmptyBatch tests that an empty batch gets a single Invalid Request error
+func TestBacklogMCP_StdioEmptyBatch(t *testing.T) {
+	binary := buildServer(t)
+
+	var response struct {
+		Error *struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	line := runStdio(t, binary, "[]")
+	if err := json.Unmarshal([]byte(line), &response); err != nil {
+		t.Fatalf("Expected a single error response, got %q: %v", line, err)
+	}
+	if response.Error == nil || response.Error.Code != -32600 {
+		t.Errorf("Expected Invalid Request error, got %q", line)
+	}
+}
+
+// TestBacklogMCP_StdioBatchSkipsNotifications tests that notifications in a
+// batch get no response and that a batch of only notifications writes nothing
+func TestBacklogMCP_StdioBatchSkipsNotifications(t *testing.T) {
+	binary := buildServer(t)
+
+	mixed := `[{"jsonrpc": "2.0", "method": "notifications/initialized"}, {"jsonrpc": "2.0", "id": 1, "method": "tools/list"}]`
+	var responses []struct {
+		ID *int64 `json:"id"`
+	}
+	line := runStdio(t, binary, mixed)
+	if err := json.Unmarshal([]byte(line), &responses); err != nil {
+		t.Fatalf("Expected a batch response array, got %q: %v", line, err)
+	}
+	if len(responses) != 1 || responses[0].ID == nil || *responses[0].ID != 1 {
+		t.Errorf("Expected only the response to request 1, got %q", line)
+	}
+
+	// Nothing is written for the notifications, so the first line answers the
+	// request that follows them
+	notifications := `[{"jsonrpc": "2.0", "method": "notifications/initialized"}, {"jsonrpc": "2.0", "method": "notifications/initialized"}]`
+	line = runStdio(t, binary, notifications+"\n"+`{"jsonrpc": "2.0", "id": 2, "method": "initialize"}`)
+	var response struct {
+		ID *int64 `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.ID == nil || *response.ID != 2 {
+		t.Errorf("Expected no output for the notifications before the response to request 2, got %q", line)
+	}
+}
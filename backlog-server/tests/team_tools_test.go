@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestBacklogMCP_GetTeamRequiresTeamId tests that get_team rejects calls
+// without a numeric teamId
+func TestBacklogMCP_GetTeamRequiresTeamId(t *testing.T) {
+	binary := buildServer(t)
+
+	calls := []string{
+		`{"name": "get_team", "arguments": {}}`,
+		`{"name": "get_team", "arguments": {"teamId": "developers"}}`,
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	for i, call := range calls {
+		if got := messages[int64(i+1)]; got != "teamId is required" {
+			t.Errorf("Call %s: expected error %q, got %q", call, "teamId is required", got)
+		}
+	}
+}
+
+// TestBacklogMCP_TeamToolsCallBacklog tests that get_teams passes its paging
+// parameters to the teams endpoint, that get_team reads the given team, and
+// that both return Backlog's teams
+func TestBacklogMCP_TeamToolsCallBacklog(t *testing.T) {
+	binary := buildServer(t)
+	team := `{"id": 3, "name": "Developers", "members": [{"id": 1, "userId": "alice", "name": "Alice"}]}`
+
+	backlog, requests := newRecordingBacklog(t, "["+team+"]")
+	result := callTool(t, binary, backlog.URL, "get_teams", `{"order": "asc", "offset": 20, "count": 10}`)
+	received := requests()
+	if len(received) != 1 || received[0].Method != http.MethodGet || received[0].Path != "/teams" {
+		t.Fatalf("Expected GET /teams, got %+v", received)
+	}
+	query := received[0].Query
+	query.Del("apiKey")
+	if want := (url.Values{"order": {"asc"}, "offset": {"20"}, "count": {"10"}}); !reflect.DeepEqual(query, want) {
+		t.Errorf("Expected query %v, got %v", want, query)
+	}
+	assertSameJSON(t, result, "["+team+"]")
+
+	backlog, requests = newRecordingBacklog(t, team)
+	result = callTool(t, binary, backlog.URL, "get_team", `{"teamId": 3}`)
+	received = requests()
+	if len(received) != 1 || received[0].Method != http.MethodGet || received[0].Path != "/teams/3" {
+		t.Fatalf("Expected GET /teams/3, got %+v", received)
+	}
+	assertSameJSON(t, result, team)
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestBacklogMCP_VersionToolValidation tests that the version (milestone)
+// management tools reject missing or malformed arguments before calling Backlog
+func TestBacklogMCP_VersionToolValidation(t *testing.T) {
+	binary := buildServer(t)
+
+	cases := []struct {
+		call     string
+		expected string
+	}{
+		{`{"name": "get_versions", "arguments": {}}`, "either projectId or projectKey is required"},
+		{`{"name": "add_version", "arguments": {"name": "v1.0"}}`, "either projectId or projectKey is required"},
+		{`{"name": "add_version", "arguments": {"projectKey": "PRJ"}}`, "name is required"},
+		{`{"name": "add_version", "arguments": {"projectKey": "PRJ", "name": "v1.0", "startDate": "2024/04/01"}}`, "startDate must be a date in yyyy-MM-dd format"},
+		{`{"name": "add_version", "arguments": {"projectId": 1, "name": "v1.0", "startDate": "2024-05-01", "releaseDueDate": "2024-04-30"}}`, "releaseDueDate must not be before startDate"},
+		{`{"name": "update_version", "arguments": {"id": 3, "name": "v1.0"}}`, "either projectId or projectKey is required"},
+		{`{"name": "update_version", "arguments": {"projectKey": "PRJ", "name": "v1.0"}}`, "id is required"},
+		{`{"name": "update_version", "arguments": {"projectKey": "PRJ", "id": 3}}`, "name is required"},
+		{`{"name": "update_version", "arguments": {"projectKey": "PRJ", "id": 3, "name": "v1.0", "archived": "yes"}}`, "archived must be a boolean"},
+		{`{"name": "delete_version", "arguments": {"id": 3}}`, "either projectId or projectKey is required"},
+		{`{"name": "delete_version", "arguments": {"projectKey": "PRJ"}}`, "id is required"},
+	}
+
+	calls := make([]string, len(cases))
+	for i, tc := range cases {
+		calls[i] = tc.call
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	for i, tc := range cases {
+		if got := messages[int64(i+1)]; !strings.HasPrefix(got, tc.expected) {
+			t.Errorf("Call %s: expected error %q, got %q", tc.call, tc.expected, got)
+		}
+	}
+}
+
+// TestBacklogMCP_VersionToolsCallBacklog tests that the version (milestone)
+// management tools resolve the project, send the version fields to its version
+// endpoints, and return Backlog's version
+func TestBacklogMCP_VersionToolsCallBacklog(t *testing.T) {
+	binary := buildServer(t)
+	version := `{"id": 3, "projectId": 12, "name": "v1.0", "startDate": "2026-10-01T00:00:00Z", "releaseDueDate": "2026-10-31T00:00:00Z", "archived": false}`
+
+	cases := []struct {
+		tool, arguments, method, path string
+		form                          url.Values
+	}{
+		{"add_version", `{"projectKey": "PRJ", "name": "v1.0", "description": "First release", "startDate": "2026-10-01", "releaseDueDate": "2026-10-31"}`,
+			http.MethodPost, "/projects/PRJ/versions", url.Values{
+				"name": {"v1.0"}, "description": {"First release"}, "startDate": {"2026-10-01"}, "releaseDueDate": {"2026-10-31"},
+			}},
+		{"update_version", `{"projectId": 12, "id": 3, "name": "v1.0", "archived": true}`,
+			http.MethodPatch, "/projects/12/versions/3", url.Values{"name": {"v1.0"}, "archived": {"true"}}},
+		{"delete_version", `{"projectKey": "PRJ", "id": 3}`,
+			http.MethodDelete, "/projects/PRJ/versions/3", url.Values{}},
+	}
+	for _, tc := range cases {
+		backlog, requests := newRecordingBacklog(t, version)
+		result := callTool(t, binary, backlog.URL, tc.tool, tc.arguments)
+
+		received := requests()
+		if len(received) != 1 || received[0].Method != tc.method || received[0].Path != tc.path {
+			t.Fatalf("%s: expected %s %s, got %+v", tc.tool, tc.method, tc.path, received)
+		}
+		if !reflect.DeepEqual(received[0].Form, tc.form) {
+			t.Errorf("%s: expected form %v, got %v", tc.tool, tc.form, received[0].Form)
+		}
+		assertSameJSON(t, result, version)
+	}
+}
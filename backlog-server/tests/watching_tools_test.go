@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestBacklogMCP_WatchingToolValidation tests that the watch and unwatch tools
+// reject missing arguments before calling Backlog
+func TestBacklogMCP_WatchingToolValidation(t *testing.T) {
+	binary := buildServer(t)
+
+	cases := []struct {
+		call     string
+		expected string
+	}{
+		{`{"name": "add_watching", "arguments": {}}`, "either issueId or issueIdOrKey is required"},
+		{`{"name": "add_watching", "arguments": {"issueIdOrKey": "", "note": "keep an eye on this"}}`, "either issueId or issueIdOrKey is required"},
+		{`{"name": "delete_watching", "arguments": {}}`, "watchingId is required"},
+		{`{"name": "delete_watching", "arguments": {"watchingId": "12"}}`, "watchingId is required"},
+	}
+
+	calls := make([]string, len(cases))
+	for i, tc := range cases {
+		calls[i] = tc.call
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	for i, tc := range cases {
+		if got := messages[int64(i+1)]; !strings.HasPrefix(got, tc.expected) {
+			t.Errorf("Call %s: expected error %q, got %q", tc.call, tc.expected, got)
+		}
+	}
+}
+
+// TestBacklogMCP_WatchingListReadsGivenUser tests that get_watching_list_items
+// reads the watch list of the given userId, passing paging parameters through,
+// and the authenticated user's list when userId is omitted
+func TestBacklogMCP_WatchingListReadsGivenUser(t *testing.T) {
+	binary := buildServer(t)
+
+	var paths []string
+	var queries []url.Values
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": 1, "type": "issue", "issue": {"id": 10, "projectId": 100, "issueKey": "DEMO-1"}}]`))
+	}))
+	defer backlog.Close()
+
+	batch := "[" + strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_watching_list_items", "arguments": {"userId": 7, "count": 100, "offset": 200}}}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "get_watching_list_items", "arguments": {}}}`,
+	}, ",") + "]"
+	output := runStdio(t, binary, batch, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+	if !strings.Contains(output, "DEMO-1") {
+		t.Errorf("Expected the watch list in the tool result, got %q", output)
+	}
+
+	if len(paths) != 2 || paths[0] != "/users/7/watchings" || paths[1] != "/users/myself/watchings" {
+		t.Fatalf("Expected the given user's and then the authenticated user's watch list, got %v", paths)
+	}
+	if queries[0].Get("count") != "100" || queries[0].Get("offset") != "200" || queries[0].Has("userId") {
+		t.Errorf("Expected count and offset without userId in the query, got %v", queries[0])
+	}
+}
+
+// TestBacklogMCP_WatchingToolsCallBacklog tests that add_watching posts the
+// issue and note to the watchings endpoint, that delete_watching deletes the
+// given watch, and that both return Backlog's watch
+func TestBacklogMCP_WatchingToolsCallBacklog(t *testing.T) {
+	binary := buildServer(t)
+	watching := `{"id": 41, "resourceAlreadyRead": false, "note": "keep an eye on this", "type": "issue", "issue": {"id": 10, "issueKey": "DEMO-1"}}`
+
+	cases := []struct {
+		tool, arguments, method, path string
+		form                          url.Values
+	}{
+		{"add_watching", `{"issueIdOrKey": "DEMO-1", "note": "keep an eye on this"}`,
+			http.MethodPost, "/watchings", url.Values{"issueIdOrKey": {"DEMO-1"}, "note": {"keep an eye on this"}}},
+		{"add_watching", `{"issueId": 10}`,
+			http.MethodPost, "/watchings", url.Values{"issueIdOrKey": {"10"}}},
+		{"delete_watching", `{"watchingId": 41}`,
+			http.MethodDelete, "/watchings/41", url.Values{}},
+	}
+	for _, tc := range cases {
+		backlog, requests := newRecordingBacklog(t, watching)
+		result := callTool(t, binary, backlog.URL, tc.tool, tc.arguments)
+
+		received := requests()
+		if len(received) != 1 || received[0].Method != tc.method || received[0].Path != tc.path {
+			t.Fatalf("%s: expected %s %s, got %+v", tc.tool, tc.method, tc.path, received)
+		}
+		if !reflect.DeepEqual(received[0].Form, tc.form) {
+			t.Errorf("%s: expected form %v, got %v", tc.tool, tc.form, received[0].Form)
+		}
+		assertSameJSON(t, result, watching)
+	}
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestBacklogMCP_WebhookToolsValidation tests that the webhook tools reject
+// calls missing the project, required fields, or activity types
+func TestBacklogMCP_WebhookToolsValidation(t *testing.T) {
+	binary := buildServer(t)
+
+	cases := []struct {
+		call     string
+		expected string
+	}{
+		{`{"name": "get_webhooks", "arguments": {}}`, "either projectId or projectKey is required"},
+		{`{"name": "add_webhook", "arguments": {"projectKey": "PRJ", "hookUrl": "https://example.com/hook", "activityTypeIds": [1]}}`, "name is required"},
+		{`{"name": "add_webhook", "arguments": {"projectKey": "PRJ", "name": "CI", "activityTypeIds": [1]}}`, "hookUrl is required"},
+		{`{"name": "add_webhook", "arguments": {"projectKey": "PRJ", "name": "CI", "hookUrl": "ftp://example.com", "activityTypeIds": [1]}}`, "hookUrl must be an http or https URL"},
+		{`{"name": "add_webhook", "arguments": {"projectKey": "PRJ", "name": "CI", "hookUrl": "https://example.com/hook"}}`, "activityTypeIds is required unless allEvent is true"},
+		{`{"name": "add_webhook", "arguments": {"projectKey": "PRJ", "name": "CI", "hookUrl": "https://example.com/hook", "activityTypeIds": ["issue"]}}`, "activityTypeIds must be numbers"},
+		{`{"name": "delete_webhook", "arguments": {"projectKey": "PRJ"}}`, "webhookId is required"},
+	}
+
+	calls := make([]string, len(cases))
+	for i, tc := range cases {
+		calls[i] = tc.call
+	}
+	messages := toolCallErrors(t, binary, calls)
+
+	for i, tc := range cases {
+		if got := messages[int64(i+1)]; got != tc.expected {
+			t.Errorf("Call %s: expected error %q, got %q", tc.call, tc.expected, got)
+		}
+	}
+}
+
+// TestBacklogMCP_AddWebhookEncodesActivityTypes tests that add_webhook posts
+// activity type IDs with the same indexed array encoding as other form bodies
+func TestBacklogMCP_AddWebhookEncodesActivityTypes(t *testing.T) {
+	binary := buildServer(t)
+
+	var path string
+	var form url.Values
+	backlog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 3, "name": "CI"}`))
+	}))
+	defer backlog.Close()
+
+	request := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "add_webhook", "arguments": {"projectKey": "PRJ", "name": "CI", "hookUrl": "https://example.com/hook", "activityTypeIds": [1, 2]}}}`
+	runStdio(t, binary, request, "BACKLOG_API_KEY=test-key", "BACKLOG_API_BASE_URL="+backlog.URL)
+
+	if path != "/projects/PRJ/webhooks" {
+		t.Errorf("Expected request to /projects/PRJ/webhooks, got %s", path)
+	}
+	if form.Get("name") != "CI" || form.Get("hookUrl") != "https://example.com/hook" {
+		t.Errorf("Expected name and hookUrl in the form, got %v", form)
+	}
+	if form.Get("activityTypeIds[0]") != "1" || form.Get("activityTypeIds[1]") != "2" {
+		t.Errorf("Expected indexed activityTypeIds in the form, got %v", form)
+	}
+}
+
+// TestBacklogMCP_WebhookToolsCallBacklog tests that get_webhooks reads and
+// delete_webhook deletes the project's webhooks, returning Backlog's webhooks
+func TestBacklogMCP_WebhookToolsCallBacklog(t *testing.T) {
+	binary := buildServer(t)
+	webhook := `{"id": 3, "name": "CI", "hookUrl": "https://example.com/hook", "allEvent": false, "activityTypeIds": [1, 2]}`
+
+	cases := []struct {
+		tool, arguments, response, method, path string
+	}{
+		{"get_webhooks", `{"projectKey": "PRJ"}`, "[" + webhook + "]", http.MethodGet, "/projects/PRJ/webhooks"},
+		{"get_webhooks", `{"projectId": 12}`, "[" + webhook + "]", http.MethodGet, "/projects/12/webhooks"},
+		{"delete_webhook", `{"projectKey": "PRJ", "webhookId": 3}`, webhook, http.MethodDelete, "/projects/PRJ/webhooks/3"},
+	}
+	for _, tc := range cases {
+		backlog, requests := newRecordingBacklog(t, tc.response)
+		result := callTool(t, binary, backlog.URL, tc.tool, tc.arguments)
+
+		received := requests()
+		if len(received) != 1 || received[0].Method != tc.method || received[0].Path != tc.path {
+			t.Fatalf("%s: expected %s %s, got %+v", tc.tool, tc.method, tc.path, received)
+		}
+		if !reflect.DeepEqual(received[0].Form, url.Values{}) {
+			t.Errorf("%s: expected no form, got %v", tc.tool, received[0].Form)
+		}
+		assertSameJSON(t, result, tc.response)
+	}
+}
@@ -55,6 +55,9 @@ export interface Project {
  * - `notifications`: Communication efficiency, alert patterns, and engagement metrics
  * - `predictive_analysis`: Forecasts, trend analysis, and future planning insights
  * - `summary_plan`: Project summary, lessons learned, and next steps
+ * - `period_comparison`: Issue statistics for this week compared with last week
+ * - `space_overview`: Users, projects, disk usage, and licence of the whole Backlog space
+ * - `portfolio_comparison`: Progress of several projects side by side
  * 
  * @example
  * ```typescript
@@ -76,6 +79,9 @@ export type SlideTheme =
   | 'notifications'
   | 'predictive_analysis'
   | 'summary_plan'
+  | 'period_comparison'
+  | 'space_overview'
+  | 'portfolio_comparison'
 
 /**
  * Re-export all authentication-related types from the auth module.
@@ -24,6 +24,9 @@ export type SlideTheme =
   | 'notifications'         // Communication efficiency and flow
   | 'predictive_analysis'   // Forecasts and trend analysis
   | 'summary_plan'          // Project summary and future planning
+  | 'period_comparison'     // Week-over-week issue statistics
+  | 'space_overview'        // Space-wide users, projects, disk usage, and licence
+  | 'portfolio_comparison'  // Progress of several projects side by side
 
 /**
  * Request payload for initiating slide generation.
@@ -32,6 +35,7 @@ export type SlideTheme =
  * @property projectId - Backlog project identifier (string or numeric ID)
  * @property themes - Array of slide themes to generate
  * @property language - Target language code ('ja' for Japanese, 'en' for English)
+ * @property projectIds - Further projects compared on portfolio_comparison slides
  * 
  * @example
  * ```typescript
@@ -46,6 +50,7 @@ export interface SlideGenerationRequest {
   projectId: string
   themes: SlideTheme[]
   language: string
+  projectIds?: string[]
 }
 
 /**
@@ -119,6 +124,8 @@ export interface WebSocketMessage {
 export interface ErrorMessage {
   message: string
   code: string
+  status?: number
+  hint?: string  // User-facing suggestion for resolving the error
 }
 
 export interface SlideThemeOption {
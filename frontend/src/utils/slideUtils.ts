@@ -187,7 +187,10 @@ export const getThemeLabel = (theme: string): string => {
     'codebase_activity': 'コードベース活動',     // Development and code metrics
     'notifications': '通知管理',                 // Communication and notification patterns
     'predictive_analysis': '予測分析',           // Forecasting and trend analysis
-    'summary_plan': '総括と計画'                 // Summary and future planning
+    'summary_plan': '総括と計画',                // Summary and future planning
+    'period_comparison': '期間比較',             // Week-over-week comparison
+    'space_overview': 'スペース概要',            // Space-wide users, storage, and licence
+    'portfolio_comparison': 'ポートフォリオ比較' // Several projects side by side
   }
   
   // Return localized label or fall back to original theme identifier
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"speech-mcp-server/internal/handlers"
+	"speech-mcp-server/internal/services"
 	"speech-mcp-server/pkg/config"
 
 	"github.com/gin-contrib/cors"
@@ -41,7 +42,9 @@ import (
 //   1. Loading environment variables and configuration
 //   2. Setting up Gin web framework and CORS middleware
 //   3. Registering API routes and MCP protocol handlers
-//   4. Starting the HTTP server with graceful shutdown support
+//   4. Starting the HTTP server with graceful shutdown support, removing
+//      partially written audio from the cache on exit
+//   5. Warming up configured TTS engines in the background
 //
 // The server listens for SIGINT and SIGTERM signals for clean shutdown.
 func main() {
@@ -99,6 +102,11 @@ func main() {
 		}
 	}()
 
+	// Prime configured TTS engines in the background so startup is not delayed
+	if len(cfg.WarmupEngines) > 0 {
+		go warmUpEngines(cfg)
+	}
+
 	// Wait for interrupt signal to gracefully shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -106,16 +114,38 @@ func main() {
 	log.Println("Shutting down Speech MCP Server...")
 
 	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server forced to shutdown:", err)
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+
+	// Remove audio left partially written by synthesis cut short by shutdown
+	if removed, err := services.CleanPartialCacheFiles(cfg.CacheDir); err != nil {
+		log.Printf("Failed to clean audio cache: %v", err)
+	} else if removed > 0 {
+		log.Printf("Removed %d partial audio files from the cache", removed)
 	}
 
 	log.Println("Speech MCP Server exited")
 }
 
+// warmUpEngines synthesizes a short phrase with each engine listed in
+// WARMUP_ENGINES and logs how each engine responded.
+//
+// Parameters:
+//   - cfg: configuration listing the engines to warm up
+func warmUpEngines(cfg *config.Config) {
+	for _, result := range services.NewTTSService(cfg).WarmUp(cfg.WarmupEngines) {
+		if result.Err != nil {
+			log.Printf("TTS engine %s warm-up failed after %v: %v", result.Engine, result.Duration, result.Err)
+			continue
+		}
+		log.Printf("TTS engine %s warmed up in %v", result.Engine, result.Duration)
+	}
+}
+
 // setupRoutes configures all HTTP routes and endpoints for the Speech MCP Server.
 // It organizes routes into logical groups for API versioning and MCP protocol support.
 //
@@ -132,6 +162,7 @@ func setupRoutes(router *gin.Engine, speechHandler *handlers.SpeechHandler) {
 	v1 := router.Group("/api/v1")
 	{
 		v1.POST("/synthesize", speechHandler.SynthesizeSpeech)
+		v1.POST("/synthesize/batch", speechHandler.SynthesizeBatch)
 		v1.GET("/audio/:filename", speechHandler.ServeAudioFile)
 		v1.GET("/voices", speechHandler.ListVoices)
 		v1.GET("/languages", speechHandler.ListLanguages)
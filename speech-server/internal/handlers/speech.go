@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"speech-mcp-server/internal/models"
@@ -39,6 +40,30 @@ func (h *SpeechHandler) SynthesizeSpeech(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// SynthesizeBatch synthesizes several requests in one call. Per-item failures,
+// such as an unsupported language, are reported in that item's result and the
+// response is still 200 so the successful items can be used. Batches of more
+// than MaxBatchItems items are rejected with 400.
+func (h *SpeechHandler) SynthesizeBatch(c *gin.Context) {
+	var req models.SpeechBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+	maxItems := h.config.MaxBatchItems
+	if maxItems <= 0 {
+		maxItems = config.DefaultMaxBatchItems
+	}
+	if len(req.Items) > maxItems {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("A batch may contain at most %d items, got %d", maxItems, len(req.Items)),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.ttsService.SynthesizeBatch(req.Items))
+}
+
 func (h *SpeechHandler) ServeAudioFile(c *gin.Context) {
 	filename := c.Param("filename")
 	c.File(h.config.CacheDir + "/" + filename)
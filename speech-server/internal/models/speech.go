@@ -22,10 +22,33 @@ type SpeechResponse struct {
 	Duration  time.Duration `json:"duration"`  // Estimated duration of the audio
 	Language  string        `json:"language"`  // Language used for synthesis
 	Voice     string        `json:"voice"`     // Voice used for synthesis
+	Engine    string        `json:"engine,omitempty"` // TTS engine that synthesized the audio (empty on cache hits)
 	CacheHit  bool          `json:"cacheHit"`  // Whether audio was served from cache
 	RequestID string        `json:"requestId"` // Unique identifier for this request
 }
 
+// SpeechBatchRequest represents several synthesis requests submitted together,
+// typically the same narration in multiple languages.
+type SpeechBatchRequest struct {
+	Items []SpeechRequest `json:"items" binding:"required"` // Requests to synthesize, in order
+}
+
+// SpeechBatchResult reports the outcome of one item in a batch. Exactly one of
+// Result and Error is set, so a failing item never hides the others.
+type SpeechBatchResult struct {
+	Index    int             `json:"index"`            // Position of the item in the request
+	Language string          `json:"language"`         // Language requested for this item
+	Result   *SpeechResponse `json:"result,omitempty"` // Synthesis result when the item succeeded
+	Error    string          `json:"error,omitempty"`  // Error message when the item failed
+}
+
+// SpeechBatchResponse represents the per-item results of a batch synthesis.
+type SpeechBatchResponse struct {
+	Results   []SpeechBatchResult `json:"results"`   // One result per requested item, in order
+	Succeeded int                 `json:"succeeded"` // Number of items synthesized successfully
+	Failed    int                 `json:"failed"`    // Number of items that returned an error
+}
+
 // MCPRequest represents an MCP JSON-RPC request for speech operations.
 // It follows the JSON-RPC 2.0 specification with MCP-specific extensions
 // for speech synthesis tool calls and protocol methods.
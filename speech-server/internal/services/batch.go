@@ -0,0 +1,50 @@
+package services
+
+import (
+	"fmt"
+
+	"speech-mcp-server/internal/models"
+)
+
+// IsLanguageSupported reports whether code is one of the languages returned
+// by GetSupportedLanguages.
+func (s *TTSService) IsLanguageSupported(code string) bool {
+	for _, language := range s.GetSupportedLanguages() {
+		if language.Code == code && language.Supported {
+			return true
+		}
+	}
+	return false
+}
+
+// SynthesizeBatch synthesizes each request independently and reports a result
+// or an error per item, so one unsupported language or engine failure does not
+// fail the rest of the batch. Unsupported languages are rejected before any
+// engine is called.
+func (s *TTSService) SynthesizeBatch(reqs []models.SpeechRequest) *models.SpeechBatchResponse {
+	response := &models.SpeechBatchResponse{Results: make([]models.SpeechBatchResult, 0, len(reqs))}
+	for i, req := range reqs {
+		result := models.SpeechBatchResult{Index: i, Language: req.Language}
+		switch {
+		case req.Text == "":
+			result.Error = "text is required"
+		case !s.IsLanguageSupported(req.Language):
+			result.Error = fmt.Sprintf("language '%s' is not supported", req.Language)
+		default:
+			resp, err := s.SynthesizeSpeech(req)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Result = resp
+			}
+		}
+
+		if result.Error != "" {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// partialSuffix marks audio that is still being written. Synthesis writes to
+// a partial file and renames it into place only once the audio is complete,
+// so a cache hit never sees a truncated file.
+const partialSuffix = ".partial"
+
+// CleanPartialCacheFiles removes partial and zero-byte files from the audio
+// cache, such as those left behind when the server stops mid-synthesis.
+//
+// Parameters:
+//   - dir: Cache directory to clean
+//
+// Returns the number of files removed and the first error encountered.
+func CleanPartialCacheFiles(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	removed := 0
+	var firstErr error
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if !strings.HasSuffix(entry.Name(), partialSuffix) && info.Size() > 0 {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+			}
+			continue
+		}
+		removed++
+	}
+	return removed, firstErr
+}
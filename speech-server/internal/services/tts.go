@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -60,18 +61,20 @@ func NewTTSService(cfg *config.Config) *TTSService {
 //   - *models.SpeechResponse: Complete response with audio URL and metadata
 //   - error: Any error that occurred during synthesis
 func (s *TTSService) SynthesizeSpeech(req models.SpeechRequest) (*models.SpeechResponse, error) {
-	// Generate cache key based on text, language, and voice
-	cacheKey := s.generateCacheKey(req.Text, req.Language, req.Voice)
+	// Generate cache key based on text, language, voice, and speed
+	cacheKey := s.generateCacheKey(req.Text, req.Language, req.Voice, req.Speed)
 	
 	// Check if audio file already exists in cache
 	audioFile := filepath.Join(s.config.CacheDir, cacheKey+"."+s.config.AudioFormat)
 	
 	var cacheHit bool
+	var engine string
 	if _, err := os.Stat(audioFile); err == nil {
 		cacheHit = true
 	} else {
 		// Generate audio file
-		if err := s.generateAudioFile(req, audioFile); err != nil {
+		engine, err = s.generateAudioFile(req, audioFile)
+		if err != nil {
 			return nil, fmt.Errorf("failed to generate audio: %w", err)
 		}
 		cacheHit = false
@@ -79,42 +82,122 @@ func (s *TTSService) SynthesizeSpeech(req models.SpeechRequest) (*models.SpeechR
 	
 	// Generate audio URL
 	audioURL := fmt.Sprintf("/cache/%s.%s", cacheKey, s.config.AudioFormat)
+
+	duration := s.estimateDuration(req.Text)
+	if s.audioPadded(audioFile) {
+		duration += s.config.LeadingSilence + s.config.TrailingSilence
+	}
 	
 	return &models.SpeechResponse{
 		AudioURL:  audioURL,
-		Duration:  s.estimateDuration(req.Text),
+		Duration:  duration,
 		Language:  req.Language,
 		Voice:     req.Voice,
+		Engine:    engine,
 		CacheHit:  cacheHit,
 		RequestID: uuid.New().String(),
 	}, nil
 }
 
 // generateCacheKey creates a unique cache key for the TTS request.
-// It uses MD5 hashing of the text, language, and voice parameters
+// It uses MD5 hashing of the text, language, voice, and speed parameters
 // to create a consistent identifier for audio file caching.
 //
 // Parameters:
 //   - text: The text content to be synthesized
 //   - language: The target language code
 //   - voice: The voice identifier or preference
+//   - speed: The requested speed multiplier (0 or 1.0 = normal)
 //
 // Returns a unique hash string suitable for use as a filename.
-func (s *TTSService) generateCacheKey(text, language, voice string) string {
+func (s *TTSService) generateCacheKey(text, language, voice string, speed float32) string {
 	content := fmt.Sprintf("%s:%s:%s", text, language, voice)
+	// Keep existing cache entries valid for normal-speed requests
+	if speed > 0 && speed != 1.0 {
+		content = fmt.Sprintf("%s:%.2f", content, speed)
+	}
+	// Padded audio differs from unpadded audio cached before padding was configured
+	if s.config.LeadingSilence > 0 || s.config.TrailingSilence > 0 {
+		content = fmt.Sprintf("%s:pad=%d/%d", content, s.config.LeadingSilence.Milliseconds(), s.config.TrailingSilence.Milliseconds())
+	}
 	hash := md5.Sum([]byte(content))
 	return fmt.Sprintf("%x", hash)
 }
 
 // generateAudioFile creates the actual audio file using Japanese TTS engines
-func (s *TTSService) generateAudioFile(req models.SpeechRequest, outputPath string) error {
+// and returns the name of the engine that succeeded after any fallback
+func (s *TTSService) generateAudioFile(req models.SpeechRequest, outputPath string) (string, error) {
 	// Ensure cache directory exists
 	if err := os.MkdirAll(s.config.CacheDir, 0755); err != nil {
-		return fmt.Errorf("failed to create cache directory: %w", err)
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
 	}
 	
+	// Write to a partial file and rename it into place only when complete,
+	// so an interrupted or failed synthesis never leaves a truncated cache entry
+	partial, err := os.CreateTemp(s.config.CacheDir, filepath.Base(outputPath)+".*"+partialSuffix)
+	if err != nil {
+		return "", fmt.Errorf("failed to create partial audio file: %w", err)
+	}
+	partialPath := partial.Name()
+	partial.Close()
+	defer os.Remove(partialPath)
+
 	// Use M4-optimized TTS to generate high-quality audio
-	return s.generateM4OptimizedAudio(req, outputPath)
+	engine, err := s.generateM4OptimizedAudio(req, partialPath)
+	if err != nil {
+		return "", err
+	}
+	if err := s.padAudioFile(partialPath); err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(partialPath); err != nil || info.Size() == 0 {
+		return "", fmt.Errorf("%s produced no audio", engine)
+	}
+	// CreateTemp makes the partial file private, but cached audio is served to
+	// other processes such as a reverse proxy
+	if err := os.Chmod(partialPath, 0644); err != nil {
+		return "", fmt.Errorf("failed to set audio file permissions: %w", err)
+	}
+	if err := os.Rename(partialPath, outputPath); err != nil {
+		return "", fmt.Errorf("failed to move audio into the cache: %w", err)
+	}
+	return engine, nil
+}
+
+// padAudioFile adds the configured leading and trailing silence to a
+// synthesized WAV file. Audio in other formats is left unchanged.
+func (s *TTSService) padAudioFile(path string) error {
+	if s.config.LeadingSilence <= 0 && s.config.TrailingSilence <= 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read audio for padding: %w", err)
+	}
+	padded, err := PadWAV(data, s.config.LeadingSilence, s.config.TrailingSilence)
+	if err != nil {
+		fmt.Printf("Skipping silence padding for non-WAV audio %s: %v\n", filepath.Base(path), err)
+		return nil
+	}
+	if err := os.WriteFile(path, padded, 0644); err != nil {
+		return fmt.Errorf("failed to write padded audio: %w", err)
+	}
+	return nil
+}
+
+// audioPadded reports whether the cached audio file carries the configured
+// silence, which padAudioFile adds only to WAV audio
+func (s *TTSService) audioPadded(path string) bool {
+	if s.config.LeadingSilence <= 0 && s.config.TrailingSilence <= 0 {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	_, _, err = parseWAV(data)
+	return err == nil
 }
 
 // estimateDuration estimates speech duration based on text length
@@ -139,6 +222,50 @@ func (s *TTSService) estimateDuration(text string) time.Duration {
 	return time.Duration(seconds * float64(time.Second))
 }
 
+// Speed limits accepted by all supported engines
+const (
+	minEngineSpeed = 0.5
+	maxEngineSpeed = 2.0
+)
+
+// EngineSpeed converts a requested speed multiplier into the value sent to the
+// given engine so that the same request speed sounds equally fast on every
+// engine. The deviation from normal speed (1.0) is scaled by the engine's
+// configured sensitivity and the result is clamped to the engines' range.
+//
+// Parameters:
+//   - engine: The TTS engine name ("voicevox", "kokoro", or "mlx-audio")
+//   - speed: The requested speed multiplier (0 means normal speed)
+//
+// Returns the engine-specific speed value.
+func (s *TTSService) EngineSpeed(engine string, speed float32) float64 {
+	if speed <= 0 {
+		return 1.0
+	}
+
+	sensitivity := 1.0
+	switch engine {
+	case "voicevox":
+		sensitivity = s.config.VoicevoxSpeedSensitivity
+	case "kokoro":
+		sensitivity = s.config.KokoroSpeedSensitivity
+	case "mlx-audio":
+		sensitivity = s.config.MLXSpeedSensitivity
+	}
+	if sensitivity <= 0 {
+		sensitivity = 1.0
+	}
+
+	normalized := 1.0 + (float64(speed)-1.0)*sensitivity
+	if normalized < minEngineSpeed {
+		return minEngineSpeed
+	}
+	if normalized > maxEngineSpeed {
+		return maxEngineSpeed
+	}
+	return normalized
+}
+
 // GetAvailableVoices returns a comprehensive list of available voices from all TTS engines.
 // It includes voices from VOICEVOX (Japanese high-quality), Kokoro TTS (multilingual),
 // and MLX-Audio (Apple Silicon optimized) with detailed metadata for each voice.
@@ -311,7 +438,8 @@ func (s *TTSService) GetSupportedLanguages() []models.LanguageInfo {
 }
 
 // generateM4OptimizedAudio generates high-quality audio with multi-language support for Mac M4
-func (s *TTSService) generateM4OptimizedAudio(req models.SpeechRequest, outputPath string) error {
+// and returns the name of the engine that produced it
+func (s *TTSService) generateM4OptimizedAudio(req models.SpeechRequest, outputPath string) (string, error) {
 	// Get preferred TTS engine from environment
 	preferredEngine := os.Getenv("TTS_ENGINE")
 	
@@ -322,71 +450,86 @@ func (s *TTSService) generateM4OptimizedAudio(req models.SpeechRequest, outputPa
 	case "en", "es", "fr", "hi", "it", "pt", "zh":
 		return s.generateMultilingualAudio(req, outputPath, preferredEngine)
 	default:
-		return fmt.Errorf("language '%s' is not supported. Supported languages: ja, en, es, fr, hi, it, pt, zh", req.Language)
+		return "", fmt.Errorf("language '%s' is not supported. Supported languages: ja, en, es, fr, hi, it, pt, zh", req.Language)
 	}
 }
 
 // generateJapaneseAudio generates Japanese audio using VOICEVOX/Kokoro/MLX-Audio with new priority order
-func (s *TTSService) generateJapaneseAudio(req models.SpeechRequest, outputPath string, preferredEngine string) error {
+func (s *TTSService) generateJapaneseAudio(req models.SpeechRequest, outputPath string, preferredEngine string) (string, error) {
 	// Japanese TTS priority: VOICEVOX (primary) -> Kokoro (secondary) -> MLX-Audio (fallback)
 	switch preferredEngine {
 	case "voicevox":
 		if err := s.generateVoicevoxAudio(req, outputPath); err == nil {
-			return nil
+			return "voicevox", nil
 		} else {
 			fmt.Printf("VOICEVOX TTS failed, trying Kokoro: %v\n", err)
 		}
 		// Fallback to Kokoro
 		if err := s.generateKokoroAudio(req, outputPath); err == nil {
-			return nil
+			return "kokoro", nil
 		} else {
 			fmt.Printf("Kokoro failed, trying MLX-Audio: %v\n", err)
 		}
 		// Final fallback to MLX-Audio
-		return s.generateMLXAudio(req, outputPath)
+		if err := s.generateMLXAudio(req, outputPath); err != nil {
+			return "", err
+		}
+		return "mlx-audio", nil
 	case "kokoro":
 		if err := s.generateKokoroAudio(req, outputPath); err == nil {
-			return nil
+			return "kokoro", nil
 		} else {
 			fmt.Printf("Kokoro TTS failed, trying VOICEVOX: %v\n", err)
 		}
 		// Fallback to VOICEVOX
 		if err := s.generateVoicevoxAudio(req, outputPath); err == nil {
-			return nil
+			return "voicevox", nil
 		} else {
 			fmt.Printf("VOICEVOX failed, trying MLX-Audio: %v\n", err)
 		}
 		// Final fallback to MLX-Audio
-		return s.generateMLXAudio(req, outputPath)
+		if err := s.generateMLXAudio(req, outputPath); err != nil {
+			return "", err
+		}
+		return "mlx-audio", nil
 	case "mlx-audio":
 		if err := s.generateMLXAudio(req, outputPath); err == nil {
-			return nil
+			return "mlx-audio", nil
 		} else {
 			fmt.Printf("MLX-Audio failed, trying VOICEVOX: %v\n", err)
 		}
 		// Fallback to VOICEVOX
 		if err := s.generateVoicevoxAudio(req, outputPath); err == nil {
-			return nil
+			return "voicevox", nil
 		}
 		// Final fallback to Kokoro
-		return s.generateKokoroAudio(req, outputPath)
+		if err := s.generateKokoroAudio(req, outputPath); err != nil {
+			return "", err
+		}
+		return "kokoro", nil
 	default:
 		// Default order for Japanese: VOICEVOX -> Kokoro -> MLX-Audio
 		if err := s.generateVoicevoxAudio(req, outputPath); err == nil {
-			return nil
+			return "voicevox", nil
 		}
 		if err := s.generateKokoroAudio(req, outputPath); err == nil {
-			return nil
+			return "kokoro", nil
+		}
+		if err := s.generateMLXAudio(req, outputPath); err != nil {
+			return "", err
 		}
-		return s.generateMLXAudio(req, outputPath)
+		return "mlx-audio", nil
 	}
 }
 
 // generateMultilingualAudio generates non-Japanese audio using Kokoro TTS
-func (s *TTSService) generateMultilingualAudio(req models.SpeechRequest, outputPath string, preferredEngine string) error {
+func (s *TTSService) generateMultilingualAudio(req models.SpeechRequest, outputPath string, preferredEngine string) (string, error) {
 	// For non-Japanese languages, use Kokoro TTS as primary engine
-	fmt.Printf("Using Kokoro TTS for %s language text: %s\n", req.Language, req.Text[:min(50, len(req.Text))])
-	return s.generateKokoroAudio(req, outputPath)
+	fmt.Printf("Using Kokoro TTS for %s language text: %s\n", req.Language, TruncateText(req.Text, 50))
+	if err := s.generateKokoroAudio(req, outputPath); err != nil {
+		return "", err
+	}
+	return "kokoro", nil
 }
 
 // generateVoicevoxAudio generates high-quality Japanese audio using VOICEVOX Engine
@@ -397,7 +540,7 @@ func (s *TTSService) generateVoicevoxAudio(req models.SpeechRequest, outputPath
 		voicevoxURL = "http://localhost:50021"
 	}
 	
-	fmt.Printf("Using VOICEVOX Engine for Japanese text: %s\n", req.Text[:min(50, len(req.Text))])
+	fmt.Printf("Using VOICEVOX Engine for Japanese text: %s\n", TruncateText(req.Text, 50))
 	
 	// Check if VOICEVOX Engine is available
 	client := &http.Client{Timeout: 5 * time.Second}
@@ -441,6 +584,14 @@ func (s *TTSService) generateVoicevoxAudio(req models.SpeechRequest, outputPath
 	if err := json.Unmarshal(queryData, &queryJSON); err != nil {
 		return fmt.Errorf("audio_query response is not valid JSON: %w", err)
 	}
+
+	// Apply the requested speed and configured fields to the query before synthesis
+	queryJSON["speedScale"] = s.EngineSpeed("voicevox", req.Speed)
+	s.applyExtraPayload("voicevox", queryJSON)
+	queryData, err = json.Marshal(queryJSON)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audio query: %w", err)
+	}
 	
 	// Step 2: Synthesize audio
 	// POST /synthesis?speaker=<speaker_id> with the query JSON as body
@@ -465,17 +616,9 @@ func (s *TTSService) generateVoicevoxAudio(req models.SpeechRequest, outputPath
 		return fmt.Errorf("VOICEVOX synthesis returned status %d: %s", synthResp.StatusCode, string(body))
 	}
 	
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-	
-	// Copy audio data to file
-	_, err = io.Copy(file, synthResp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write audio data: %w", err)
+	// Copy audio data to the output file, rejecting oversized output
+	if err := s.writeAudioFile(outputPath, synthResp.Body); err != nil {
+		return err
 	}
 	
 	// Verify the output file was created and has content
@@ -499,7 +642,7 @@ func (s *TTSService) generateMLXAudio(req models.SpeechRequest, outputPath strin
 		mlxURL = "http://localhost:8881"
 	}
 	
-	fmt.Printf("Using MLX-Audio for Japanese text: %s\n", req.Text[:min(50, len(req.Text))])
+	fmt.Printf("Using MLX-Audio for Japanese text: %s\n", TruncateText(req.Text, 50))
 	
 	// Check if MLX-Audio server is available
 	client := &http.Client{Timeout: 5 * time.Second}
@@ -519,8 +662,9 @@ func (s *TTSService) generateMLXAudio(req models.SpeechRequest, outputPath strin
 		"language": req.Language,
 		"voice":    voice,
 		"format":   "wav",
-		"speed":    1.0,
+		"speed":    s.EngineSpeed("mlx-audio", req.Speed),
 	}
+	s.applyExtraPayload("mlx-audio", payload)
 	
 	// Convert payload to JSON
 	jsonData, err := json.Marshal(payload)
@@ -553,17 +697,9 @@ func (s *TTSService) generateMLXAudio(req models.SpeechRequest, outputPath strin
 		return fmt.Errorf("MLX-Audio returned status %d: %s", resp.StatusCode, string(body))
 	}
 	
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-	
-	// Copy audio data to file
-	_, err = io.Copy(file, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write audio data: %w", err)
+	// Copy audio data to the output file, rejecting oversized output
+	if err := s.writeAudioFile(outputPath, resp.Body); err != nil {
+		return err
 	}
 	
 	// Verify the output file was created and has content
@@ -587,7 +723,7 @@ func (s *TTSService) generateKokoroAudio(req models.SpeechRequest, outputPath st
 		kokoroURL = "http://localhost:8882"
 	}
 	
-	fmt.Printf("Using Kokoro TTS for %s text: %s\n", req.Language, req.Text[:min(50, len(req.Text))])
+	fmt.Printf("Using Kokoro TTS for %s text: %s\n", req.Language, TruncateText(req.Text, 50))
 	
 	// Check if Kokoro TTS server is available
 	client := &http.Client{Timeout: 5 * time.Second}
@@ -604,8 +740,9 @@ func (s *TTSService) generateKokoroAudio(req models.SpeechRequest, outputPath st
 		"language": req.Language,
 		"voice":    voice,
 		"format":   "wav",
-		"speed":    1.0,
+		"speed":    s.EngineSpeed("kokoro", req.Speed),
 	}
+	s.applyExtraPayload("kokoro", payload)
 	
 	// Convert payload to JSON
 	jsonData, err := json.Marshal(payload)
@@ -622,9 +759,10 @@ func (s *TTSService) generateKokoroAudio(req models.SpeechRequest, outputPath st
 	
 	// Set headers
 	req_http.Header.Set("Content-Type", "application/json")
-	req_http.Header.Set("Accept", "application/json")
+	req_http.Header.Set("Accept", "application/json, audio/wav")
 	
-	// Send request for TTS metadata
+	// Send request for TTS metadata, or for the audio itself on Kokoro versions
+	// that return it directly
 	client = &http.Client{Timeout: 600 * time.Second}
 	resp, err := client.Do(req_http)
 	if err != nil {
@@ -638,39 +776,35 @@ func (s *TTSService) generateKokoroAudio(req models.SpeechRequest, outputPath st
 		return fmt.Errorf("Kokoro TTS returned status %d: %s", resp.StatusCode, string(body))
 	}
 	
-	// Parse the response to get audio URL
-	var ttsResponse map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&ttsResponse); err != nil {
-		return fmt.Errorf("failed to parse TTS response: %w", err)
-	}
-	
-	audioURL, ok := ttsResponse["audio_url"].(string)
-	if !ok {
-		return fmt.Errorf("audio_url not found in TTS response")
-	}
-	
-	// Download the audio file
-	audioResp, err := client.Get(kokoroURL + audioURL)
-	if err != nil {
-		return fmt.Errorf("failed to download audio file: %w", err)
-	}
-	defer audioResp.Body.Close()
-	
-	if audioResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("audio download returned status %d", audioResp.StatusCode)
-	}
-	
-	// Create output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+	audio := resp.Body
+	if !isAudioContentType(resp.Header.Get("Content-Type")) {
+		// Parse the JSON response to get the audio URL
+		var ttsResponse map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&ttsResponse); err != nil {
+			return fmt.Errorf("failed to parse TTS response: %w", err)
+		}
+		
+		audioURL, ok := ttsResponse["audio_url"].(string)
+		if !ok {
+			return fmt.Errorf("audio_url not found in TTS response")
+		}
+		
+		// Download the audio file
+		audioResp, err := client.Get(kokoroURL + audioURL)
+		if err != nil {
+			return fmt.Errorf("failed to download audio file: %w", err)
+		}
+		defer audioResp.Body.Close()
+		
+		if audioResp.StatusCode != http.StatusOK {
+			return fmt.Errorf("audio download returned status %d", audioResp.StatusCode)
+		}
+		audio = audioResp.Body
 	}
-	defer file.Close()
 	
-	// Copy audio data to file
-	_, err = io.Copy(file, audioResp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write audio data: %w", err)
+	// Copy audio data to the output file, rejecting oversized output
+	if err := s.writeAudioFile(outputPath, audio); err != nil {
+		return err
 	}
 	
 	// Verify the output file was created and has content
@@ -686,10 +820,63 @@ func (s *TTSService) generateKokoroAudio(req models.SpeechRequest, outputPath st
 	return nil
 }
 
-// min returns the minimum of two integers
-func min(a, b int) int {
-	if a < b {
-		return a
+// isAudioContentType reports whether a response Content-Type is audio rather
+// than JSON metadata
+func isAudioContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "audio/") || mediaType == "application/octet-stream"
+}
+
+// applyExtraPayload merges the fields configured for the engine into its
+// request payload, replacing built-in fields of the same name
+func (s *TTSService) applyExtraPayload(engine string, payload map[string]interface{}) {
+	for key, value := range s.config.EngineExtraPayloads[engine] {
+		payload[key] = value
+	}
+}
+
+// writeAudioFile copies engine audio to outputPath. Output larger than the
+// configured MaxAudioBytes is rejected and the partial file is removed, so it is
+// never served from the cache.
+func (s *TTSService) writeAudioFile(outputPath string, audio io.Reader) error {
+	maxBytes := s.config.MaxAudioBytes
+	if maxBytes <= 0 {
+		maxBytes = config.DefaultMaxAudioBytes
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	// Read one byte past the limit to detect oversized output
+	written, err := io.Copy(file, io.LimitReader(audio, maxBytes+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && written > maxBytes {
+		err = fmt.Errorf("audio exceeds the maximum size of %d bytes", maxBytes)
 	}
-	return b
+	if err != nil {
+		os.Remove(outputPath)
+		return fmt.Errorf("failed to write audio data: %w", err)
+	}
+	return nil
+}
+
+// TruncateText shortens text to at most maxRunes characters for logging.
+// It counts runes rather than bytes so multibyte text such as Japanese is
+// never cut in the middle of a character.
+func TruncateText(text string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	return string(runes[:maxRunes])
 }
\ No newline at end of file
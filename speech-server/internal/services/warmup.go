@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"speech-mcp-server/internal/models"
+)
+
+// warmupRequests holds the short phrase each engine synthesizes during warm-up
+var warmupRequests = map[string]models.SpeechRequest{
+	"voicevox":  {Text: "こんにちは", Language: "ja"},
+	"kokoro":    {Text: "Hello", Language: "en"},
+	"mlx-audio": {Text: "こんにちは", Language: "ja"},
+}
+
+// WarmupResult reports the outcome of priming one TTS engine
+type WarmupResult struct {
+	Engine   string        // Engine name as configured
+	Duration time.Duration // Time taken by the warm-up synthesis
+	Err      error         // Failure, or nil if the engine responded
+}
+
+// WarmUp primes each engine by synthesizing a short phrase with it directly,
+// without fallback to other engines, so that a cold engine does not slow the
+// first real request. Engines are warmed up concurrently and the audio is
+// discarded.
+//
+// Parameters:
+//   - engines: Engine names to warm up (voicevox, kokoro, mlx-audio)
+//
+// Returns one result per engine, in the order given.
+func (s *TTSService) WarmUp(engines []string) []WarmupResult {
+	results := make([]WarmupResult, len(engines))
+
+	var wg sync.WaitGroup
+	for i, engine := range engines {
+		wg.Add(1)
+		go func(i int, engine string) {
+			defer wg.Done()
+			engine = strings.TrimSpace(engine)
+			start := time.Now()
+			err := s.warmUpEngine(engine)
+			results[i] = WarmupResult{Engine: engine, Duration: time.Since(start), Err: err}
+		}(i, engine)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// warmUpEngine synthesizes the engine's warm-up phrase into a temporary file
+func (s *TTSService) warmUpEngine(engine string) error {
+	req, ok := warmupRequests[engine]
+	if !ok {
+		return fmt.Errorf("unknown TTS engine %q", engine)
+	}
+
+	dir, err := os.MkdirTemp("", "tts-warmup-")
+	if err != nil {
+		return fmt.Errorf("failed to create warm-up directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	outputPath := filepath.Join(dir, "warmup.wav")
+
+	switch engine {
+	case "voicevox":
+		return s.generateVoicevoxAudio(req, outputPath)
+	case "kokoro":
+		return s.generateKokoroAudio(req, outputPath)
+	default:
+		return s.generateMLXAudio(req, outputPath)
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// wavFormat describes the sample layout of PCM WAV audio
+type wavFormat struct {
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+}
+
+// parseWAV reads the fmt and data chunks of a RIFF WAV file, skipping any others
+func parseWAV(data []byte) (wavFormat, []byte, error) {
+	var format wavFormat
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return format, nil, errors.New("not a RIFF WAVE file")
+	}
+
+	var samples []byte
+	var hasFormat, hasData bool
+	for offset := 12; offset+8 <= len(data); {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		start := offset + 8
+		end := start + chunkSize
+		if chunkSize < 0 || end > len(data) {
+			// Streaming encoders may leave the data size unset; use the rest of the file
+			if chunkID != "data" {
+				return format, nil, fmt.Errorf("truncated %q chunk", chunkID)
+			}
+			end = len(data)
+		}
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize < 16 {
+				return format, nil, errors.New("fmt chunk is too short")
+			}
+			if err := binary.Read(bytes.NewReader(data[start:start+16]), binary.LittleEndian, &format); err != nil {
+				return format, nil, fmt.Errorf("failed to read fmt chunk: %w", err)
+			}
+			hasFormat = true
+		case "data":
+			samples = data[start:end]
+			hasData = true
+		}
+
+		// Chunks are padded to an even size
+		offset = end + chunkSize%2
+	}
+
+	if !hasFormat || !hasData {
+		return format, nil, errors.New("missing fmt or data chunk")
+	}
+	if format.BlockAlign == 0 || format.ByteRate == 0 {
+		return format, nil, errors.New("invalid WAV format")
+	}
+	return format, samples, nil
+}
+
+// encodeWAV writes sample data as a canonical 44-byte-header WAV file
+func encodeWAV(format wavFormat, samples []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, uint32(36+len(samples)))
+	b.WriteString("WAVEfmt ")
+	binary.Write(&b, binary.LittleEndian, uint32(16))
+	binary.Write(&b, binary.LittleEndian, format)
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, uint32(len(samples)))
+	b.Write(samples)
+	return b.Bytes()
+}
+
+// silence returns sample data for duration d of silence in the given format
+func silence(format wavFormat, d time.Duration) []byte {
+	frames := int64(d) * int64(format.SampleRate) / int64(time.Second)
+	data := make([]byte, frames*int64(format.BlockAlign))
+	// Unsigned 8-bit PCM is centred on 128 rather than 0
+	if format.BitsPerSample == 8 {
+		for i := range data {
+			data[i] = 0x80
+		}
+	}
+	return data
+}
+
+// WAVDuration returns the playing time of a WAV file
+func WAVDuration(data []byte) (time.Duration, error) {
+	format, samples, err := parseWAV(data)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(len(samples)) * time.Second / time.Duration(format.ByteRate), nil
+}
+
+// PadWAV surrounds the audio of a WAV file with silence so that players which
+// clip the first or last moments of a file do not cut off speech.
+//
+// Parameters:
+//   - data: The complete WAV file
+//   - leading: Silence inserted before the audio
+//   - trailing: Silence appended after the audio
+//
+// Returns the padded WAV file, or an error if data is not a valid WAV file.
+func PadWAV(data []byte, leading, trailing time.Duration) ([]byte, error) {
+	format, samples, err := parseWAV(data)
+	if err != nil {
+		return nil, err
+	}
+
+	padded := make([]byte, 0, len(samples))
+	padded = append(padded, silence(format, leading)...)
+	padded = append(padded, samples...)
+	padded = append(padded, silence(format, trailing)...)
+	return encodeWAV(format, padded), nil
+}
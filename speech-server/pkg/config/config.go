@@ -4,10 +4,20 @@
 package config
 
 import (
+	"encoding/json"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// DefaultMaxAudioBytes is the default limit on the size of a synthesized audio file
+const DefaultMaxAudioBytes = 50 << 20
+
+// DefaultMaxBatchItems is the default limit on the number of items in one
+// batch synthesis request
+const DefaultMaxBatchItems = 10
+
 // Config holds all configuration values for the Speech MCP Server.
 // It includes TTS engine settings, audio parameters, external API configuration,
 // and server operation settings.
@@ -31,6 +41,41 @@ type Config struct {
 	SampleRate  int    // Audio sample rate in Hz
 	BitRate     int    // Audio bit rate for compressed formats
 
+	// Per-engine speed sensitivity. Engines interpret their speed parameter
+	// differently, so the deviation of a requested speed from 1.0 is multiplied
+	// by the engine's sensitivity before it is sent to the engine.
+	VoicevoxSpeedSensitivity float64 // Applied to VOICEVOX speedScale
+	KokoroSpeedSensitivity   float64 // Applied to Kokoro TTS speed
+	MLXSpeedSensitivity      float64 // Applied to MLX-Audio speed
+
+	// Engine warm-up. Each listed engine (voicevox, kokoro, mlx-audio) synthesizes
+	// a short phrase at startup so the first real request is not slowed by a cold
+	// engine. An empty list disables warm-up.
+	WarmupEngines []string
+
+	// MaxAudioBytes caps the size of audio accepted from an engine so that a
+	// misbehaving engine cannot fill the cache disk
+	MaxAudioBytes int64
+
+	// MaxBatchItems caps the number of items in one batch synthesis request,
+	// since a batch is synthesized within a single HTTP request
+	MaxBatchItems int
+
+	// Extra fields merged into each engine's synthesis request body, keyed by
+	// engine (voicevox, kokoro, mlx-audio). Configured fields replace built-in
+	// ones, so a renamed or changed engine API field can be fixed without a
+	// code change.
+	EngineExtraPayloads map[string]map[string]interface{}
+
+	// Silence added before and after synthesized WAV audio, for players that
+	// clip the start or end of a file
+	LeadingSilence  time.Duration
+	TrailingSilence time.Duration
+
+	// ShutdownTimeout bounds how long in-flight requests may run after a
+	// shutdown signal before partial cache files are cleaned up
+	ShutdownTimeout time.Duration
+
 	// CORS configuration for cross-origin requests
 	CORSOrigins []string // List of allowed origins for CORS requests
 }
@@ -42,8 +87,8 @@ type Config struct {
 // Returns a fully configured Config struct with all fields populated
 // from environment variables or their default values.
 func Load() *Config {
-	return &Config{
-		Port:        getEnv("PORT", "3001"),
+	cfg := &Config{
+		Port:        getEnv("PORT", "3002"),
 		Environment: getEnv("NODE_ENV", "development"),
 		TTSEngine:   getEnv("TTS_ENGINE", "go-tts"),
 		Language:    getEnv("LANGUAGE", "ja"),
@@ -54,8 +99,29 @@ func Load() *Config {
 		AudioFormat: getEnv("AUDIO_FORMAT", "wav"),
 		SampleRate:  getEnvInt("SAMPLE_RATE", 22050),
 		BitRate:     getEnvInt("BIT_RATE", 128),
+		VoicevoxSpeedSensitivity: getEnvFloat("VOICEVOX_SPEED_SENSITIVITY", 1.0),
+		KokoroSpeedSensitivity:   getEnvFloat("KOKORO_SPEED_SENSITIVITY", 0.8),
+		MLXSpeedSensitivity:      getEnvFloat("MLX_SPEED_SENSITIVITY", 0.8),
+		WarmupEngines:            getEnvAsSlice("WARMUP_ENGINES", nil),
+		MaxAudioBytes:            getEnvInt64("MAX_AUDIO_BYTES", DefaultMaxAudioBytes),
+		MaxBatchItems:            getEnvInt("MAX_BATCH_ITEMS", DefaultMaxBatchItems),
 		CORSOrigins: getEnvAsSlice("CORS_ORIGINS", []string{"http://localhost:3003"}),
+		EngineExtraPayloads: make(map[string]map[string]interface{}),
+		LeadingSilence:      getEnvMilliseconds("AUDIO_LEADING_SILENCE_MS"),
+		TrailingSilence:     getEnvMilliseconds("AUDIO_TRAILING_SILENCE_MS"),
+		ShutdownTimeout:     time.Duration(getEnvInt64("SHUTDOWN_TIMEOUT_SECONDS", 30)) * time.Second,
+	}
+
+	for engine, key := range map[string]string{
+		"voicevox":  "VOICEVOX_EXTRA_PAYLOAD",
+		"kokoro":    "KOKORO_EXTRA_PAYLOAD",
+		"mlx-audio": "MLX_EXTRA_PAYLOAD",
+	} {
+		if extra := getEnvJSONObject(key); extra != nil {
+			cfg.EngineExtraPayloads[engine] = extra
+		}
 	}
+	return cfg
 }
 
 // getEnvAsSlice converts a comma-separated environment variable into a string slice.
@@ -117,4 +183,73 @@ func getEnvInt(key string, defaultValue int) int {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getEnvFloat retrieves a positive floating-point environment variable with a
+// fallback default used when the variable is unset or not a positive number.
+//
+// Parameters:
+//   - key: the environment variable name to retrieve
+//   - defaultValue: the value to return if the variable is unset or invalid
+//
+// Returns the parsed value or the default value.
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvInt64 retrieves a positive 64-bit integer environment variable with a
+// fallback default used when the variable is unset or not a positive number.
+//
+// Parameters:
+//   - key: the environment variable name to retrieve
+//   - defaultValue: the value to return if the variable is unset or invalid
+//
+// Returns the parsed value or the default value.
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvJSONObject retrieves an environment variable holding a JSON object,
+// such as {"format": "wav", "sample_rate": 24000}.
+//
+// Parameters:
+//   - key: the environment variable name to retrieve
+//
+// Returns the decoded object, or nil if the variable is unset or not a JSON object.
+func getEnvJSONObject(key string) map[string]interface{} {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return nil
+	}
+	return object
+}
+
+// getEnvMilliseconds retrieves a non-negative number of milliseconds from an
+// environment variable as a duration.
+//
+// Parameters:
+//   - key: the environment variable name to retrieve
+//
+// Returns the duration, or 0 if the variable is unset or not a non-negative integer.
+func getEnvMilliseconds(key string) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			return time.Duration(parsed) * time.Millisecond
+		}
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"speech-mcp-server/pkg/config"
+)
+
+// TestConfig_DefaultPort tests that the speech server defaults to port 3002,
+// matching the backend's MCP_SPEECH_URL default and avoiding the Backlog bridge port
+func TestConfig_DefaultPort(t *testing.T) {
+	t.Setenv("PORT", "")
+
+	cfg := config.Load()
+	if cfg.Port != "3002" {
+		t.Errorf("Expected default port 3002, got %s", cfg.Port)
+	}
+}
+
+// TestConfig_PortOverride tests that the PORT environment variable overrides the default
+func TestConfig_PortOverride(t *testing.T) {
+	t.Setenv("PORT", "4000")
+
+	cfg := config.Load()
+	if cfg.Port != "4000" {
+		t.Errorf("Expected port 4000 from environment, got %s", cfg.Port)
+	}
+}
+
+// TestConfig_EngineExtraPayloads tests that per-engine extra payloads are read
+// from JSON environment variables and invalid JSON is ignored
+func TestConfig_EngineExtraPayloads(t *testing.T) {
+	t.Setenv("KOKORO_EXTRA_PAYLOAD", `{"format": "mp3"}`)
+	t.Setenv("MLX_EXTRA_PAYLOAD", `not json`)
+	t.Setenv("VOICEVOX_EXTRA_PAYLOAD", "")
+
+	cfg := config.Load()
+	if got := cfg.EngineExtraPayloads["kokoro"]["format"]; got != "mp3" {
+		t.Errorf("Expected Kokoro extra format mp3, got %v", got)
+	}
+	if _, exists := cfg.EngineExtraPayloads["mlx-audio"]; exists {
+		t.Error("Expected invalid MLX extra payload to be ignored")
+	}
+	if _, exists := cfg.EngineExtraPayloads["voicevox"]; exists {
+		t.Error("Expected no VOICEVOX extra payload when unset")
+	}
+}
+
+// TestConfig_AudioSilence tests that leading and trailing silence are read in
+// milliseconds and invalid values disable padding
+func TestConfig_AudioSilence(t *testing.T) {
+	t.Setenv("AUDIO_LEADING_SILENCE_MS", "300")
+	t.Setenv("AUDIO_TRAILING_SILENCE_MS", "-5")
+
+	cfg := config.Load()
+	if cfg.LeadingSilence != 300*time.Millisecond {
+		t.Errorf("Expected 300ms leading silence, got %v", cfg.LeadingSilence)
+	}
+	if cfg.TrailingSilence != 0 {
+		t.Errorf("Expected no trailing silence for an invalid value, got %v", cfg.TrailingSilence)
+	}
+}
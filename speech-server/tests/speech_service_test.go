@@ -1,7 +1,25 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"speech-mcp-server/internal/handlers"
+	"speech-mcp-server/internal/models"
+	"speech-mcp-server/internal/services"
+	"speech-mcp-server/pkg/config"
+
+	"github.com/gin-gonic/gin"
 )
 
 // TestSpeechService_AudioFormats tests supported audio formats
@@ -73,20 +91,12 @@ func TestSpeechService_TextValidation(t *testing.T) {
 			isValid := tc.text != "" && len(tc.text) <= 5000 // Assuming 5KB limit
 			
 			if isValid != tc.valid {
-				t.Errorf("Expected validity %v, got %v for text: %s", tc.valid, isValid, tc.text[:min(50, len(tc.text))])
+				t.Errorf("Expected validity %v, got %v for text: %s", tc.valid, isValid, services.TruncateText(tc.text, 50))
 			}
 		})
 	}
 }
 
-// min helper function for Go versions that don't have it built-in
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 // TestSpeechService_VoiceConfiguration tests voice configuration parameters
 func TestSpeechService_VoiceConfiguration(t *testing.T) {
 	testCases := []struct {
@@ -203,4 +213,596 @@ func hasExtension(filename, ext string) bool {
 		return false
 	}
 	return filename[len(filename)-len(ext):] == ext
-}
\ No newline at end of file
+}
+
+// TestTruncateText_MultibyteSafe tests that truncating multibyte text for logging
+// never splits a rune
+func TestTruncateText_MultibyteSafe(t *testing.T) {
+	text := "これは音声合成のための日本語テキストです。文字の途中で切れてはいけません。さらに長い文章を続けます。"
+
+	truncated := services.TruncateText(text, 50)
+	if !utf8.ValidString(truncated) {
+		t.Fatalf("Truncated text is not valid UTF-8: %q", truncated)
+	}
+	if utf8.RuneCountInString(truncated) != 50 {
+		t.Errorf("Expected 50 runes, got %d", utf8.RuneCountInString(truncated))
+	}
+
+	if short := services.TruncateText("日本語テキスト", 5); short != "日本語テキ" {
+		t.Errorf("Expected first 5 characters, got %q", short)
+	}
+	if same := services.TruncateText("short", 50); same != "short" {
+		t.Errorf("Expected short text to be unchanged, got %q", same)
+	}
+}
+
+// TestTTSService_EngineSpeedNormalization tests that the same request speed is
+// scaled differently per engine according to each engine's sensitivity
+func TestTTSService_EngineSpeedNormalization(t *testing.T) {
+	service := services.NewTTSService(&config.Config{
+		CacheDir:                 t.TempDir(),
+		VoicevoxSpeedSensitivity: 1.0,
+		KokoroSpeedSensitivity:   0.5,
+		MLXSpeedSensitivity:      0.8,
+	})
+
+	testCases := []struct {
+		engine   string
+		speed    float32
+		expected float64
+	}{
+		{engine: "voicevox", speed: 1.2, expected: 1.2},
+		{engine: "kokoro", speed: 1.2, expected: 1.1},
+		{engine: "mlx-audio", speed: 1.2, expected: 1.16},
+		{engine: "kokoro", speed: 0.8, expected: 0.9},
+		{engine: "voicevox", speed: 0, expected: 1.0},
+		{engine: "voicevox", speed: 4.0, expected: 2.0},
+		{engine: "voicevox", speed: 0.25, expected: 0.5},
+	}
+
+	for _, tc := range testCases {
+		got := service.EngineSpeed(tc.engine, tc.speed)
+		if math.Abs(got-tc.expected) > 1e-6 {
+			t.Errorf("EngineSpeed(%s, %v) = %v, expected %v", tc.engine, tc.speed, got, tc.expected)
+		}
+	}
+
+	if service.EngineSpeed("voicevox", 1.2) == service.EngineSpeed("kokoro", 1.2) {
+		t.Error("Expected engines with different sensitivities to receive different speeds")
+	}
+}
+
+// TestTTSService_ReportsFallbackEngine tests that the response names the engine
+// that actually synthesized the audio when VOICEVOX fails over to Kokoro
+func TestTTSService_ReportsFallbackEngine(t *testing.T) {
+	voicevox := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "engine unavailable", http.StatusServiceUnavailable)
+	}))
+	defer voicevox.Close()
+
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tts":
+			json.NewEncoder(w).Encode(map[string]string{"audio_url": "/audio/test.wav"})
+		case "/audio/test.wav":
+			w.Write([]byte("RIFF-test-audio"))
+		}
+	}))
+	defer kokoro.Close()
+
+	t.Setenv("TTS_ENGINE", "voicevox")
+	t.Setenv("VOICEVOX_ENGINE_URL", voicevox.URL)
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+	service := services.NewTTSService(&config.Config{
+		CacheDir:    t.TempDir(),
+		AudioFormat: "wav",
+	})
+
+	response, err := service.SynthesizeSpeech(models.SpeechRequest{Text: "こんにちは", Language: "ja"})
+	if err != nil {
+		t.Fatalf("SynthesizeSpeech failed: %v", err)
+	}
+	if response.Engine != "kokoro" {
+		t.Errorf("Expected engine kokoro after VOICEVOX fallback, got %q", response.Engine)
+	}
+	if response.CacheHit {
+		t.Error("Expected freshly synthesized audio not to be a cache hit")
+	}
+}
+
+// TestTTSService_WarmUpCallsEachEngineOnce tests that warm-up synthesizes once
+// with every configured engine and reports unknown engines as failures
+func TestTTSService_WarmUpCallsEachEngineOnce(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	record := func(engine string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[engine]++
+	}
+
+	voicevox := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/audio_query":
+			w.Write([]byte(`{"speedScale": 1.0}`))
+		case "/synthesis":
+			record("voicevox")
+			w.Write([]byte("RIFF-voicevox"))
+		}
+	}))
+	defer voicevox.Close()
+
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tts":
+			record("kokoro")
+			json.NewEncoder(w).Encode(map[string]string{"audio_url": "/audio/warmup.wav"})
+		case "/audio/warmup.wav":
+			w.Write([]byte("RIFF-kokoro"))
+		}
+	}))
+	defer kokoro.Close()
+
+	mlx := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tts" {
+			record("mlx-audio")
+			w.Write([]byte("RIFF-mlx"))
+		}
+	}))
+	defer mlx.Close()
+
+	t.Setenv("VOICEVOX_ENGINE_URL", voicevox.URL)
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+	t.Setenv("MLX_AUDIO_URL", mlx.URL)
+
+	service := services.NewTTSService(&config.Config{CacheDir: t.TempDir(), AudioFormat: "wav"})
+	results := service.WarmUp([]string{"voicevox", " kokoro", "mlx-audio", "unknown"})
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 warm-up results, got %d", len(results))
+	}
+	for _, result := range results[:3] {
+		if result.Err != nil {
+			t.Errorf("Expected %s warm-up to succeed, got %v", result.Engine, result.Err)
+		}
+	}
+	if results[3].Err == nil {
+		t.Error("Expected warm-up of an unknown engine to fail")
+	}
+	for _, engine := range []string{"voicevox", "kokoro", "mlx-audio"} {
+		if calls[engine] != 1 {
+			t.Errorf("Expected %s to be called once, got %d", engine, calls[engine])
+		}
+	}
+}
+
+// TestTTSService_RejectsOversizedAudio tests that engine output larger than
+// MaxAudioBytes is rejected and not left behind in the cache
+func TestTTSService_RejectsOversizedAudio(t *testing.T) {
+	voicevox := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/audio_query":
+			json.NewEncoder(w).Encode(map[string]interface{}{"speedScale": 1.0})
+		case "/synthesis":
+			w.Write(bytes.Repeat([]byte("A"), 1024))
+		}
+	}))
+	defer voicevox.Close()
+
+	unavailable := httptest.NewServer(http.NotFoundHandler())
+	unavailable.Close()
+
+	t.Setenv("TTS_ENGINE", "voicevox")
+	t.Setenv("VOICEVOX_ENGINE_URL", voicevox.URL)
+	t.Setenv("KOKORO_TTS_URL", unavailable.URL)
+	t.Setenv("MLX_AUDIO_URL", unavailable.URL)
+
+	cacheDir := t.TempDir()
+	service := services.NewTTSService(&config.Config{
+		CacheDir:      cacheDir,
+		AudioFormat:   "wav",
+		MaxAudioBytes: 512,
+	})
+
+	if _, err := service.SynthesizeSpeech(models.SpeechRequest{Text: "こんにちは", Language: "ja"}); err == nil {
+		t.Fatal("Expected oversized engine output to be rejected")
+	}
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatalf("Failed to read cache directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no cached audio after rejection, found %d files", len(entries))
+	}
+}
+
+// TestTTSService_MergesEngineExtraPayload tests that configured extra fields
+// appear in the outgoing MLX-Audio and Kokoro payloads, replacing built-in ones
+func TestTTSService_MergesEngineExtraPayload(t *testing.T) {
+	var mu sync.Mutex
+	payloads := make(map[string]map[string]interface{})
+	capture := func(engine string, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		defer mu.Unlock()
+		payloads[engine] = payload
+	}
+
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tts":
+			capture("kokoro", r)
+			json.NewEncoder(w).Encode(map[string]string{"audio_url": "/audio/test.wav"})
+		case "/audio/test.wav":
+			w.Write([]byte("RIFF-kokoro"))
+		}
+	}))
+	defer kokoro.Close()
+
+	mlx := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tts" {
+			capture("mlx-audio", r)
+			w.Write([]byte("RIFF-mlx"))
+		}
+	}))
+	defer mlx.Close()
+
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+	t.Setenv("MLX_AUDIO_URL", mlx.URL)
+
+	service := services.NewTTSService(&config.Config{
+		CacheDir:    t.TempDir(),
+		AudioFormat: "wav",
+		EngineExtraPayloads: map[string]map[string]interface{}{
+			"kokoro":    {"format": "pcm", "lang_code": "a"},
+			"mlx-audio": {"sample_rate": float64(24000)},
+		},
+	})
+	for _, result := range service.WarmUp([]string{"kokoro", "mlx-audio"}) {
+		if result.Err != nil {
+			t.Fatalf("Expected %s synthesis to succeed, got %v", result.Engine, result.Err)
+		}
+	}
+
+	if got := payloads["kokoro"]; got["format"] != "pcm" || got["lang_code"] != "a" || got["text"] == nil {
+		t.Errorf("Expected Kokoro payload with configured fields, got %v", got)
+	}
+	if got := payloads["mlx-audio"]; got["sample_rate"] != float64(24000) || got["format"] != "wav" {
+		t.Errorf("Expected MLX-Audio payload with configured and built-in fields, got %v", got)
+	}
+}
+
+// TestTTSService_KokoroResponseShapes tests that Kokoro audio is saved both when
+// the engine returns JSON with an audio_url and when it returns audio directly
+func TestTTSService_KokoroResponseShapes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "JSON with audio_url",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/tts":
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(map[string]string{"audio_url": "/audio/test.wav"})
+				case "/audio/test.wav":
+					w.Header().Set("Content-Type", "audio/wav")
+					w.Write([]byte("RIFF-kokoro"))
+				}
+			},
+		},
+		{
+			name: "direct audio",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/tts" {
+					w.Header().Set("Content-Type", "audio/wav")
+					w.Write([]byte("RIFF-kokoro"))
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kokoro := httptest.NewServer(tc.handler)
+			defer kokoro.Close()
+			t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+			cacheDir := t.TempDir()
+			service := services.NewTTSService(&config.Config{CacheDir: cacheDir, AudioFormat: "wav"})
+			response, err := service.SynthesizeSpeech(models.SpeechRequest{Text: "Hello there", Language: "en"})
+			if err != nil {
+				t.Fatalf("Expected synthesis to succeed, got %v", err)
+			}
+			if response.Engine != "kokoro" {
+				t.Errorf("Expected the kokoro engine, got %q", response.Engine)
+			}
+
+			entries, err := os.ReadDir(cacheDir)
+			if err != nil || len(entries) != 1 {
+				t.Fatalf("Expected one cached audio file, got %d (%v)", len(entries), err)
+			}
+			audio, _ := os.ReadFile(filepath.Join(cacheDir, entries[0].Name()))
+			if string(audio) != "RIFF-kokoro" {
+				t.Errorf("Expected the engine audio to be saved, got %q", audio)
+			}
+		})
+	}
+}
+
+// newTestWAV returns a 16 kHz mono 16-bit PCM WAV file of duration d
+func newTestWAV(d time.Duration) []byte {
+	const sampleRate = 16000
+	samples := make([]byte, int(d.Seconds()*sampleRate)*2)
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, uint32(36+len(samples)))
+	b.WriteString("WAVEfmt ")
+	for _, field := range []interface{}{uint32(16), uint16(1), uint16(1), uint32(sampleRate), uint32(sampleRate * 2), uint16(2), uint16(16)} {
+		binary.Write(&b, binary.LittleEndian, field)
+	}
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, uint32(len(samples)))
+	b.Write(samples)
+	return b.Bytes()
+}
+
+// TestTTSService_PadsAudioWithSilence tests that the configured leading and
+// trailing silence lengthen the synthesized WAV by exactly that amount
+func TestTTSService_PadsAudioWithSilence(t *testing.T) {
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(newTestWAV(time.Second))
+	}))
+	defer kokoro.Close()
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+	cacheDir := t.TempDir()
+	service := services.NewTTSService(&config.Config{
+		CacheDir:        cacheDir,
+		AudioFormat:     "wav",
+		LeadingSilence:  250 * time.Millisecond,
+		TrailingSilence: 500 * time.Millisecond,
+	})
+	response, err := service.SynthesizeSpeech(models.SpeechRequest{Text: "Hello there", Language: "en"})
+	if err != nil {
+		t.Fatalf("Expected synthesis to succeed, got %v", err)
+	}
+
+	audio, err := os.ReadFile(filepath.Join(cacheDir, filepath.Base(response.AudioURL)))
+	if err != nil {
+		t.Fatalf("Failed to read cached audio: %v", err)
+	}
+	duration, err := services.WAVDuration(audio)
+	if err != nil {
+		t.Fatalf("Expected a valid WAV file, got %v", err)
+	}
+	if want := 1750 * time.Millisecond; duration != want {
+		t.Errorf("Expected padded duration %v, got %v", want, duration)
+	}
+}
+
+// TestTTSService_CachedAudioIsReadable tests that audio moved into the cache
+// from its private partial file is readable by other users
+func TestTTSService_CachedAudioIsReadable(t *testing.T) {
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(newTestWAV(time.Second))
+	}))
+	defer kokoro.Close()
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+	cacheDir := t.TempDir()
+	service := services.NewTTSService(&config.Config{CacheDir: cacheDir, AudioFormat: "wav"})
+	response, err := service.SynthesizeSpeech(models.SpeechRequest{Text: "Readable audio", Language: "en"})
+	if err != nil {
+		t.Fatalf("Expected synthesis to succeed, got %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(cacheDir, filepath.Base(response.AudioURL)))
+	if err != nil {
+		t.Fatalf("Failed to stat cached audio: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0644 {
+		t.Errorf("Expected cached audio with mode 0644, got %v", perm)
+	}
+}
+
+// TestTTSService_DurationIncludesSilenceOnlyWhenPadded tests that the reported
+// duration includes the configured silence for padded WAV audio, on synthesis
+// and on a cache hit, but not for audio in other formats, which is not padded
+func TestTTSService_DurationIncludesSilenceOnlyWhenPadded(t *testing.T) {
+	audio := newTestWAV(time.Second)
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(audio)
+	}))
+	defer kokoro.Close()
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+	synthesize := func(padded bool) []time.Duration {
+		t.Helper()
+		cfg := &config.Config{CacheDir: t.TempDir(), AudioFormat: "wav"}
+		if padded {
+			cfg.LeadingSilence = 250 * time.Millisecond
+			cfg.TrailingSilence = 500 * time.Millisecond
+		}
+		service := services.NewTTSService(cfg)
+		var durations []time.Duration
+		for i := 0; i < 2; i++ {
+			response, err := service.SynthesizeSpeech(models.SpeechRequest{Text: "Hello there", Language: "en"})
+			if err != nil {
+				t.Fatalf("Expected synthesis to succeed, got %v", err)
+			}
+			durations = append(durations, response.Duration)
+		}
+		return durations
+	}
+
+	plain := synthesize(false)
+	padded := synthesize(true)
+	for i, label := range []string{"synthesis", "cache hit"} {
+		if want := plain[i] + 750*time.Millisecond; padded[i] != want {
+			t.Errorf("Expected the padded WAV duration on %s to be %v, got %v", label, want, padded[i])
+		}
+	}
+
+	audio = []byte("ID3 not a WAV file")
+	notWAV := synthesize(true)
+	for i, label := range []string{"synthesis", "cache hit"} {
+		if notWAV[i] != plain[i] {
+			t.Errorf("Expected unpadded audio on %s to report %v, got %v", label, plain[i], notWAV[i])
+		}
+	}
+}
+
+// TestTTSService_SynthesizeBatchReportsPerItemErrors tests that an unsupported
+// language fails only its own item while the rest of the batch is synthesized
+func TestTTSService_SynthesizeBatchReportsPerItemErrors(t *testing.T) {
+	var calls int
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tts" {
+			calls++
+		}
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(newTestWAV(time.Second))
+	}))
+	defer kokoro.Close()
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+	service := services.NewTTSService(&config.Config{CacheDir: t.TempDir(), AudioFormat: "wav"})
+	response := service.SynthesizeBatch([]models.SpeechRequest{
+		{Text: "Hello there", Language: "en"},
+		{Text: "Sawubona", Language: "zu"},
+		{Text: "Hola", Language: "es"},
+	})
+
+	if response.Succeeded != 2 || response.Failed != 1 {
+		t.Fatalf("Expected 2 succeeded and 1 failed, got %d and %d", response.Succeeded, response.Failed)
+	}
+	if len(response.Results) != 3 {
+		t.Fatalf("Expected one result per item, got %d", len(response.Results))
+	}
+	for _, i := range []int{0, 2} {
+		result := response.Results[i]
+		if result.Index != i || result.Result == nil || result.Error != "" {
+			t.Errorf("Expected item %d to succeed, got %+v", i, result)
+		}
+	}
+	failed := response.Results[1]
+	if failed.Result != nil || failed.Language != "zu" || failed.Error != "language 'zu' is not supported" {
+		t.Errorf("Expected item 1 to report the unsupported language, got %+v", failed)
+	}
+	if calls != 2 {
+		t.Errorf("Expected synthesis only for supported languages, got %d calls", calls)
+	}
+}
+
+// TestSpeechHandler_SynthesizeBatchLimitsItems tests that a batch of more than
+// MaxBatchItems items is rejected with 400 before any item is synthesized
+func TestSpeechHandler_SynthesizeBatchLimitsItems(t *testing.T) {
+	var calls int
+	kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(newTestWAV(time.Second))
+	}))
+	defer kokoro.Close()
+	t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSpeechHandler(&config.Config{CacheDir: t.TempDir(), AudioFormat: "wav", MaxBatchItems: 2})
+	router := gin.New()
+	router.POST("/batch", handler.SynthesizeBatch)
+
+	post := func(count int) *httptest.ResponseRecorder {
+		items := make([]models.SpeechRequest, count)
+		for i := range items {
+			items[i] = models.SpeechRequest{Text: "Hello there", Language: "en"}
+		}
+		body, _ := json.Marshal(models.SpeechBatchRequest{Items: items})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/batch", bytes.NewReader(body)))
+		return w
+	}
+
+	w := post(3)
+	if w.Code != http.StatusBadRequest || !bytes.Contains(w.Body.Bytes(), []byte("at most 2 items, got 3")) {
+		t.Errorf("Expected 400 for a batch over the limit, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls != 0 {
+		t.Errorf("Expected no synthesis for a rejected batch, got %d calls", calls)
+	}
+	if w := post(2); w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for a batch at the limit, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestTTSService_FailedSynthesisLeavesNoPartialFile tests that audio rejected
+// mid-write or missing altogether leaves neither the cache entry nor a partial
+// file behind
+func TestTTSService_FailedSynthesisLeavesNoPartialFile(t *testing.T) {
+	cases := map[string][]byte{
+		"oversized": newTestWAV(time.Second),
+		"empty":     {},
+	}
+	for name, audio := range cases {
+		t.Run(name, func(t *testing.T) {
+			kokoro := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "audio/wav")
+				w.Write(audio)
+			}))
+			defer kokoro.Close()
+			t.Setenv("KOKORO_TTS_URL", kokoro.URL)
+
+			cacheDir := t.TempDir()
+			service := services.NewTTSService(&config.Config{CacheDir: cacheDir, AudioFormat: "wav", MaxAudioBytes: 1024})
+			if _, err := service.SynthesizeSpeech(models.SpeechRequest{Text: "Hello there", Language: "en"}); err == nil {
+				t.Fatal("Expected synthesis to fail")
+			}
+
+			entries, err := os.ReadDir(cacheDir)
+			if err != nil {
+				t.Fatalf("Failed to read cache directory: %v", err)
+			}
+			for _, entry := range entries {
+				t.Errorf("Expected an empty cache after a failed synthesis, found %s", entry.Name())
+			}
+		})
+	}
+}
+
+// TestCleanPartialCacheFiles tests that shutdown cleanup removes partial and
+// zero-byte files while keeping complete audio
+func TestCleanPartialCacheFiles(t *testing.T) {
+	cacheDir := t.TempDir()
+	files := map[string][]byte{
+		"complete.wav":                 newTestWAV(time.Second),
+		"empty.wav":                    {},
+		"interrupted.wav.1234.partial": []byte("RIFF"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(cacheDir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	removed, err := services.CleanPartialCacheFiles(cacheDir)
+	if err != nil {
+		t.Fatalf("Expected cleanup to succeed, got %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 files removed, got %d", removed)
+	}
+	entries, _ := os.ReadDir(cacheDir)
+	if len(entries) != 1 || entries[0].Name() != "complete.wav" {
+		t.Errorf("Expected only complete.wav to remain, got %v", entries)
+	}
+
+	if removed, err := services.CleanPartialCacheFiles(filepath.Join(cacheDir, "missing")); err != nil || removed != 0 {
+		t.Errorf("Expected a missing cache directory to be ignored, got %d and %v", removed, err)
+	}
+}